@@ -12,19 +12,28 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/apikey"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/audit"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/config"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
-	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/domain/user"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/http"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/idempotency"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/logger"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/module"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/scheduler"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/password"
 )
 
 const (
 	shutdownTimeoutSeconds = 30
+	// purgeInterval 만료된 멱등성 키와 발행된 아웃박스 메시지 정리 주기 / How often expired idempotency keys and published outbox messages are cleaned up
+	purgeInterval = time.Hour
 )
 
 // @title           Spindle API
@@ -45,7 +54,7 @@ const (
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
 // @name Authorization
-// @description Type "Bearer" followed by a space and JWT token.
+// @description Type "Bearer" followed by a space and an API key or JWT.
 
 func main() {
 	// 헬스 체크 모드 / Health check mode
@@ -60,7 +69,16 @@ func main() {
 	database := setupDatabase(cfg)
 	defer closeDatabase(database)
 
-	app := setupServer(cfg, database)
+	modules := setupModules(cfg, database)
+	migrateDatabase(database, modules)
+
+	app := setupServer(cfg, database, modules)
+
+	publisher, closePublisher := setupOutboxPublisher(cfg)
+	defer closePublisher()
+
+	jobs := startScheduler(cfg, database, publisher, modules)
+	defer jobs.Stop()
 
 	runServerWithGracefulShutdown(app, cfg)
 }
@@ -118,12 +136,41 @@ func setupDatabase(cfg *config.Config) *gorm.DB {
 		zap.L().Fatal("Failed to get underlying sql.DB", zap.Error(err))
 	}
 
-	// Auto-migrate 테이블 / Auto-migrate tables
-	if err := database.AutoMigrate(&user.User{}); err != nil {
-		zap.L().Fatal("Failed to auto-migrate database", zap.Error(err))
+	return database
+}
+
+// setupModules 등록된 도메인 모듈 생성 / Build the registered domain modules
+// 모듈은 modules.go의 빈 import로 등록됨 / Modules are registered by the blank imports in modules.go
+func setupModules(cfg *config.Config, database *gorm.DB) []module.Module {
+	modules := module.Build(module.Deps{
+		Config: cfg,
+		DB:     database,
+		Tx: db.NewTxManager(database, db.TxConfig{
+			MaxAttempts: cfg.DBTxMaxAttempts,
+			Backoff:     cfg.DBTxRetryBackoff,
+		}),
+		Passwords: password.NewHasher(cfg.PasswordParams(), cfg.PasswordMaxConcurrent),
+	})
+
+	names := make([]string, len(modules))
+	for i, m := range modules {
+		names[i] = m.Name()
 	}
+	zap.L().Info("Domain modules loaded", zap.Strings("modules", names))
 
-	return database
+	return modules
+}
+
+// migrateDatabase 공통 테이블과 모듈 모델 Auto-migrate / Auto-migrate shared tables and module models
+func migrateDatabase(database *gorm.DB, modules []module.Module) {
+	models := []any{&audit.Event{}, &idempotency.Record{}, &scheduler.Lease{}, &outbox.Message{}, &apikey.Key{}}
+	for _, m := range modules {
+		models = append(models, m.Models()...)
+	}
+
+	if err := database.AutoMigrate(models...); err != nil {
+		zap.L().Fatal("Failed to auto-migrate database", zap.Error(err))
+	}
 }
 
 // closeDatabase closes the underlying database connection pool during shutdown.
@@ -140,14 +187,100 @@ func closeDatabase(database *gorm.DB) {
 }
 
 // setupServer 서버 설정 / Setup server
-func setupServer(cfg *config.Config, database *gorm.DB) *fiber.App {
+func setupServer(cfg *config.Config, database *gorm.DB, modules []module.Module) *fiber.App {
 	// HTTP 라우터 설정 / Setup HTTP router
-	router := http.NewRouter(cfg, database)
-	router.Setup()
+	router := http.NewRouter(cfg, database, modules)
+	if err := router.Setup(); err != nil {
+		zap.L().Fatal("Failed to set up routes", zap.Error(err))
+	}
 
 	return router.GetApp()
 }
 
+// setupOutboxPublisher 아웃박스 발행기 생성 / Create the outbox publisher
+// 반환된 함수는 스케줄러가 멈춘 뒤 호출 / Call the returned function after the scheduler stops
+func setupOutboxPublisher(cfg *config.Config) (outbox.Publisher, func()) {
+	switch cfg.OutboxPublisher {
+	case "log":
+		return outbox.NewLogPublisher(), func() {}
+	case "file":
+		publisher, err := outbox.NewFilePublisher(cfg.OutboxFilePath)
+		if err != nil {
+			zap.L().Fatal("Failed to create outbox publisher", zap.Error(err))
+		}
+		return publisher, func() {
+			if err := publisher.Close(); err != nil {
+				zap.L().Error("Failed to close outbox publisher", zap.Error(err))
+			}
+		}
+	default:
+		zap.L().Fatal("Unsupported outbox publisher", zap.String("publisher", cfg.OutboxPublisher))
+		return nil, nil
+	}
+}
+
+// startScheduler 백그라운드 작업 시작 / Start background jobs
+// 여러 레플리카에서 실행되어도 작업마다 임대를 가진 하나만 실행 / With several replicas, only the lease holder runs each job
+func startScheduler(cfg *config.Config, database *gorm.DB, publisher outbox.Publisher, modules []module.Module) *scheduler.Scheduler {
+	hostname, _ := os.Hostname()
+	jobs := scheduler.New(scheduler.NewLeaseStore(database), hostname+"-"+uuid.NewString())
+
+	// 모듈 작업 등록, 구독 모듈은 아웃박스 메시지도 받음 / Add module jobs; subscriber modules also receive outbox messages
+	publishers := []outbox.Publisher{publisher}
+	for _, m := range modules {
+		for _, job := range m.Jobs() {
+			jobs.Add(job)
+		}
+		if s, ok := m.(module.Subscriber); ok {
+			if subscriber := s.Subscriber(); subscriber != nil {
+				publishers = append(publishers, subscriber)
+			}
+		}
+	}
+	if len(publishers) > 1 {
+		publisher = outbox.NewMultiPublisher(publishers...)
+	}
+
+	messages := outbox.NewStore(database)
+	relay := outbox.NewRelay(messages, publisher, outbox.RelayConfig{
+		BatchSize:   cfg.OutboxBatchSize,
+		MaxAttempts: cfg.OutboxMaxAttempts,
+		BaseBackoff: cfg.OutboxBaseBackoff,
+		MaxBackoff:  cfg.OutboxMaxBackoff,
+	})
+	jobs.Add(scheduler.Job{
+		Name:     "outbox.relay",
+		Interval: cfg.OutboxRelayInterval,
+		Run: func(ctx context.Context) error {
+			_, err := relay.RunOnce(ctx)
+			return err
+		},
+	})
+	if cfg.OutboxRetention > 0 {
+		jobs.Add(scheduler.Job{
+			Name:     "outbox.purge-published",
+			Interval: purgeInterval,
+			Run: func(context.Context) error {
+				_, err := messages.PurgePublished(time.Now().Add(-cfg.OutboxRetention))
+				return err
+			},
+		})
+	}
+
+	keys := idempotency.NewStore(database)
+	jobs.Add(scheduler.Job{
+		Name:     "idempotency.purge-expired",
+		Interval: purgeInterval,
+		Run: func(context.Context) error {
+			_, err := keys.PurgeExpired(time.Now())
+			return err
+		},
+	})
+
+	jobs.Start(context.Background())
+	return jobs
+}
+
 // runServerWithGracefulShutdown 서버 실행 및 graceful shutdown / Run server with graceful shutdown
 func runServerWithGracefulShutdown(app *fiber.App, cfg *config.Config) {
 	// Graceful shutdown 설정 / Setup graceful shutdown
@@ -201,7 +334,7 @@ func healthCheck() {
 		os.Exit(1)
 	}
 
-	if err := db.HealthCheck(database); err != nil {
+	if err := db.HealthCheck(context.Background(), database); err != nil {
 		fmt.Println("Health check failed: database health check error")
 		os.Exit(1)
 	}
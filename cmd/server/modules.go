@@ -0,0 +1,10 @@
+package main
+
+// 도메인 모듈 등록 / Domain module registration
+// 각 패키지의 init이 module.Register를 호출, 새 도메인은 여기에 한 줄만 추가
+// Each package's init calls module.Register; a new domain only adds one line here
+import (
+	_ "github.com/kyungseok-lee/go-fiber-gorm-starter/internal/domain/auth"
+	_ "github.com/kyungseok-lee/go-fiber-gorm-starter/internal/domain/user"
+	_ "github.com/kyungseok-lee/go-fiber-gorm-starter/internal/domain/webhook"
+)
@@ -0,0 +1,90 @@
+package apikey
+
+import (
+	"sync"
+	"time"
+)
+
+// cache 토큰 해시별 검증된 키 캐시 / Cache of verified keys by token hash
+// 폐기는 이 인스턴스에서 즉시, 다른 인스턴스에서는 TTL 이내에 반영 / Revocations apply at once on this instance and within the TTL elsewhere
+type cache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	key       Key
+	expiresAt time.Time
+}
+
+func newCache(ttl time.Duration, maxEntries int) *cache {
+	return &cache{ttl: ttl, maxEntries: maxEntries, entries: make(map[string]cacheEntry)}
+}
+
+// get 만료되지 않은 항목의 복사본 반환 / Return a copy of an unexpired entry
+func (c *cache) get(hash string, now time.Time) (*Key, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[hash]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(entry.expiresAt) {
+		delete(c.entries, hash)
+		return nil, false
+	}
+	key := entry.key
+	return &key, true
+}
+
+// put 항목 저장, 가득 차면 만료 항목부터 비우고 그래도 차 있으면 임의 항목 제거
+// Store an entry; when full, drop expired entries first and then an arbitrary one
+func (c *cache) put(hash string, key *Key, now time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[hash]; !ok && len(c.entries) >= c.maxEntries {
+		for h, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, h)
+			}
+		}
+		for h := range c.entries {
+			if len(c.entries) < c.maxEntries {
+				break
+			}
+			delete(c.entries, h)
+		}
+	}
+	c.entries[hash] = cacheEntry{key: *key, expiresAt: now.Add(c.ttl)}
+}
+
+// touch 캐시된 키의 마지막 사용 시각 갱신 / Update the last-used time of a cached key
+func (c *cache) touch(hash string, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[hash]; ok {
+		entry.key.LastUsedAt = &at
+		c.entries[hash] = entry
+	}
+}
+
+// forget 키 ID의 모든 항목 제거 / Drop every entry of a key ID
+func (c *cache) forget(id uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for h, entry := range c.entries {
+		if entry.key.ID == id {
+			delete(c.entries, h)
+		}
+	}
+}
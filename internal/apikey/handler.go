@@ -0,0 +1,199 @@
+package apikey
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/resp"
+)
+
+// Handler API 키 관리 HTTP 핸들러 / API key admin HTTP handler
+type Handler struct {
+	service Service
+	// grantedScopes 요청 호출자의 범위, 인증이 꺼져 있으면 nil / Scopes of the calling request; nil when authentication is off
+	grantedScopes func(c *fiber.Ctx) []string
+}
+
+// NewHandler 새 API 키 핸들러 생성 / Create new API key handler
+// grantedScopes는 보통 middleware.GrantedScopes / grantedScopes is usually middleware.GrantedScopes
+func NewHandler(service Service, grantedScopes func(c *fiber.Ctx) []string) *Handler {
+	return &Handler{service: service, grantedScopes: grantedScopes}
+}
+
+// Create 키 생성 / Create key
+// @Summary Create API key
+// @Description Create a scoped API key. The token is only returned here; only its hash is stored. Callers can only grant scopes they hold themselves.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param key body CreateKeyRequest true "API key creation request"
+// @Success 201 {object} resp.SuccessResponse{data=KeyWithToken}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 403 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/admin/api-keys [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req CreateKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return resp.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	key, token, err := h.service.Create(c.UserContext(), &req, h.grantedScopes(c))
+	if err != nil {
+		var validationErr *ValidationError
+		var scopeErr *ScopeError
+		switch {
+		case errors.As(err, &validationErr):
+			return resp.BadRequest(c, validationErr.Message)
+		case errors.As(err, &scopeErr):
+			return scopeNotGranted(c, scopeErr)
+		}
+		zap.L().Error("Failed to create API key", zap.Error(err))
+		return resp.InternalServerError(c, "Failed to create API key")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp.SuccessResponse{
+		Data: KeyWithToken{Key: key, Token: token},
+	})
+}
+
+// List 키 목록 조회 / List keys
+// @Summary List API keys
+// @Tags api-keys
+// @Produce json
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination" default(20)
+// @Success 200 {object} resp.PaginatedResponse{data=[]Key}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/admin/api-keys [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	var query ListQuery
+	if err := c.QueryParser(&query); err != nil {
+		return resp.BadRequest(c, "Invalid query parameters", err.Error())
+	}
+
+	keys, total, err := h.service.List(c.UserContext(), &query)
+	if err != nil {
+		zap.L().Error("Failed to list API keys", zap.Error(err))
+		return resp.InternalServerError(c, "Failed to list API keys")
+	}
+
+	return resp.SuccessWithPagination(c, keys, query.Offset, query.Limit, total)
+}
+
+// GetByID ID로 키 조회 / Get key by ID
+// @Summary Get API key
+// @Tags api-keys
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} resp.SuccessResponse{data=Key}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/admin/api-keys/{id} [get]
+func (h *Handler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return resp.BadRequest(c, "Invalid API key ID")
+	}
+
+	key, err := h.service.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return resp.NotFound(c, "API key not found")
+		}
+		zap.L().Error("Failed to get API key", zap.Error(err), zap.Uint64("key_id", id))
+		return resp.InternalServerError(c, "Failed to get API key")
+	}
+
+	return resp.Success(c, key)
+}
+
+// Revoke 키 폐기 / Revoke key
+// @Summary Revoke API key
+// @Description Revoke the key immediately. Revoking an already revoked key is a no-op.
+// @Tags api-keys
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} resp.SuccessResponse{data=Key}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/admin/api-keys/{id}/revoke [post]
+func (h *Handler) Revoke(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return resp.BadRequest(c, "Invalid API key ID")
+	}
+
+	key, err := h.service.Revoke(c.UserContext(), uint(id))
+	if err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return resp.NotFound(c, "API key not found")
+		}
+		zap.L().Error("Failed to revoke API key", zap.Error(err), zap.Uint64("key_id", id))
+		return resp.InternalServerError(c, "Failed to revoke API key")
+	}
+
+	return resp.Success(c, key)
+}
+
+// Rotate 키 교체 / Rotate key
+// @Summary Rotate API key
+// @Description Issue a replacement with the same name and scopes. The old key keeps working for the overlap window, then expires. Callers must hold every scope of the key.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param id path int true "API key ID"
+// @Param rotation body RotateKeyRequest false "API key rotation request"
+// @Success 201 {object} resp.SuccessResponse{data=KeyWithToken}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 403 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 409 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/admin/api-keys/{id}/rotate [post]
+func (h *Handler) Rotate(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return resp.BadRequest(c, "Invalid API key ID")
+	}
+
+	var req RotateKeyRequest
+	if len(c.Body()) > 0 {
+		if parseErr := c.BodyParser(&req); parseErr != nil {
+			return resp.BadRequest(c, "Invalid request body", parseErr.Error())
+		}
+	}
+
+	key, token, err := h.service.Rotate(c.UserContext(), uint(id), &req, h.grantedScopes(c))
+	if err != nil {
+		var validationErr *ValidationError
+		var scopeErr *ScopeError
+		switch {
+		case errors.As(err, &scopeErr):
+			return scopeNotGranted(c, scopeErr)
+		case errors.Is(err, ErrKeyNotFound):
+			return resp.NotFound(c, "API key not found")
+		case errors.Is(err, ErrKeyInactive):
+			return resp.Conflict(c, "API key is revoked, expired, or already rotated")
+		case errors.As(err, &validationErr):
+			return resp.BadRequest(c, validationErr.Message)
+		}
+		zap.L().Error("Failed to rotate API key", zap.Error(err), zap.Uint64("key_id", id))
+		return resp.InternalServerError(c, "Failed to rotate API key")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp.SuccessResponse{
+		Data: KeyWithToken{Key: key, Token: token},
+	})
+}
+
+// scopeNotGranted 호출자보다 넓은 키 요청 응답 / Response for a key wider than the caller
+func scopeNotGranted(c *fiber.Ctx, err *ScopeError) error {
+	return resp.Forbidden(c, "Cannot grant a scope you do not hold", fiber.Map{"scope": err.Scope})
+}
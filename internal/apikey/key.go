@@ -0,0 +1,195 @@
+// Package apikey stores hashed, scoped API keys and verifies presented tokens against them
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// TokenPrefix 발급 토큰의 고정 접두사 / Fixed prefix of issued tokens
+	TokenPrefix = "spk"
+	// prefixBytes 조회용 키 접두사 바이트 수 / Bytes in the lookup prefix
+	prefixBytes = 6
+	// secretBytes 토큰 비밀 부분 바이트 수 / Bytes in the secret part of a token
+	secretBytes = 32
+)
+
+var (
+	// ErrKeyNotFound is returned when an API key lookup cannot find a matching row.
+	ErrKeyNotFound = errors.New("api key not found")
+
+	// ErrInvalidKey is returned when a presented token is malformed, unknown, revoked or expired.
+	ErrInvalidKey = errors.New("invalid api key")
+
+	// ErrKeyInactive is returned when rotating a key that is already revoked, expired, or rotated.
+	ErrKeyInactive = errors.New("api key is revoked, expired, or already rotated")
+)
+
+// ValidationError 요청 필드 검증 오류 / Request field validation error
+// Message는 클라이언트에 그대로 노출됨 / Message is returned to clients as-is
+type ValidationError struct {
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// ScopeError 호출자가 갖지 않은 범위를 부여하려는 오류 / Error for granting a scope the caller does not hold
+type ScopeError struct {
+	Scope string
+}
+
+// Error implements the error interface.
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("scope %q is not granted to the caller", e.Scope)
+}
+
+// Scopes 키에 부여된 권한 범위 (JSON 텍스트로 저장) / Scopes granted to a key, stored as JSON text
+type Scopes []string
+
+// Value 데이터베이스 저장용 JSON 인코딩 / Encode as JSON for storage
+func (s Scopes) Value() (driver.Value, error) {
+	data, err := json.Marshal([]string(s))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode scopes: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan 데이터베이스 값 디코딩 / Decode a database value
+func (s *Scopes) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	case nil:
+		*s = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported scopes type %T", value)
+	}
+	return json.Unmarshal(data, (*[]string)(s))
+}
+
+// Key API 키 모델, 토큰 원문은 저장하지 않음 / API key model; the plaintext token is never stored
+type Key struct {
+	ID   uint   `json:"id" gorm:"primarykey"`
+	Name string `json:"name" gorm:"not null;size:100"`
+	// Prefix 토큰에 포함된 공개 조회 키 / Public lookup key embedded in the token
+	Prefix string `json:"prefix" gorm:"uniqueIndex;not null;size:32"`
+	// Hash 토큰 전체의 SHA-256 해시 / SHA-256 hash of the whole token
+	Hash   string `json:"-" gorm:"not null;size:64"`
+	Scopes Scopes `json:"scopes" gorm:"type:text;not null"`
+	// RotatedAt 교체된 시각, 키는 한 번만 교체됨 / When the key was rotated; a key is rotated only once
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
+	// RotatedFromID 교체로 생성된 경우 이전 키 / Previous key when created by a rotation
+	RotatedFromID *uint      `json:"rotated_from_id,omitempty"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty" gorm:"index"`
+	LastUsedAt    *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TableName 테이블 이름 지정 / Specify table name
+func (Key) TableName() string {
+	return "api_keys"
+}
+
+// Active 폐기·만료되지 않았는지 확인 / Report whether the key is neither revoked nor expired at now
+func (k *Key) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// CreateKeyRequest 키 생성 요청 / Key creation request
+type CreateKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// ExpiresAt 비우면 만료 없음 / No expiry when empty
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// RotateKeyRequest 키 교체 요청 / Key rotation request
+type RotateKeyRequest struct {
+	// Overlap 이전 키가 계속 동작하는 기간 (예: "24h"), 비우면 설정 기본값 / How long the old key keeps working (e.g. "24h"); the configured default when empty
+	Overlap string `json:"overlap,omitempty"`
+	// ExpiresAt 새 키 만료 시각, 비우면 만료 없음 / Expiry of the new key; no expiry when empty
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// KeyWithToken 토큰 원문을 포함한 생성·교체 응답 / Create and rotate response including the plaintext token
+type KeyWithToken struct {
+	*Key
+	// Token 이 응답에서만 노출 / Only exposed in this response
+	Token string `json:"token"`
+}
+
+// ListQuery 목록 조회 쿼리 / List query
+type ListQuery struct {
+	Offset int `query:"offset"`
+	Limit  int `query:"limit"`
+}
+
+// Validate 쿼리 파라미터 검증 및 기본값 설정 / Validate query parameters and set defaults
+func (q *ListQuery) Validate() {
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 20
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+}
+
+// generateToken 새 토큰과 조회 접두사 생성 / Generate a new token and its lookup prefix
+// 형식: spk_<prefix>_<secret> / Format: spk_<prefix>_<secret>
+func generateToken() (token, prefix string, err error) {
+	buf := make([]byte, prefixBytes+secretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	prefix = hex.EncodeToString(buf[:prefixBytes])
+	secret := base64.RawURLEncoding.EncodeToString(buf[prefixBytes:])
+	return TokenPrefix + "_" + prefix + "_" + secret, prefix, nil
+}
+
+// parseToken 토큰에서 조회 접두사 추출 / Extract the lookup prefix from a token
+func parseToken(token string) (string, bool) {
+	rest, ok := strings.CutPrefix(token, TokenPrefix+"_")
+	if !ok {
+		return "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != hex.EncodedLen(prefixBytes) || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+// IsToken 발급 형식의 토큰인지 확인 / Report whether the value has the issued token format
+func IsToken(token string) bool {
+	_, ok := parseToken(token)
+	return ok
+}
+
+// hashToken 토큰 SHA-256 해시 / SHA-256 hash of a token
+// 토큰은 충분한 무작위성을 가지므로 느린 해시가 필요 없음 / Tokens carry enough entropy that a slow hash is unnecessary
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,314 @@
+package apikey
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+)
+
+const (
+	// WildcardScope 모든 범위를 허용하는 범위 / Scope that grants every other scope
+	WildcardScope = "*"
+
+	maxNameLength = 100
+	// cacheMaxEntries 검증 캐시 최대 항목 수 / Maximum entries in the verification cache
+	cacheMaxEntries = 1024
+	// touchInterval 마지막 사용 시각 기록 최소 간격 / Minimum interval between last-used writes
+	touchInterval = time.Minute
+)
+
+// scopePattern "resource:action" 형식, 각 부분은 *도 허용 / "resource:action" format; either part may be *
+var scopePattern = regexp.MustCompile(`^(\*|[a-z][a-z0-9-]*:(\*|[a-z][a-z0-9-]*))$`)
+
+// Verifier 토큰 검증 인터페이스, 미들웨어가 사용 / Token verification interface used by the middleware
+type Verifier interface {
+	// Verify 활성 키를 반환, 잘못되었거나 폐기·만료된 토큰은 ErrInvalidKey
+	// Return the active key; malformed, unknown, revoked or expired tokens yield ErrInvalidKey
+	Verify(ctx context.Context, token string) (*Key, error)
+}
+
+// Service API 키 서비스 인터페이스 / API key service interface
+type Service interface {
+	Verifier
+	// Create 키 생성, 토큰 원문은 이때만 반환 / Create a key; the plaintext token is only returned here
+	// granted는 호출자의 범위로, 이를 넘는 범위는 *ScopeError. nil이면 제한 없음 (인증 꺼짐)
+	// granted holds the caller's scopes and anything beyond them yields *ScopeError; nil means unrestricted (authentication off)
+	Create(ctx context.Context, req *CreateKeyRequest, granted []string) (*Key, string, error)
+	GetByID(ctx context.Context, id uint) (*Key, error)
+	List(ctx context.Context, query *ListQuery) ([]*Key, int64, error)
+	Revoke(ctx context.Context, id uint) (*Key, error)
+	// Rotate 같은 이름과 범위의 새 키 발급, 이전 키는 겹침 기간 후 만료
+	// Issue a new key with the same name and scopes; the old key expires after the overlap window
+	// 호출자는 키의 범위를 모두 가져야 하며, 키는 한 번만 교체됨 / The caller must hold every scope of the key, and a key is rotated only once
+	Rotate(ctx context.Context, id uint, req *RotateKeyRequest, granted []string) (*Key, string, error)
+}
+
+// ServiceConfig API 키 서비스 설정 / API key service settings
+type ServiceConfig struct {
+	// CacheTTL 검증된 키 캐시 기간, 0이면 캐시하지 않음 / How long verified keys are cached; 0 disables the cache
+	CacheTTL time.Duration
+	// RotationOverlap 요청에 겹침 기간이 없을 때 기본값 / Default overlap when a rotation request has none
+	RotationOverlap time.Duration
+}
+
+// service API 키 서비스 구현체 / API key service implementation
+type service struct {
+	store Store
+	tx    db.TxManager
+	cfg   ServiceConfig
+	cache *cache
+	now   func() time.Time
+}
+
+// NewService 새 API 키 서비스 생성 / Create new API key service
+func NewService(store Store, tx db.TxManager, cfg ServiceConfig) Service {
+	return &service{
+		store: store,
+		tx:    tx,
+		cfg:   cfg,
+		cache: newCache(cfg.CacheTTL, cacheMaxEntries),
+		now:   time.Now,
+	}
+}
+
+// Create 키 생성 / Create key
+func (s *service) Create(ctx context.Context, req *CreateKeyRequest, granted []string) (*Key, string, error) {
+	if err := validateName(req.Name); err != nil {
+		return nil, "", err
+	}
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := checkGranted(granted, scopes); err != nil {
+		return nil, "", err
+	}
+	if err := s.validateExpiry(req.ExpiresAt); err != nil {
+		return nil, "", err
+	}
+
+	key, token, err := newKey(req.Name, scopes, req.ExpiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.store.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	zap.L().Info("API key created",
+		zap.String("method", "apikey.Service.Create"),
+		zap.Uint("key_id", key.ID),
+		zap.String("prefix", key.Prefix),
+		zap.Strings("scopes", key.Scopes))
+	return key, token, nil
+}
+
+// GetByID ID로 키 조회 / Get key by ID
+func (s *service) GetByID(ctx context.Context, id uint) (*Key, error) {
+	return s.store.GetByID(ctx, id)
+}
+
+// List 키 목록 조회 / List keys
+func (s *service) List(ctx context.Context, query *ListQuery) ([]*Key, int64, error) {
+	query.Validate()
+	return s.store.List(ctx, query.Offset, query.Limit)
+}
+
+// Revoke 키 폐기 / Revoke key
+func (s *service) Revoke(ctx context.Context, id uint) (*Key, error) {
+	if _, err := s.store.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.store.Revoke(ctx, id, s.now()); err != nil {
+		return nil, err
+	}
+	s.cache.forget(id)
+
+	key, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	zap.L().Info("API key revoked",
+		zap.String("method", "apikey.Service.Revoke"),
+		zap.Uint("key_id", key.ID),
+		zap.String("prefix", key.Prefix))
+	return key, nil
+}
+
+// Rotate 키 교체 / Rotate key
+func (s *service) Rotate(ctx context.Context, id uint, req *RotateKeyRequest, granted []string) (*Key, string, error) {
+	overlap := s.cfg.RotationOverlap
+	if req.Overlap != "" {
+		parsed, err := time.ParseDuration(req.Overlap)
+		if err != nil || parsed < 0 {
+			return nil, "", &ValidationError{Message: "Overlap must be a non-negative duration such as 24h"}
+		}
+		overlap = parsed
+	}
+	if err := s.validateExpiry(req.ExpiresAt); err != nil {
+		return nil, "", err
+	}
+
+	now := s.now()
+	var old, replacement *Key
+	var token string
+	var oldExpiresAt time.Time
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if old, err = s.store.GetByID(ctx, id); err != nil {
+			return err
+		}
+		if !old.Active(now) || old.RotatedAt != nil {
+			return ErrKeyInactive
+		}
+		if err := checkGranted(granted, old.Scopes); err != nil {
+			return err
+		}
+
+		if replacement, token, err = newKey(old.Name, old.Scopes, req.ExpiresAt); err != nil {
+			return err
+		}
+		replacement.RotatedFromID = &old.ID
+
+		// 겹침 기간이 기존 만료보다 길면 기존 만료 유지 / Keep the old expiry when it ends before the overlap
+		oldExpiresAt = now.Add(overlap)
+		if old.ExpiresAt != nil && old.ExpiresAt.Before(oldExpiresAt) {
+			oldExpiresAt = *old.ExpiresAt
+		}
+		return s.store.Rotate(ctx, old, replacement, now, oldExpiresAt)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	s.cache.forget(old.ID)
+
+	zap.L().Info("API key rotated",
+		zap.String("method", "apikey.Service.Rotate"),
+		zap.Uint("key_id", old.ID),
+		zap.Uint("replacement_id", replacement.ID),
+		zap.Time("old_expires_at", oldExpiresAt))
+	return replacement, token, nil
+}
+
+// Verify 토큰 검증 / Verify token
+// 캐시 적중 시 데이터베이스를 조회하지 않음 / A cache hit skips the database
+func (s *service) Verify(ctx context.Context, token string) (*Key, error) {
+	prefix, ok := parseToken(token)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	hash := hashToken(token)
+	now := s.now()
+
+	key, hit := s.cache.get(hash, now)
+	if !hit {
+		found, err := s.store.FindByPrefix(ctx, prefix)
+		if err != nil {
+			if errors.Is(err, ErrKeyNotFound) {
+				return nil, ErrInvalidKey
+			}
+			return nil, err
+		}
+		if subtle.ConstantTimeCompare([]byte(found.Hash), []byte(hash)) != 1 {
+			return nil, ErrInvalidKey
+		}
+		s.cache.put(hash, found, now)
+		key = found
+	}
+
+	if !key.Active(now) {
+		return nil, ErrInvalidKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= touchInterval {
+		if err := s.store.Touch(ctx, key.ID, now); err != nil {
+			zap.L().Warn("Failed to record API key use",
+				zap.String("method", "apikey.Service.Verify"),
+				zap.Uint("key_id", key.ID),
+				zap.Error(err))
+		} else {
+			key.LastUsedAt = &now
+			s.cache.touch(hash, now)
+		}
+	}
+	return key, nil
+}
+
+// validateExpiry 만료 시각이 미래인지 확인 / Check the expiry is in the future
+func (s *service) validateExpiry(expiresAt *time.Time) error {
+	if expiresAt != nil && !expiresAt.After(s.now()) {
+		return &ValidationError{Message: "Expiry must be in the future"}
+	}
+	return nil
+}
+
+// newKey 토큰을 생성하고 해시만 담은 키 반환 / Generate a token and return a key holding only its hash
+func newKey(name string, scopes []string, expiresAt *time.Time) (*Key, string, error) {
+	token, prefix, err := generateToken()
+	if err != nil {
+		return nil, "", err
+	}
+	return &Key{
+		Name:      name,
+		Prefix:    prefix,
+		Hash:      hashToken(token),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}, token, nil
+}
+
+// validateName 키 이름 확인 / Check the key name
+func validateName(name string) error {
+	if name == "" {
+		return &ValidationError{Message: "Name is required"}
+	}
+	if len(name) > maxNameLength {
+		return &ValidationError{Message: fmt.Sprintf("Name must be at most %d characters", maxNameLength)}
+	}
+	return nil
+}
+
+// normalizeScopes 범위 형식 확인 후 정렬·중복 제거 / Check the scope format, then sort and deduplicate
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, &ValidationError{Message: "At least one scope is required"}
+	}
+	for _, scope := range scopes {
+		if !scopePattern.MatchString(scope) {
+			return nil, &ValidationError{Message: fmt.Sprintf("Invalid scope %q, expected resource:action", scope)}
+		}
+	}
+	return slices.Compact(slices.Sorted(slices.Values(scopes))), nil
+}
+
+// checkGranted 호출자가 모든 범위를 가졌는지 확인, 범위 상승 방지 / Check the caller holds every scope, preventing escalation
+func checkGranted(granted, scopes []string) error {
+	if granted == nil {
+		return nil
+	}
+	for _, scope := range scopes {
+		if !HasScope(granted, scope) {
+			return &ScopeError{Scope: scope}
+		}
+	}
+	return nil
+}
+
+// HasScope 부여된 범위가 필요 범위를 포함하는지 확인 / Report whether the granted scopes cover the required scope
+// "*"는 모든 범위, "users:*"는 users의 모든 동작을 포함 / "*" covers every scope and "users:*" every users action
+func HasScope(granted []string, required string) bool {
+	resource, _, _ := strings.Cut(required, ":")
+	return slices.ContainsFunc(granted, func(scope string) bool {
+		return scope == WildcardScope || scope == required || scope == resource+":*"
+	})
+}
@@ -0,0 +1,279 @@
+package apikey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+)
+
+func setupTestService(t *testing.T, cfg ServiceConfig) (*service, *gorm.DB) {
+	t.Helper()
+
+	database, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, database.AutoMigrate(&Key{}))
+	return NewService(NewStore(database), db.NewTxManager(database, db.TxConfig{}), cfg).(*service), database
+}
+
+func TestService_Create(t *testing.T) {
+	svc, database := setupTestService(t, ServiceConfig{})
+	past := time.Now().Add(-time.Hour)
+
+	testCases := []struct {
+		name    string
+		request CreateKeyRequest
+		wantErr string
+	}{
+		{name: "valid", request: CreateKeyRequest{Name: "ci", Scopes: []string{"users:read", "users:write", "users:read"}}},
+		{name: "wildcard", request: CreateKeyRequest{Name: "admin", Scopes: []string{"*"}}},
+		{name: "missing name", request: CreateKeyRequest{Scopes: []string{"users:read"}}, wantErr: "Name is required"},
+		{name: "no scopes", request: CreateKeyRequest{Name: "ci"}, wantErr: "At least one scope is required"},
+		{name: "malformed scope", request: CreateKeyRequest{Name: "ci", Scopes: []string{"Users"}}, wantErr: `Invalid scope "Users", expected resource:action`},
+		{name: "past expiry", request: CreateKeyRequest{Name: "ci", Scopes: []string{"users:read"}, ExpiresAt: &past}, wantErr: "Expiry must be in the future"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, token, err := svc.Create(t.Context(), &tc.request, nil)
+			if tc.wantErr != "" {
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, tc.wantErr, validationErr.Message)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, IsToken(token))
+			assert.Contains(t, token, key.Prefix)
+
+			var stored Key
+			require.NoError(t, database.First(&stored, key.ID).Error)
+			assert.Equal(t, hashToken(token), stored.Hash)
+			assert.NotContains(t, stored.Hash, token)
+			assert.IsIncreasing(t, []string(stored.Scopes))
+		})
+	}
+}
+
+func TestService_Verify(t *testing.T) {
+	svc, database := setupTestService(t, ServiceConfig{CacheTTL: time.Minute})
+	ctx := t.Context()
+
+	key, token, err := svc.Create(ctx, &CreateKeyRequest{Name: "ci", Scopes: []string{"users:read"}}, nil)
+	require.NoError(t, err)
+
+	verified, err := svc.Verify(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, verified.ID)
+	assert.NotNil(t, verified.LastUsedAt)
+
+	var stored Key
+	require.NoError(t, database.First(&stored, key.ID).Error)
+	assert.NotNil(t, stored.LastUsedAt)
+
+	testCases := []struct {
+		name  string
+		token string
+	}{
+		{name: "malformed", token: "not-a-token"},
+		{name: "unknown prefix", token: "spk_000000000000_secret"},
+		{name: "wrong secret", token: "spk_" + key.Prefix + "_wrong"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.Verify(ctx, tc.token)
+			assert.ErrorIs(t, err, ErrInvalidKey)
+		})
+	}
+
+	t.Run("revoked", func(t *testing.T) {
+		revoked, err := svc.Revoke(ctx, key.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, revoked.RevokedAt)
+
+		_, err = svc.Verify(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidKey)
+
+		_, err = svc.Revoke(ctx, key.ID)
+		require.NoError(t, err)
+		_, err = svc.Revoke(ctx, key.ID+100)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
+
+func TestService_VerifyCache(t *testing.T) {
+	svc, database := setupTestService(t, ServiceConfig{CacheTTL: time.Minute})
+	ctx := t.Context()
+
+	_, token, err := svc.Create(ctx, &CreateKeyRequest{Name: "ci", Scopes: []string{"users:read"}}, nil)
+	require.NoError(t, err)
+	_, err = svc.Verify(ctx, token)
+	require.NoError(t, err)
+
+	// 캐시 적중은 데이터베이스를 읽지 않음 / A cache hit does not read the database
+	require.NoError(t, database.Exec("DELETE FROM api_keys").Error)
+	_, err = svc.Verify(ctx, token)
+	require.NoError(t, err)
+
+	now := time.Now()
+	svc.now = func() time.Time { return now.Add(2 * time.Minute) }
+	_, err = svc.Verify(ctx, token)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestService_Rotate(t *testing.T) {
+	svc, _ := setupTestService(t, ServiceConfig{CacheTTL: time.Minute, RotationOverlap: time.Hour})
+	ctx := t.Context()
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	old, oldToken, err := svc.Create(ctx, &CreateKeyRequest{Name: "ci", Scopes: []string{"users:read"}}, nil)
+	require.NoError(t, err)
+	_, err = svc.Verify(ctx, oldToken)
+	require.NoError(t, err)
+
+	replacement, newToken, err := svc.Rotate(ctx, old.ID, &RotateKeyRequest{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, old.Name, replacement.Name)
+	assert.Equal(t, old.Scopes, replacement.Scopes)
+	require.NotNil(t, replacement.RotatedFromID)
+	assert.Equal(t, old.ID, *replacement.RotatedFromID)
+	assert.NotEqual(t, oldToken, newToken)
+
+	// 겹침 기간에는 두 키 모두 동작 / Both keys work during the overlap window
+	_, err = svc.Verify(ctx, oldToken)
+	require.NoError(t, err)
+	_, err = svc.Verify(ctx, newToken)
+	require.NoError(t, err)
+
+	// 교체된 키는 겹침 기간에도 다시 교체할 수 없음 / A rotated key cannot be rotated again, even during the overlap
+	_, _, err = svc.Rotate(ctx, old.ID, &RotateKeyRequest{}, nil)
+	assert.ErrorIs(t, err, ErrKeyInactive)
+
+	svc.now = func() time.Time { return now.Add(time.Hour) }
+	_, err = svc.Verify(ctx, oldToken)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	_, err = svc.Verify(ctx, newToken)
+	require.NoError(t, err)
+
+	_, _, err = svc.Rotate(ctx, old.ID, &RotateKeyRequest{}, nil)
+	assert.ErrorIs(t, err, ErrKeyInactive)
+
+	var validationErr *ValidationError
+	_, _, err = svc.Rotate(ctx, replacement.ID, &RotateKeyRequest{Overlap: "soon"}, nil)
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "Overlap must be a non-negative duration such as 24h", validationErr.Message)
+
+	_, _, err = svc.Rotate(ctx, replacement.ID+100, &RotateKeyRequest{}, nil)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestStore_RotateOnlyOnce(t *testing.T) {
+	svc, database := setupTestService(t, ServiceConfig{})
+	ctx := t.Context()
+	now := time.Now()
+
+	old, _, err := svc.Create(ctx, &CreateKeyRequest{Name: "ci", Scopes: []string{"users:read"}}, nil)
+	require.NoError(t, err)
+
+	// 두 교체가 모두 갱신 전의 키를 읽은 경우 하나만 성공 / When both rotations read the key before either writes, only one wins
+	store := NewStore(database)
+	first, err := store.GetByID(ctx, old.ID)
+	require.NoError(t, err)
+	second, err := store.GetByID(ctx, old.ID)
+	require.NoError(t, err)
+
+	for i, read := range []*Key{first, second} {
+		replacement, _, err := newKey(old.Name, old.Scopes, nil)
+		require.NoError(t, err)
+		replacement.RotatedFromID = &old.ID
+
+		err = store.Rotate(ctx, read, replacement, now, now.Add(time.Hour))
+		if i == 0 {
+			require.NoError(t, err)
+		} else {
+			assert.ErrorIs(t, err, ErrKeyInactive)
+		}
+	}
+
+	var replacements int64
+	require.NoError(t, database.Model(&Key{}).Where("rotated_from_id = ?", old.ID).Count(&replacements).Error)
+	assert.EqualValues(t, 1, replacements)
+}
+
+func TestService_RotateKeepsEarlierExpiry(t *testing.T) {
+	svc, _ := setupTestService(t, ServiceConfig{RotationOverlap: 24 * time.Hour})
+	ctx := t.Context()
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	old, _, err := svc.Create(ctx, &CreateKeyRequest{Name: "ci", Scopes: []string{"users:read"}, ExpiresAt: &expiresAt}, nil)
+	require.NoError(t, err)
+
+	_, _, err = svc.Rotate(ctx, old.ID, &RotateKeyRequest{}, nil)
+	require.NoError(t, err)
+
+	stored, err := svc.GetByID(ctx, old.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.ExpiresAt)
+	assert.True(t, stored.ExpiresAt.Equal(expiresAt))
+}
+
+func TestService_CreateRejectsUngrantedScopes(t *testing.T) {
+	svc, _ := setupTestService(t, ServiceConfig{})
+
+	testCases := []struct {
+		name      string
+		granted   []string
+		scopes    []string
+		wantScope string
+	}{
+		{name: "wildcard from a narrower caller", granted: []string{"api-keys:write"}, scopes: []string{"*"}, wantScope: "*"},
+		{name: "other resource", granted: []string{"api-keys:write", "users:read"}, scopes: []string{"users:read", "users:write"}, wantScope: "users:write"},
+		{name: "resource wildcard from a single action", granted: []string{"users:read"}, scopes: []string{"users:*"}, wantScope: "users:*"},
+		{name: "no scopes", granted: []string{}, scopes: []string{"users:read"}, wantScope: "users:read"},
+		{name: "covered by resource wildcard", granted: []string{"users:*"}, scopes: []string{"users:read", "users:write"}},
+		{name: "covered by wildcard", granted: []string{"*"}, scopes: []string{"*"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := svc.Create(t.Context(), &CreateKeyRequest{Name: "ci", Scopes: tc.scopes}, tc.granted)
+
+			if tc.wantScope == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var scopeErr *ScopeError
+			require.ErrorAs(t, err, &scopeErr)
+			assert.Equal(t, tc.wantScope, scopeErr.Scope)
+		})
+	}
+}
+
+func TestService_RotateRejectsUngrantedScopes(t *testing.T) {
+	svc, _ := setupTestService(t, ServiceConfig{})
+	ctx := t.Context()
+
+	old, _, err := svc.Create(ctx, &CreateKeyRequest{Name: "admin", Scopes: []string{"*"}}, nil)
+	require.NoError(t, err)
+
+	// 더 좁은 호출자는 넓은 키를 교체해 토큰을 얻을 수 없음 / A narrower caller cannot rotate a wider key to obtain its token
+	var scopeErr *ScopeError
+	_, _, err = svc.Rotate(ctx, old.ID, &RotateKeyRequest{}, []string{"api-keys:write"})
+	require.ErrorAs(t, err, &scopeErr)
+	assert.Equal(t, "*", scopeErr.Scope)
+
+	stored, err := svc.GetByID(ctx, old.ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.ExpiresAt, "a rejected rotation leaves the key alone")
+
+	_, _, err = svc.Rotate(ctx, old.ID, &RotateKeyRequest{}, []string{"*"})
+	assert.NoError(t, err)
+}
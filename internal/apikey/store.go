@@ -0,0 +1,132 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+)
+
+// Store API 키 저장소 인터페이스 / API key store interface
+type Store interface {
+	Create(ctx context.Context, key *Key) error
+	GetByID(ctx context.Context, id uint) (*Key, error)
+	// FindByPrefix 토큰의 조회 접두사로 키 조회 / Find a key by the lookup prefix of a token
+	FindByPrefix(ctx context.Context, prefix string) (*Key, error)
+	List(ctx context.Context, offset, limit int) ([]*Key, int64, error)
+	// Revoke 폐기 시각 기록, 이미 폐기된 키는 그대로 / Record the revocation time; already revoked keys are left as-is
+	Revoke(ctx context.Context, id uint, at time.Time) error
+	// Rotate 이전 키를 교체됨으로 표시하고 만료를 단축한 뒤 새 키 생성, 호출자가 트랜잭션으로 감쌈
+	// Mark the old key rotated, shorten its expiry, and create the replacement; the caller wraps it in a transaction
+	// 이전 키가 now에 활성이 아니거나 이미 교체되었으면 ErrKeyInactive / ErrKeyInactive when the old key is not active at now or was already rotated
+	Rotate(ctx context.Context, old, replacement *Key, now, oldExpiresAt time.Time) error
+	// Touch 마지막 사용 시각 기록 / Record the last-used time
+	Touch(ctx context.Context, id uint, at time.Time) error
+}
+
+// store GORM 기반 저장소 구현체 / GORM-backed store implementation
+type store struct {
+	db *gorm.DB
+}
+
+// NewStore 새 API 키 저장소 생성 / Create new API key store
+func NewStore(db *gorm.DB) Store {
+	return &store{db: db}
+}
+
+// Create 키 생성 / Create key
+func (s *store) Create(ctx context.Context, key *Key) error {
+	if err := db.Conn(ctx, s.db).Create(key).Error; err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+	return nil
+}
+
+// GetByID ID로 키 조회 / Get key by ID
+func (s *store) GetByID(ctx context.Context, id uint) (*Key, error) {
+	var key Key
+	if err := db.Conn(ctx, s.db).First(&key, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return &key, nil
+}
+
+// FindByPrefix 접두사로 키 조회 / Find key by prefix
+func (s *store) FindByPrefix(ctx context.Context, prefix string) (*Key, error) {
+	var key Key
+	if err := db.Conn(ctx, s.db).Where("prefix = ?", prefix).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+	return &key, nil
+}
+
+// List 키 목록 조회 / List keys
+func (s *store) List(ctx context.Context, offset, limit int) ([]*Key, int64, error) {
+	conn := db.Conn(ctx, s.db)
+
+	var total int64
+	if err := conn.Model(&Key{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count api keys: %w", err)
+	}
+
+	var keys []*Key
+	if err := conn.Order("id").Offset(offset).Limit(limit).Find(&keys).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, total, nil
+}
+
+// Revoke 키 폐기 / Revoke key
+func (s *store) Revoke(ctx context.Context, id uint, at time.Time) error {
+	err := db.Conn(ctx, s.db).Model(&Key{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return nil
+}
+
+// Rotate 키 교체 / Rotate key
+// 조건부 갱신이 행을 잠그므로 동시 교체 중 하나만 성공 / The conditional update locks the row, so only one concurrent rotation wins
+func (s *store) Rotate(ctx context.Context, old, replacement *Key, now, oldExpiresAt time.Time) error {
+	conn := db.Conn(ctx, s.db)
+
+	result := conn.Model(&Key{}).
+		Where("id = ? AND revoked_at IS NULL AND rotated_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", old.ID, now).
+		Updates(map[string]any{"expires_at": oldExpiresAt, "rotated_at": now})
+	if result.Error != nil {
+		return fmt.Errorf("failed to expire rotated api key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrKeyInactive
+	}
+
+	if err := conn.Create(replacement).Error; err != nil {
+		return fmt.Errorf("failed to create replacement api key: %w", err)
+	}
+	old.ExpiresAt = &oldExpiresAt
+	old.RotatedAt = &now
+	return nil
+}
+
+// Touch 마지막 사용 시각 갱신 / Update last-used time
+func (s *store) Touch(ctx context.Context, id uint, at time.Time) error {
+	err := db.Conn(ctx, s.db).Model(&Key{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to touch api key: %w", err)
+	}
+	return nil
+}
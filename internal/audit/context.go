@@ -0,0 +1,17 @@
+package audit
+
+import "context"
+
+// metaKey 컨텍스트에 저장된 Meta의 키 / Context key of the stored Meta
+type metaKey struct{}
+
+// WithMeta 변경 주체 정보를 담은 컨텍스트 반환 / Return a context carrying who makes the change
+func WithMeta(ctx context.Context, meta Meta) context.Context {
+	return context.WithValue(ctx, metaKey{}, meta)
+}
+
+// MetaFromContext 컨텍스트의 변경 주체 정보, 없으면 빈 값 / Meta carried by ctx, or the zero value when absent
+func MetaFromContext(ctx context.Context) Meta {
+	meta, _ := ctx.Value(metaKey{}).(Meta)
+	return meta
+}
@@ -0,0 +1,124 @@
+// Package audit records an append-only history of entity mutations
+package audit
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Meta 변경 주체 정보 / Who made a change
+type Meta struct {
+	Actor     string
+	RequestID string
+}
+
+// Event 감사 이벤트 레코드 / Audit event record
+type Event struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	EntityType string    `json:"entity_type" gorm:"not null;size:50;index:idx_audit_events_entity,priority:1"`
+	EntityID   string    `json:"entity_id" gorm:"not null;size:64;index:idx_audit_events_entity,priority:2"`
+	Action     string    `json:"action" gorm:"not null;size:50"`
+	Actor      string    `json:"actor" gorm:"not null;size:100"`
+	RequestID  string    `json:"request_id,omitempty" gorm:"size:100"`
+	Changes    Changes   `json:"changes" gorm:"type:text"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName 테이블 이름 지정 / Specify table name
+func (Event) TableName() string {
+	return "audit_events"
+}
+
+// Change 필드 변경 전후 값 / Field value before and after a change
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Changes 필드별 변경 내역 (JSON 컬럼) / Field-level changes stored as a JSON column
+type Changes map[string]Change
+
+// Value implements driver.Valuer.
+func (c Changes) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit changes: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner.
+func (c *Changes) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*c = Changes{}
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("unsupported audit changes type %T", value)
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("failed to decode audit changes: %w", err)
+	}
+	return nil
+}
+
+// errNotObject JSON 객체가 아닌 값 / A value that does not encode to a JSON object
+var errNotObject = errors.New("audit diff requires values that encode to JSON objects")
+
+// Diff JSON 필드 기준 변경 내역 계산 / Compute changes by JSON field
+// before나 after가 nil이면 생성 또는 삭제로 보고 모든 필드를 기록 / A nil before or after records every field, as for a create or delete
+func Diff(before, after interface{}, ignore ...string) (Changes, error) {
+	beforeFields, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	skip := make(map[string]bool, len(ignore))
+	for _, name := range ignore {
+		skip[name] = true
+	}
+
+	changes := Changes{}
+	for name, value := range afterFields {
+		if !skip[name] && !reflect.DeepEqual(beforeFields[name], value) {
+			changes[name] = Change{Before: beforeFields[name], After: value}
+		}
+	}
+	for name, value := range beforeFields {
+		if _, ok := afterFields[name]; !ok && !skip[name] {
+			changes[name] = Change{Before: value}
+		}
+	}
+	return changes, nil
+}
+
+func fields(value interface{}) (map[string]interface{}, error) {
+	if value == nil || (reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil()) {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit value: %w", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errNotObject
+	}
+	return result, nil
+}
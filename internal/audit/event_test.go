@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type record struct {
+	Name   string  `json:"name"`
+	Email  string  `json:"email"`
+	Note   *string `json:"note"`
+	Status string  `json:"status"`
+}
+
+func TestDiff(t *testing.T) {
+	note := "vip"
+	before := &record{Name: "Old", Email: "a@example.com", Status: "active"}
+	after := &record{Name: "New", Email: "a@example.com", Note: &note, Status: "inactive"}
+
+	testCases := []struct {
+		name     string
+		before   interface{}
+		after    interface{}
+		ignore   []string
+		expected Changes
+	}{
+		{
+			name:   "update",
+			before: before,
+			after:  after,
+			expected: Changes{
+				"name":   {Before: "Old", After: "New"},
+				"note":   {Before: nil, After: "vip"},
+				"status": {Before: "active", After: "inactive"},
+			},
+		},
+		{
+			name:     "ignored fields",
+			before:   before,
+			after:    after,
+			ignore:   []string{"name", "note"},
+			expected: Changes{"status": {Before: "active", After: "inactive"}},
+		},
+		{
+			name:   "create",
+			before: (*record)(nil),
+			after:  before,
+			ignore: []string{"note"},
+			expected: Changes{
+				"name":   {After: "Old"},
+				"email":  {After: "a@example.com"},
+				"status": {After: "active"},
+			},
+		},
+		{
+			name:   "delete",
+			before: before,
+			ignore: []string{"note", "email"},
+			expected: Changes{
+				"name":   {Before: "Old"},
+				"status": {Before: "active"},
+			},
+		},
+		{
+			name:     "no changes",
+			before:   before,
+			after:    before,
+			expected: Changes{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			changes, err := Diff(tc.before, tc.after, tc.ignore...)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, changes)
+		})
+	}
+
+	_, err := Diff("not an object", nil)
+	assert.ErrorIs(t, err, errNotObject)
+}
+
+func TestStore_RecordAndList(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Event{}))
+	store := NewStore(db)
+
+	for _, action := range []string{"create", "update", "delete"} {
+		require.NoError(t, store.Record(&Event{
+			EntityType: "user", EntityID: "1", Action: action, Actor: "api-key", RequestID: "req-" + action,
+			Changes: Changes{"name": {Before: "Old", After: "New"}},
+		}))
+	}
+	require.NoError(t, store.Record(&Event{EntityType: "user", EntityID: "2", Action: "create", Actor: "api-key"}))
+
+	events, total, err := store.List("user", "1", 0, 2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, total)
+	require.Len(t, events, 2)
+	assert.Equal(t, "delete", events[0].Action)
+	assert.Equal(t, "update", events[1].Action)
+	assert.Equal(t, Change{Before: "Old", After: "New"}, events[0].Changes["name"])
+
+	events, _, err = store.List("user", "2", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, Changes{}, events[0].Changes)
+}
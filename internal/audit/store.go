@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Store 감사 이벤트 저장소 인터페이스 (추가 전용) / Append-only audit event store interface
+type Store interface {
+	Record(event *Event) error
+	// List 엔터티의 이벤트를 최신순으로 조회 / List an entity's events, newest first
+	List(entityType, entityID string, offset, limit int) ([]*Event, int64, error)
+}
+
+// store GORM 기반 저장소 구현체 / GORM-backed store implementation
+type store struct {
+	db *gorm.DB
+}
+
+// NewStore 새 감사 이벤트 저장소 생성 / Create new audit event store
+// 트랜잭션 핸들을 넘기면 같은 트랜잭션에서 기록 / Pass a transaction handle to record within that transaction
+func NewStore(db *gorm.DB) Store {
+	return &store{db: db}
+}
+
+// Record 이벤트 추가 / Append an event
+func (s *store) Record(event *Event) error {
+	if err := s.db.Create(event).Error; err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+	return nil
+}
+
+// List 이벤트 조회 / List events
+func (s *store) List(entityType, entityID string, offset, limit int) ([]*Event, int64, error) {
+	var events []*Event
+	var total int64
+
+	db := s.db.Model(&Event{}).Where("entity_type = ? AND entity_id = ?", entityType, entityID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+	if err := db.Order("id DESC").Offset(offset).Limit(limit).Find(&events).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	return events, total, nil
+}
@@ -4,11 +4,15 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/netip"
 	"net/url"
 	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v11"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/jwt"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/password"
 )
 
 // Config 환경설정 구조체 / Application configuration structure
@@ -17,6 +21,9 @@ type Config struct {
 	Env  string `env:"ENV" envDefault:"local"`
 	Port string `env:"PORT" envDefault:"8080"`
 
+	// Request settings (0 disables the per-request deadline)
+	RequestTimeout time.Duration `env:"REQUEST_TIMEOUT" envDefault:"15s"`
+
 	// Database settings
 	DBDriver      string        `env:"DB_DRIVER" envDefault:"mysql"`
 	DBHost        string        `env:"DB_HOST" envDefault:"localhost"`
@@ -29,10 +36,87 @@ type Config struct {
 	DBMaxIdle     int           `env:"DB_MAX_IDLE" envDefault:"10"`
 	DBMaxLifetime time.Duration `env:"DB_MAX_LIFETIME" envDefault:"300s"`
 
-	// Security settings
+	// Transaction retry settings (serialization failures and deadlocks)
+	DBTxMaxAttempts  int           `env:"DB_TX_MAX_ATTEMPTS" envDefault:"3"`
+	DBTxRetryBackoff time.Duration `env:"DB_TX_RETRY_BACKOFF" envDefault:"50ms"`
+
+	// Security settings (API_KEY is an all-access bootstrap key)
 	APIKey               string `env:"API_KEY" envDefault:""`
 	CORSAllowedOrigins   string `env:"CORS_ALLOWED_ORIGINS" envDefault:""`
 	CORSAllowCredentials bool   `env:"CORS_ALLOW_CREDENTIALS" envDefault:"false"`
+	// Proxies whose X-Forwarded-For is trusted for the client IP (empty uses the connection address)
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envSeparator:","`
+
+	// Database API key settings (0 cache TTL verifies every request against the database)
+	APIKeysEnabled        bool          `env:"API_KEYS_ENABLED" envDefault:"false"`
+	APIKeyCacheTTL        time.Duration `env:"API_KEY_CACHE_TTL" envDefault:"30s"`
+	APIKeyRotationOverlap time.Duration `env:"API_KEY_ROTATION_OVERLAP" envDefault:"24h"`
+
+	// JWT settings (on when a JWKS source or an HMAC secret is set)
+	JWTIssuer      string        `env:"JWT_ISSUER" envDefault:""`
+	JWTAudience    string        `env:"JWT_AUDIENCE" envDefault:""`
+	JWTJWKSFile    string        `env:"JWT_JWKS_FILE" envDefault:""`
+	JWTJWKSURL     string        `env:"JWT_JWKS_URL" envDefault:""`
+	JWTJWKSRefresh time.Duration `env:"JWT_JWKS_REFRESH" envDefault:"10m"`
+	JWTHMACSecret  string        `env:"JWT_HMAC_SECRET" envDefault:""`
+	JWTLeeway      time.Duration `env:"JWT_LEEWAY" envDefault:"30s"`
+
+	// Password login settings (access tokens are HS256-signed with JWT_HMAC_SECRET)
+	AuthLoginEnabled    bool          `env:"AUTH_LOGIN_ENABLED" envDefault:"false"`
+	AuthAccessTokenTTL  time.Duration `env:"AUTH_ACCESS_TOKEN_TTL" envDefault:"15m"`
+	AuthRefreshTokenTTL time.Duration `env:"AUTH_REFRESH_TOKEN_TTL" envDefault:"720h"`
+	AuthTokenScopes     []string      `env:"AUTH_TOKEN_SCOPES" envSeparator:"," envDefault:"users:read"`
+	AuthSessionCacheTTL time.Duration `env:"AUTH_SESSION_CACHE_TTL" envDefault:"10s"`
+
+	// Login throttling settings (failures are counted per email and per client IP in a sliding window)
+	AuthFailureWindow      time.Duration `env:"AUTH_FAILURE_WINDOW" envDefault:"15m"`
+	AuthMaxAccountFailures int           `env:"AUTH_MAX_ACCOUNT_FAILURES" envDefault:"5"`
+	AuthMaxIPFailures      int           `env:"AUTH_MAX_IP_FAILURES" envDefault:"20"`
+	AuthLockoutBase        time.Duration `env:"AUTH_LOCKOUT_BASE" envDefault:"1m"`
+	AuthLockoutMax         time.Duration `env:"AUTH_LOCKOUT_MAX" envDefault:"24h"`
+	AuthLockoutReset       time.Duration `env:"AUTH_LOCKOUT_RESET" envDefault:"24h"`
+
+	// Password hashing settings (argon2id, memory in KiB; each concurrent hash uses that much memory)
+	PasswordMemory        uint32 `env:"PASSWORD_ARGON2_MEMORY" envDefault:"65536"`
+	PasswordIterations    uint32 `env:"PASSWORD_ARGON2_ITERATIONS" envDefault:"3"`
+	PasswordParallelism   uint8  `env:"PASSWORD_ARGON2_PARALLELISM" envDefault:"4"`
+	PasswordMaxConcurrent int    `env:"PASSWORD_MAX_CONCURRENT" envDefault:"4"`
+
+	// Idempotency settings
+	IdempotencyTTL     time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
+	IdempotencyMaxBody int           `env:"IDEMPOTENCY_MAX_BODY" envDefault:"1048576"`
+
+	// Scheduler settings (0 disables the sweep)
+	SuspensionSweepInterval  time.Duration `env:"SUSPENSION_SWEEP_INTERVAL" envDefault:"1m"`
+	SuspensionSweepBatchSize int           `env:"SUSPENSION_SWEEP_BATCH_SIZE" envDefault:"100"`
+
+	// Outbox settings (publisher: log or file; 0 interval disables the relay, 0 retention keeps published events)
+	OutboxPublisher     string        `env:"OUTBOX_PUBLISHER" envDefault:"log"`
+	OutboxFilePath      string        `env:"OUTBOX_FILE_PATH" envDefault:"outbox.ndjson"`
+	OutboxRelayInterval time.Duration `env:"OUTBOX_RELAY_INTERVAL" envDefault:"5s"`
+	OutboxBatchSize     int           `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+	OutboxMaxAttempts   int           `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"10"`
+	OutboxBaseBackoff   time.Duration `env:"OUTBOX_BASE_BACKOFF" envDefault:"1s"`
+	OutboxMaxBackoff    time.Duration `env:"OUTBOX_MAX_BACKOFF" envDefault:"5m"`
+	OutboxRetention     time.Duration `env:"OUTBOX_RETENTION" envDefault:"168h"`
+
+	// Webhook settings (0 interval disables webhook deliveries; allow private only for local receivers)
+	WebhookDeliveryInterval time.Duration `env:"WEBHOOK_DELIVERY_INTERVAL" envDefault:"5s"`
+	WebhookBatchSize        int           `env:"WEBHOOK_BATCH_SIZE" envDefault:"50"`
+	WebhookWorkers          int           `env:"WEBHOOK_WORKERS" envDefault:"4"`
+	WebhookTimeout          time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	WebhookMaxAttempts      int           `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"8"`
+	WebhookBaseBackoff      time.Duration `env:"WEBHOOK_BASE_BACKOFF" envDefault:"10s"`
+	WebhookMaxBackoff       time.Duration `env:"WEBHOOK_MAX_BACKOFF" envDefault:"1h"`
+	WebhookAllowPrivate     bool          `env:"WEBHOOK_ALLOW_PRIVATE" envDefault:"false"`
+
+	// User change stream (SSE) settings
+	StreamPollInterval time.Duration `env:"STREAM_POLL_INTERVAL" envDefault:"500ms"`
+	StreamHeartbeat    time.Duration `env:"STREAM_HEARTBEAT" envDefault:"15s"`
+	StreamGapGrace     time.Duration `env:"STREAM_GAP_GRACE" envDefault:"2s"`
+	StreamBufferSize   int           `env:"STREAM_BUFFER_SIZE" envDefault:"256"`
+	StreamWriteTimeout time.Duration `env:"STREAM_WRITE_TIMEOUT" envDefault:"10s"`
+	StreamMaxClients   int           `env:"STREAM_MAX_CLIENTS" envDefault:"1000"`
 
 	// Logging settings
 	LogLevel string `env:"LOG_LEVEL" envDefault:"info"`
@@ -58,12 +142,57 @@ func Load() (*Config, error) {
 
 // Validate checks configuration values that are unsafe to run with.
 func (c *Config) Validate() error {
+	if c.JWTHMACSecret != "" && len(c.JWTHMACSecret) < jwt.MinSecretLength {
+		return fmt.Errorf("JWT_HMAC_SECRET must be at least %d bytes", jwt.MinSecretLength)
+	}
+	if c.JWTJWKSFile != "" && c.JWTJWKSURL != "" {
+		return errors.New("JWT_JWKS_FILE and JWT_JWKS_URL cannot both be set")
+	}
+	if c.AuthLoginEnabled && c.JWTHMACSecret == "" {
+		return errors.New("JWT_HMAC_SECRET must be set when AUTH_LOGIN_ENABLED is on")
+	}
+	if c.AuthAccessTokenTTL <= 0 || c.AuthRefreshTokenTTL <= 0 {
+		return errors.New("AUTH_ACCESS_TOKEN_TTL and AUTH_REFRESH_TOKEN_TTL must be positive")
+	}
+	if c.AuthSessionCacheTTL < 0 {
+		return errors.New("AUTH_SESSION_CACHE_TTL cannot be negative")
+	}
+	if c.AuthFailureWindow <= 0 || c.AuthMaxAccountFailures <= 0 || c.AuthMaxIPFailures <= 0 {
+		return errors.New("AUTH_FAILURE_WINDOW, AUTH_MAX_ACCOUNT_FAILURES and AUTH_MAX_IP_FAILURES must be positive")
+	}
+	if c.AuthLockoutBase <= 0 || c.AuthLockoutMax < c.AuthLockoutBase || c.AuthLockoutReset < 0 {
+		return errors.New("AUTH_LOCKOUT_BASE must be positive, AUTH_LOCKOUT_MAX at least AUTH_LOCKOUT_BASE, and AUTH_LOCKOUT_RESET not negative")
+	}
+	for _, proxy := range c.TrustedProxies {
+		if !isIPOrCIDR(strings.TrimSpace(proxy)) {
+			return fmt.Errorf("TRUSTED_PROXIES entry %q is not an IP or CIDR", proxy)
+		}
+	}
+	if c.PasswordIterations == 0 || c.PasswordParallelism == 0 || c.PasswordMemory < 8*uint32(c.PasswordParallelism) {
+		return errors.New("PASSWORD_ARGON2_ITERATIONS and PASSWORD_ARGON2_PARALLELISM must be positive and PASSWORD_ARGON2_MEMORY at least 8 KiB per lane")
+	}
+	if c.PasswordMaxConcurrent < 0 {
+		return errors.New("PASSWORD_MAX_CONCURRENT cannot be negative")
+	}
+	if c.IdempotencyMaxBody < 0 {
+		return errors.New("IDEMPOTENCY_MAX_BODY cannot be negative")
+	}
+	if c.OutboxRetention < 0 {
+		return errors.New("OUTBOX_RETENTION cannot be negative")
+	}
+	if c.StreamPollInterval <= 0 || c.StreamHeartbeat <= 0 {
+		return errors.New("STREAM_POLL_INTERVAL and STREAM_HEARTBEAT must be positive")
+	}
+
 	if !c.IsProd() {
 		return nil
 	}
 
-	if c.APIKey == "" || c.APIKey == "your-api-key-here" {
-		return errors.New("API_KEY must be set to a non-placeholder value in prod")
+	if c.APIKey == "your-api-key-here" || !c.AuthEnabled() {
+		return errors.New("API_KEY must be set to a non-placeholder value, or API_KEYS_ENABLED or JWT turned on in prod")
+	}
+	if c.JWTEnabled() && (c.JWTIssuer == "" || c.JWTAudience == "") {
+		return errors.New("JWT_ISSUER and JWT_AUDIENCE must be set when JWT is on in prod")
 	}
 	if c.DBPass == "" || c.DBPass == "password" {
 		return errors.New("DB_PASS must be set to a non-default value in prod")
@@ -75,6 +204,30 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// APIKeyAuthEnabled API 키 인증 사용 여부 / Report whether API key authentication is on
+func (c *Config) APIKeyAuthEnabled() bool {
+	return c.APIKey != "" || c.APIKeysEnabled
+}
+
+// JWTEnabled JWT 인증 사용 여부 / Report whether JWT authentication is on
+func (c *Config) JWTEnabled() bool {
+	return c.JWTJWKSFile != "" || c.JWTJWKSURL != "" || c.JWTHMACSecret != ""
+}
+
+// AuthEnabled API 키 또는 JWT 인증 사용 여부 / Report whether API key or JWT authentication is on
+func (c *Config) AuthEnabled() bool {
+	return c.APIKeyAuthEnabled() || c.JWTEnabled()
+}
+
+// PasswordParams argon2id 비용 설정 / argon2id cost settings
+func (c *Config) PasswordParams() password.Params {
+	return password.Params{
+		Memory:      c.PasswordMemory,
+		Iterations:  c.PasswordIterations,
+		Parallelism: c.PasswordParallelism,
+	}
+}
+
 // IsDev 개발 환경인지 확인 / Check if running in development environment
 func (c *Config) IsDev() bool {
 	return c.Env == "dev" || c.Env == "local"
@@ -85,6 +238,14 @@ func (c *Config) IsProd() bool {
 	return c.Env == "prod"
 }
 
+func isIPOrCIDR(value string) bool {
+	if _, err := netip.ParseAddr(value); err == nil {
+		return true
+	}
+	_, err := netip.ParsePrefix(value)
+	return err == nil
+}
+
 func hasExactOrigin(origins string, target string) bool {
 	for _, origin := range strings.Split(origins, ",") {
 		if strings.TrimSpace(origin) == target {
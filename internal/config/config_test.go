@@ -84,6 +84,20 @@ func TestLoadAcceptsSecureProductionConfig(t *testing.T) {
 	assert.True(t, cfg.CORSAllowCredentials)
 }
 
+func TestLoadAcceptsDatabaseAPIKeysInProduction(t *testing.T) {
+	t.Setenv("ENV", "prod")
+	t.Setenv("API_KEY", "")
+	t.Setenv("API_KEYS_ENABLED", "true")
+	t.Setenv("DB_PASS", "strong-db-pass")
+
+	cfg, err := Load()
+
+	require.NoError(t, err)
+	assert.True(t, cfg.APIKeyAuthEnabled())
+	assert.Equal(t, 30*time.Second, cfg.APIKeyCacheTTL)
+	assert.Equal(t, 24*time.Hour, cfg.APIKeyRotationOverlap)
+}
+
 func TestGetDBDSNMySQL(t *testing.T) {
 	cfg := &Config{
 		DBDriver: "mysql",
@@ -119,3 +133,123 @@ func TestGetDBDSNPostgres(t *testing.T) {
 		"sslmode=disable TimeZone=Asia/Seoul"
 	assert.Equal(t, want, dsn)
 }
+
+func TestLoadValidatesJWTSettings(t *testing.T) {
+	testCases := []struct {
+		name          string
+		env           map[string]string
+		errorContains string
+	}{
+		{
+			name:          "short hmac secret",
+			env:           map[string]string{"ENV": "local", "JWT_HMAC_SECRET": "too-short"},
+			errorContains: "JWT_HMAC_SECRET",
+		},
+		{
+			name:          "both jwks sources",
+			env:           map[string]string{"ENV": "local", "JWT_JWKS_FILE": "jwks.json", "JWT_JWKS_URL": "https://id.example.com/jwks.json"},
+			errorContains: "JWT_JWKS_FILE",
+		},
+		{
+			name:          "missing issuer in prod",
+			env:           map[string]string{"ENV": "prod", "DB_PASS": "strong-db-pass", "JWT_JWKS_URL": "https://id.example.com/jwks.json", "JWT_AUDIENCE": "spindle"},
+			errorContains: "JWT_ISSUER",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+
+			assert.Nil(t, cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorContains)
+		})
+	}
+}
+
+func TestLoadValidatesLoginSettings(t *testing.T) {
+	testCases := []struct {
+		name          string
+		env           map[string]string
+		errorContains string
+	}{
+		{
+			name:          "login without hmac secret",
+			env:           map[string]string{"AUTH_LOGIN_ENABLED": "true"},
+			errorContains: "JWT_HMAC_SECRET",
+		},
+		{
+			name:          "zero failure threshold",
+			env:           map[string]string{"AUTH_MAX_ACCOUNT_FAILURES": "0"},
+			errorContains: "AUTH_MAX_ACCOUNT_FAILURES",
+		},
+		{
+			name:          "maximum lockout below base",
+			env:           map[string]string{"AUTH_LOCKOUT_BASE": "1h", "AUTH_LOCKOUT_MAX": "1m"},
+			errorContains: "AUTH_LOCKOUT_MAX",
+		},
+		{
+			name:          "invalid trusted proxy",
+			env:           map[string]string{"TRUSTED_PROXIES": "10.0.0.0/8,proxy.internal"},
+			errorContains: "TRUSTED_PROXIES",
+		},
+		{
+			name:          "negative password concurrency",
+			env:           map[string]string{"PASSWORD_MAX_CONCURRENT": "-1"},
+			errorContains: "PASSWORD_MAX_CONCURRENT",
+		},
+		{
+			name:          "negative outbox retention",
+			env:           map[string]string{"OUTBOX_RETENTION": "-1h"},
+			errorContains: "OUTBOX_RETENTION",
+		},
+		{
+			name:          "negative idempotency body limit",
+			env:           map[string]string{"IDEMPOTENCY_MAX_BODY": "-1"},
+			errorContains: "IDEMPOTENCY_MAX_BODY",
+		},
+		{
+			name:          "zero stream heartbeat",
+			env:           map[string]string{"STREAM_HEARTBEAT": "0s"},
+			errorContains: "STREAM_HEARTBEAT",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("ENV", "local")
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+
+			assert.Nil(t, cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorContains)
+		})
+	}
+}
+
+func TestLoadAcceptsJWTInProduction(t *testing.T) {
+	t.Setenv("ENV", "prod")
+	t.Setenv("API_KEY", "")
+	t.Setenv("DB_PASS", "strong-db-pass")
+	t.Setenv("JWT_JWKS_URL", "https://id.example.com/.well-known/jwks.json")
+	t.Setenv("JWT_ISSUER", "https://id.example.com/")
+	t.Setenv("JWT_AUDIENCE", "spindle")
+
+	cfg, err := Load()
+
+	require.NoError(t, err)
+	assert.True(t, cfg.JWTEnabled())
+	assert.True(t, cfg.AuthEnabled())
+	assert.False(t, cfg.APIKeyAuthEnabled())
+	assert.Equal(t, 10*time.Minute, cfg.JWTJWKSRefresh)
+	assert.Equal(t, 30*time.Second, cfg.JWTLeeway)
+}
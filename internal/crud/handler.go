@@ -0,0 +1,277 @@
+package crud
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/listquery"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/resp"
+)
+
+// ErrorMapping 도메인 오류의 HTTP 응답 매핑 / Mapping of a domain error to an HTTP response
+type ErrorMapping struct {
+	Err error
+	// Respond resp.Conflict 같은 응답 함수 / Response function such as resp.Conflict
+	Respond func(c *fiber.Ctx, message string, details ...interface{}) error
+	Message string
+	// Details 오류 문자열을 details로 노출 / Expose the error string as details
+	Details bool
+}
+
+// Hooks 동작별 재정의 지점, nil이면 저장소 기본 동작 / Per-operation overrides, nil falls back to the repository
+// 요청 헤더와 감사 정보를 읽을 수 있도록 fiber.Ctx를 받음 / They receive the fiber.Ctx so headers and audit metadata are reachable
+type Hooks[T any, ID comparable, C any, U any] struct {
+	Create func(c *fiber.Ctx, req *C) (*T, error)
+	Get    func(c *fiber.Ctx, id ID) (*T, error)
+	Update func(c *fiber.Ctx, id ID, req *U) (*T, error)
+	Delete func(c *fiber.Ctx, id ID) error
+	// List 파싱과 기본값이 적용된 query를 받음 / Receives the parsed query with defaults applied
+	List func(c *fiber.Ctx, query *Query) (*Page[T], error)
+	// Render 단일 엔티티 응답 작성 (ETag 등) / Write a single-entity response (ETags and the like)
+	Render func(c *fiber.Ctx, status int, entity *T) error
+}
+
+// HandlerConfig 핸들러 빌더 설정 / Handler builder configuration
+// C와 U는 생성, 수정 요청 본문 타입이며 Validate() error가 있으면 호출됨
+// C and U are the create and update body types; their Validate() error is called when present
+type HandlerConfig[T any, ID comparable, C any, U any] struct {
+	// Options 이름과 목록 필터 (저장소와 같은 값) / Names and list filters (the same value as the repository's)
+	Options Options
+	// Repository 훅이 없는 동작의 기본 구현 / Default implementation of operations without a hook
+	Repository Repository[T, ID]
+	// ParseID 경로의 :id 파싱 / Parse the :id path parameter
+	ParseID func(raw string) (ID, error)
+	// NewEntity 생성 요청을 엔티티로 변환 (기본 Create) / Convert a create request to an entity (default Create)
+	NewEntity func(req *C) *T
+	// Apply 수정 요청을 엔티티에 적용 (기본 Update) / Apply an update request to an entity (default Update)
+	Apply func(req *U, entity *T) error
+	// Errors 기본 404 매핑보다 먼저 검사 / Checked before the default 404 mapping
+	Errors []ErrorMapping
+	Hooks  Hooks[T, ID, C, U]
+}
+
+// Handler 범용 CRUD HTTP 핸들러 / Generic CRUD HTTP handler
+type Handler[T any, ID comparable, C any, U any] struct {
+	cfg   HandlerConfig[T, ID, C, U]
+	title string
+}
+
+// NewHandler 새 범용 핸들러 생성 / Create new generic handler
+func NewHandler[T any, ID comparable, C any, U any](cfg HandlerConfig[T, ID, C, U]) *Handler[T, ID, C, U] {
+	h := &Handler[T, ID, C, U]{cfg: cfg, title: strings.ToUpper(cfg.Options.Name[:1]) + cfg.Options.Name[1:]}
+
+	// 훅이 없는 동작은 저장소로 처리 / Operations without a hook go to the repository
+	hooks := &h.cfg.Hooks
+	if hooks.Create == nil {
+		hooks.Create = h.create
+	}
+	if hooks.Get == nil {
+		hooks.Get = func(c *fiber.Ctx, id ID) (*T, error) {
+			return h.cfg.Repository.GetByID(c.UserContext(), id)
+		}
+	}
+	if hooks.Update == nil {
+		hooks.Update = h.update
+	}
+	if hooks.Delete == nil {
+		hooks.Delete = func(c *fiber.Ctx, id ID) error {
+			return h.cfg.Repository.Delete(c.UserContext(), id)
+		}
+	}
+	if hooks.List == nil {
+		hooks.List = h.list
+	}
+	if hooks.Render == nil {
+		hooks.Render = func(c *fiber.Ctx, status int, entity *T) error {
+			return c.Status(status).JSON(resp.SuccessResponse{Data: entity})
+		}
+	}
+	return h
+}
+
+// ParseUint uint ID 파서 / Parser for uint IDs
+func ParseUint(raw string) (uint, error) {
+	id, err := strconv.ParseUint(raw, 10, 32)
+	return uint(id), err
+}
+
+// Create 생성 / Create
+func (h *Handler[T, ID, C, U]) Create(c *fiber.Ctx) error {
+	req := new(C)
+	if err := c.BodyParser(req); err != nil {
+		return resp.BadRequest(c, "Invalid request body", err.Error())
+	}
+	if err := validate(req); err != nil {
+		return resp.BadRequest(c, err.Error())
+	}
+
+	entity, err := h.cfg.Hooks.Create(c, req)
+	if err != nil {
+		return h.fail(c, err, "Failed to create "+h.cfg.Options.Name)
+	}
+	return h.cfg.Hooks.Render(c, fiber.StatusCreated, entity)
+}
+
+// GetByID ID로 조회 / Get by ID
+func (h *Handler[T, ID, C, U]) GetByID(c *fiber.Ctx) error {
+	id, err := h.cfg.ParseID(c.Params("id"))
+	if err != nil {
+		return resp.BadRequest(c, "Invalid "+h.cfg.Options.Name+" ID")
+	}
+
+	entity, err := h.cfg.Hooks.Get(c, id)
+	if err != nil {
+		return h.fail(c, err, "Failed to get "+h.cfg.Options.Name, zap.Any(h.cfg.Options.Name+"_id", id))
+	}
+	return h.cfg.Hooks.Render(c, fiber.StatusOK, entity)
+}
+
+// Update 수정 / Update
+func (h *Handler[T, ID, C, U]) Update(c *fiber.Ctx) error {
+	id, err := h.cfg.ParseID(c.Params("id"))
+	if err != nil {
+		return resp.BadRequest(c, "Invalid "+h.cfg.Options.Name+" ID")
+	}
+
+	req := new(U)
+	if parseErr := c.BodyParser(req); parseErr != nil {
+		return resp.BadRequest(c, "Invalid request body", parseErr.Error())
+	}
+	if err := validate(req); err != nil {
+		return resp.BadRequest(c, err.Error())
+	}
+
+	entity, err := h.cfg.Hooks.Update(c, id, req)
+	if err != nil {
+		return h.fail(c, err, "Failed to update "+h.cfg.Options.Name, zap.Any(h.cfg.Options.Name+"_id", id))
+	}
+	return h.cfg.Hooks.Render(c, fiber.StatusOK, entity)
+}
+
+// Delete 삭제 / Delete
+func (h *Handler[T, ID, C, U]) Delete(c *fiber.Ctx) error {
+	id, err := h.cfg.ParseID(c.Params("id"))
+	if err != nil {
+		return resp.BadRequest(c, "Invalid "+h.cfg.Options.Name+" ID")
+	}
+
+	if err := h.cfg.Hooks.Delete(c, id); err != nil {
+		return h.fail(c, err, "Failed to delete "+h.cfg.Options.Name, zap.Any(h.cfg.Options.Name+"_id", id))
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// List 목록 조회 / List
+func (h *Handler[T, ID, C, U]) List(c *fiber.Ctx) error {
+	var query Query
+
+	// 쿼리 파라미터 파싱 / Parse query parameters
+	if err := c.QueryParser(&query); err != nil {
+		return resp.BadRequest(c, "Invalid query parameters", err.Error())
+	}
+
+	// 구조화된 필터 파싱 / Parse structured filters
+	filter, err := listquery.ParseFilter(c.Queries(), h.cfg.Options.Filters)
+	if err != nil {
+		return resp.BadRequest(c, "Invalid filter", err)
+	}
+	query.Filter = filter
+
+	// 쿼리 검증 및 기본값 설정 / Validate query and set defaults
+	query.Normalize()
+	if query.Cursor != "" && query.Offset > 0 {
+		return resp.BadRequest(c, "Offset cannot be combined with cursor")
+	}
+
+	page, err := h.cfg.Hooks.List(c, &query)
+	if err != nil {
+		var sortErr *listquery.SortError
+		if errors.As(err, &sortErr) {
+			return resp.BadRequest(c, "Invalid sort field", sortErr)
+		}
+		if errors.Is(err, listquery.ErrInvalidCursor) {
+			return resp.BadRequest(c, "Invalid cursor", err.Error())
+		}
+		return h.fail(c, err, "Failed to list "+h.cfg.Options.plural())
+	}
+
+	pagination := resp.Pagination{
+		Offset:     query.Offset,
+		Limit:      query.Limit,
+		Cursor:     query.Cursor,
+		NextCursor: page.NextCursor,
+	}
+	// 커서 모드에서는 개수를 세지 않으므로 total 생략 / total is left out in cursor mode, where nothing is counted
+	if query.Cursor == "" {
+		pagination.Total = &page.Total
+	}
+	return resp.SuccessWithPage(c, page.Items, pagination)
+}
+
+// create 기본 생성 / Default create
+func (h *Handler[T, ID, C, U]) create(c *fiber.Ctx, req *C) (*T, error) {
+	entity := h.cfg.NewEntity(req)
+	if err := h.cfg.Repository.Create(c.UserContext(), entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// update 기본 수정 (조회 후 적용하여 저장) / Default update (load, apply, save)
+func (h *Handler[T, ID, C, U]) update(c *fiber.Ctx, id ID, req *U) (*T, error) {
+	ctx := c.UserContext()
+	entity, err := h.cfg.Repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.cfg.Apply(req, entity); err != nil {
+		return nil, err
+	}
+	if err := h.cfg.Repository.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// list 기본 목록 조회 (오프셋 페이지네이션) / Default list (offset pagination)
+func (h *Handler[T, ID, C, U]) list(c *fiber.Ctx, query *Query) (*Page[T], error) {
+	if query.Cursor != "" {
+		return nil, listquery.ErrInvalidCursor
+	}
+	items, total, err := h.cfg.Repository.List(c.UserContext(), query)
+	if err != nil {
+		return nil, err
+	}
+	return &Page[T]{Items: items, Total: total}, nil
+}
+
+// fail 오류 매핑 후 응답, 매핑되지 않은 오류는 기록 후 500 / Respond to a mapped error; unmapped errors are logged and become 500
+func (h *Handler[T, ID, C, U]) fail(c *fiber.Ctx, err error, message string, fields ...zap.Field) error {
+	for _, mapping := range h.cfg.Errors {
+		if !errors.Is(err, mapping.Err) {
+			continue
+		}
+		if mapping.Details {
+			return mapping.Respond(c, mapping.Message, err.Error())
+		}
+		return mapping.Respond(c, mapping.Message)
+	}
+	if errors.Is(err, ErrNotFound) {
+		return resp.NotFound(c, h.title+" not found")
+	}
+
+	zap.L().Error(message, append([]zap.Field{zap.Error(err)}, fields...)...)
+	return resp.InternalServerError(c, message)
+}
+
+// validate Validate() error가 있으면 호출 / Call Validate() error when present
+func validate(req any) error {
+	if v, ok := req.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
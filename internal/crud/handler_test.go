@@ -0,0 +1,181 @@
+package crud
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/resp"
+)
+
+var errDuplicateName = errors.New("duplicate name")
+
+type createWidgetRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func (r *createWidgetRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+type updateWidgetRequest struct {
+	Color *string `json:"color"`
+}
+
+func setupWidgetApp(t *testing.T) *fiber.App {
+	t.Helper()
+
+	repo := NewRepository[widget, uint](setupTestDB(t), widgetOptions)
+	seedWidgets(t, repo)
+
+	h := NewHandler(HandlerConfig[widget, uint, createWidgetRequest, updateWidgetRequest]{
+		Options:    widgetOptions,
+		Repository: repo,
+		ParseID:    ParseUint,
+		NewEntity: func(req *createWidgetRequest) *widget {
+			return &widget{Name: req.Name, Color: req.Color}
+		},
+		Apply: func(req *updateWidgetRequest, w *widget) error {
+			if req.Color != nil {
+				w.Color = *req.Color
+			}
+			return nil
+		},
+		Errors: []ErrorMapping{{Err: errDuplicateName, Respond: resp.Conflict, Message: "Name already exists"}},
+		Hooks: Hooks[widget, uint, createWidgetRequest, updateWidgetRequest]{
+			// 기본 생성 앞에 중복 검사를 끼워 넣는 재정의 / Override that puts a duplicate check in front of the default create
+			Create: func(c *fiber.Ctx, req *createWidgetRequest) (*widget, error) {
+				if req.Name == "alpha" {
+					return nil, errDuplicateName
+				}
+				w := &widget{Name: req.Name, Color: req.Color}
+				return w, repo.Create(c.UserContext(), w)
+			},
+		},
+	})
+
+	app := fiber.New()
+	app.Get("/widgets", h.List)
+	app.Post("/widgets", h.Create)
+	app.Get("/widgets/:id", h.GetByID)
+	app.Put("/widgets/:id", h.Update)
+	app.Delete("/widgets/:id", h.Delete)
+	return app
+}
+
+func TestHandler(t *testing.T) {
+	testCases := []struct {
+		name         string
+		method       string
+		target       string
+		body         string
+		expectedCode int
+		errorCode    string
+		expectedData string
+	}{
+		{name: "create", method: fiber.MethodPost, target: "/widgets", body: `{"name":"echo","color":"blue"}`, expectedCode: fiber.StatusCreated, expectedData: `{"id":5,"name":"echo","color":"blue"}`},
+		{name: "create fails validation", method: fiber.MethodPost, target: "/widgets", body: `{"color":"blue"}`, expectedCode: fiber.StatusBadRequest, errorCode: "BAD_REQUEST"},
+		{name: "create maps domain error", method: fiber.MethodPost, target: "/widgets", body: `{"name":"alpha"}`, expectedCode: fiber.StatusConflict, errorCode: "CONFLICT"},
+		{name: "get", method: fiber.MethodGet, target: "/widgets/2", expectedCode: fiber.StatusOK, expectedData: `{"id":2,"name":"alpha","color":"blue"}`},
+		{name: "get invalid id", method: fiber.MethodGet, target: "/widgets/abc", expectedCode: fiber.StatusBadRequest, errorCode: "BAD_REQUEST"},
+		{name: "get missing", method: fiber.MethodGet, target: "/widgets/99", expectedCode: fiber.StatusNotFound, errorCode: "NOT_FOUND"},
+		{name: "update", method: fiber.MethodPut, target: "/widgets/2", body: `{"color":"green"}`, expectedCode: fiber.StatusOK, expectedData: `{"id":2,"name":"alpha","color":"green"}`},
+		{name: "update missing", method: fiber.MethodPut, target: "/widgets/99", body: `{"color":"green"}`, expectedCode: fiber.StatusNotFound, errorCode: "NOT_FOUND"},
+		{name: "delete", method: fiber.MethodDelete, target: "/widgets/1", expectedCode: fiber.StatusNoContent},
+		{name: "delete missing", method: fiber.MethodDelete, target: "/widgets/99", expectedCode: fiber.StatusNotFound, errorCode: "NOT_FOUND"},
+		{name: "list", method: fiber.MethodGet, target: "/widgets?color[eq]=red&sort=-name&limit=2", expectedCode: fiber.StatusOK, expectedData: `[{"id":1,"name":"delta","color":"red"},{"id":3,"name":"charlie","color":"red"}]`},
+		{name: "list invalid sort", method: fiber.MethodGet, target: "/widgets?sort=color", expectedCode: fiber.StatusBadRequest, errorCode: "BAD_REQUEST"},
+		{name: "list invalid filter", method: fiber.MethodGet, target: "/widgets?name[eq]=alpha", expectedCode: fiber.StatusBadRequest, errorCode: "BAD_REQUEST"},
+		{name: "list rejects cursor without a list hook", method: fiber.MethodGet, target: "/widgets?cursor=abc", expectedCode: fiber.StatusBadRequest, errorCode: "BAD_REQUEST"},
+		{name: "list rejects cursor with offset", method: fiber.MethodGet, target: "/widgets?cursor=abc&offset=1", expectedCode: fiber.StatusBadRequest, errorCode: "BAD_REQUEST"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := setupWidgetApp(t)
+
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			res, err := app.Test(req, -1)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedCode, res.StatusCode)
+			if tc.expectedCode == fiber.StatusNoContent {
+				return
+			}
+
+			var payload struct {
+				Data  json.RawMessage  `json:"data"`
+				Error resp.ErrorDetail `json:"error"`
+			}
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&payload))
+			assert.Equal(t, tc.errorCode, payload.Error.Code)
+			if tc.expectedData != "" {
+				assert.JSONEq(t, tc.expectedData, string(payload.Data))
+			}
+		})
+	}
+}
+
+func TestHandler_ListPagination(t *testing.T) {
+	app := setupWidgetApp(t)
+
+	res, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/widgets?offset=1&limit=500", nil), -1)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var payload resp.PaginatedResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&payload))
+	total := int64(4)
+	assert.Equal(t, resp.Pagination{Offset: 1, Limit: DefaultLimit, Total: &total}, payload.Pagination)
+	assert.Len(t, payload.Data, 3)
+}
+
+func TestHandler_ListCursorOmitsTotal(t *testing.T) {
+	repo := NewRepository[widget, uint](setupTestDB(t), widgetOptions)
+	seedWidgets(t, repo)
+
+	h := NewHandler(HandlerConfig[widget, uint, createWidgetRequest, updateWidgetRequest]{
+		Options:    widgetOptions,
+		Repository: repo,
+		ParseID:    ParseUint,
+		Hooks: Hooks[widget, uint, createWidgetRequest, updateWidgetRequest]{
+			// 이름을 커서로 쓰는 목록 훅 / List hook using the name as the cursor
+			List: func(c *fiber.Ctx, query *Query) (*Page[widget], error) {
+				query.Seek = func(db *gorm.DB) *gorm.DB { return db.Where("name > ?", query.Cursor) }
+				items, total, err := repo.List(c.UserContext(), query)
+				if err != nil {
+					return nil, err
+				}
+				return &Page[widget]{Items: items, Total: total}, nil
+			},
+		},
+	})
+	app := fiber.New()
+	app.Get("/widgets", h.List)
+
+	res, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/widgets?cursor=bravo", nil), -1)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var payload struct {
+		Data       []*widget      `json:"data"`
+		Pagination map[string]any `json:"pagination"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&payload))
+	assert.Equal(t, []string{"charlie", "delta"}, widgetNames(payload.Data))
+	assert.NotContains(t, payload.Pagination, "total")
+	assert.Equal(t, "bravo", payload.Pagination["cursor"])
+}
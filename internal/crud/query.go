@@ -0,0 +1,101 @@
+// Package crud provides a generic GORM repository and HTTP handler builder for CRUD domains
+package crud
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/listquery"
+)
+
+const (
+	// DefaultLimit 페이지 크기 기본값 / Default page size
+	DefaultLimit = 20
+	// MaxLimit 페이지 크기 최댓값 / Maximum page size
+	MaxLimit = 100
+
+	defaultPrimaryKey = "id"
+)
+
+// ErrNotFound 레코드 없음, gorm.ErrRecordNotFound와 같은 값 / Record not found, the same value as gorm.ErrRecordNotFound
+// 기존 errors.Is(err, gorm.ErrRecordNotFound) 검사가 그대로 동작 / Existing errors.Is(err, gorm.ErrRecordNotFound) checks keep working
+var ErrNotFound = gorm.ErrRecordNotFound
+
+// Options 리소스별 저장소와 목록 설정 / Per-resource repository and listing settings
+type Options struct {
+	// Name 오류 메시지에 쓰는 단수형 이름 (예: "user") / Singular name used in error messages (e.g. "user")
+	Name string
+	// Plural 복수형 이름, 비어 있으면 Name + "s" / Plural name, Name + "s" when empty
+	Plural string
+	// PrimaryKey 기본 키 컬럼, 비어 있으면 "id" / Primary key column, "id" when empty
+	PrimaryKey string
+	// Filters "field[op]=value" 필터 허용 컬럼 / Columns allowed in "field[op]=value" filters
+	Filters listquery.Schema
+	// SortableFields 정렬 허용 컬럼 / Columns allowed in the sort expression
+	SortableFields []string
+	// DefaultSort sort 파라미터가 없을 때의 정렬 식 / Sort expression used when sort is absent
+	DefaultSort string
+}
+
+// SortFields 정렬 식 파싱 (기본 키 동순위 해소 포함) / Parse a sort expression including the primary key tie-breaker
+func (o Options) SortFields(raw string) ([]listquery.SortField, error) {
+	if raw == "" {
+		raw = o.DefaultSort
+	}
+	fields, err := listquery.ParseSort(raw, o.SortableFields)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		fields, _ = listquery.ParseSort(o.DefaultSort, o.SortableFields)
+	}
+	return listquery.WithTieBreaker(fields, o.primaryKey()), nil
+}
+
+func (o Options) plural() string {
+	if o.Plural != "" {
+		return o.Plural
+	}
+	return o.Name + "s"
+}
+
+func (o Options) primaryKey() string {
+	if o.PrimaryKey != "" {
+		return o.PrimaryKey
+	}
+	return defaultPrimaryKey
+}
+
+// Query 목록 조회 조건 / List query
+type Query struct {
+	Offset int    `query:"offset"`
+	Limit  int    `query:"limit"`
+	Sort   string `query:"sort"`
+	// Cursor 도메인이 해석하는 불투명 커서 / Opaque cursor interpreted by the domain
+	Cursor string `query:"cursor"`
+	// Filter "field[op]=value" 형식의 구조화된 필터 / Structured "field[op]=value" filters
+	Filter listquery.Filter `query:"-"`
+
+	// Scope 개수와 페이지 모두에 적용되는 추가 조건 / Extra conditions applied to both the count and the page
+	Scope func(db *gorm.DB) *gorm.DB `query:"-"`
+	// Seek 페이지에만 적용되는 키셋 조건, 있으면 Offset 대신 사용 / Keyset condition applied to the page only, replacing Offset
+	Seek func(db *gorm.DB) *gorm.DB `query:"-"`
+}
+
+// Normalize 페이지네이션 기본값 설정 / Set pagination defaults
+func (q *Query) Normalize() {
+	if q.Limit <= 0 || q.Limit > MaxLimit {
+		q.Limit = DefaultLimit
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+}
+
+// Page 목록 조회 결과 / List result
+type Page[T any] struct {
+	Items []*T
+	// Total 전체 개수, 커서 모드 응답에서는 생략 / Total count; left out of cursor-mode responses
+	Total int64
+	// NextCursor 다음 페이지 커서, 마지막 페이지면 빈 값 / Cursor of the next page, empty on the last page
+	NextCursor string
+}
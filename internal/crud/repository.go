@@ -0,0 +1,125 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/listquery"
+)
+
+// Repository 범용 CRUD 저장소 인터페이스 / Generic CRUD repository interface
+// 모든 메서드는 ctx에 실린 db.TxManager 트랜잭션에 자동으로 참여 / Every method joins the db.TxManager transaction carried by ctx
+// 도메인 저장소는 이를 임베드하고 필요한 메서드만 다시 정의 / Domain repositories embed it and redefine only the methods they need
+type Repository[T any, ID comparable] interface {
+	Create(ctx context.Context, entity *T) error
+	// GetByID 없으면 ErrNotFound를 감싼 오류 반환 / Returns an error wrapping ErrNotFound when missing
+	GetByID(ctx context.Context, id ID) (*T, error)
+	// Update 기본 키와 생성 시각을 제외한 모든 컬럼 저장 / Save every column except the primary key and creation time
+	Update(ctx context.Context, entity *T) error
+	Delete(ctx context.Context, id ID) error
+	// List 필터, 정렬, 페이지네이션 적용 목록과 전체 개수 / Filtered, sorted, paginated page and the total count
+	// 키셋(Seek) 모드에서는 개수를 세지 않고 0을 반환 / In keyset (Seek) mode nothing is counted and the total is 0
+	List(ctx context.Context, query *Query) ([]*T, int64, error)
+	// Conn ctx의 트랜잭션 또는 기본 연결 / The transaction carried by ctx, or the base connection
+	Conn(ctx context.Context) *gorm.DB
+}
+
+// repository GORM 기반 구현체 / GORM-backed implementation
+type repository[T any, ID comparable] struct {
+	db   *gorm.DB
+	opts Options
+}
+
+// NewRepository 새 범용 저장소 생성 / Create new generic repository
+func NewRepository[T any, ID comparable](db *gorm.DB, opts Options) Repository[T, ID] {
+	return &repository[T, ID]{db: db, opts: opts}
+}
+
+// Create 레코드 생성 / Create record
+func (r *repository[T, ID]) Create(ctx context.Context, entity *T) error {
+	if err := r.Conn(ctx).Create(entity).Error; err != nil {
+		return fmt.Errorf("failed to create %s: %w", r.opts.Name, err)
+	}
+	return nil
+}
+
+// GetByID ID로 레코드 조회 / Get record by ID
+func (r *repository[T, ID]) GetByID(ctx context.Context, id ID) (*T, error) {
+	var entity T
+	if err := r.Conn(ctx).Where(r.opts.primaryKey()+" = ?", id).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s not found with id %v: %w", r.opts.Name, id, err)
+		}
+		return nil, fmt.Errorf("failed to get %s by id: %w", r.opts.Name, err)
+	}
+	return &entity, nil
+}
+
+// Update 레코드 업데이트 / Update record
+func (r *repository[T, ID]) Update(ctx context.Context, entity *T) error {
+	result := r.Conn(ctx).Model(entity).
+		Select("*").
+		Omit(r.opts.primaryKey(), "created_at").
+		Updates(entity)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update %s: %w", r.opts.Name, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to update %s: %w", r.opts.Name, ErrNotFound)
+	}
+	return nil
+}
+
+// Delete 레코드 삭제 (DeletedAt이 있으면 소프트 삭제) / Delete record (soft delete when the model has DeletedAt)
+func (r *repository[T, ID]) Delete(ctx context.Context, id ID) error {
+	result := r.Conn(ctx).Where(r.opts.primaryKey()+" = ?", id).Delete(new(T))
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete %s: %w", r.opts.Name, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s not found with id %v: %w", r.opts.Name, id, ErrNotFound)
+	}
+	return nil
+}
+
+// List 레코드 목록 조회 / List records
+func (r *repository[T, ID]) List(ctx context.Context, query *Query) ([]*T, int64, error) {
+	// 잘못된 정렬은 쿼리 전에 거부 / Reject an invalid sort before querying
+	sortFields, err := r.opts.SortFields(query.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 필터가 적용된 기본 쿼리 / Base query with filters applied
+	tx := listquery.ApplyFilter(r.Conn(ctx).Model(new(T)), query.Filter)
+	if query.Scope != nil {
+		tx = query.Scope(tx)
+	}
+
+	// 페이지네이션 적용 (키셋 또는 오프셋), 큰 테이블에서 비싼 COUNT는 오프셋 모드에서만 실행
+	// Apply pagination (keyset or offset); the COUNT, costly on large tables, only runs in offset mode
+	var total int64
+	if query.Seek != nil {
+		tx = query.Seek(tx)
+	} else {
+		if err := tx.Count(&total).Error; err != nil {
+			return nil, 0, fmt.Errorf("failed to count %s: %w", r.opts.plural(), err)
+		}
+		tx = tx.Offset(query.Offset)
+	}
+
+	var items []*T
+	if err := listquery.ApplySort(tx.Limit(query.Limit), sortFields).Find(&items).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list %s: %w", r.opts.plural(), err)
+	}
+	return items, total, nil
+}
+
+// Conn ctx의 트랜잭션 또는 기본 연결 반환 / Return the transaction carried by ctx, or the base connection
+func (r *repository[T, ID]) Conn(ctx context.Context) *gorm.DB {
+	return db.Conn(ctx, r.db)
+}
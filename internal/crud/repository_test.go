@@ -0,0 +1,188 @@
+package crud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/listquery"
+)
+
+type widget struct {
+	ID        uint           `json:"id" gorm:"primarykey"`
+	Name      string         `json:"name"`
+	Color     string         `json:"color"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+var widgetOptions = Options{
+	Name:           "widget",
+	Filters:        listquery.Schema{"color": {Type: listquery.TypeString, Ops: listquery.EqualityOps}},
+	SortableFields: []string{"id", "name"},
+	DefaultSort:    "name",
+}
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	database, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, database.AutoMigrate(&widget{}))
+	return database
+}
+
+func seedWidgets(t *testing.T, repo Repository[widget, uint]) {
+	t.Helper()
+
+	for _, w := range []*widget{
+		{Name: "delta", Color: "red"},
+		{Name: "alpha", Color: "blue"},
+		{Name: "charlie", Color: "red"},
+		{Name: "bravo", Color: "red"},
+	} {
+		require.NoError(t, repo.Create(t.Context(), w))
+	}
+}
+
+func mustFilter(t *testing.T, params map[string]string) listquery.Filter {
+	t.Helper()
+
+	filter, err := listquery.ParseFilter(params, widgetOptions.Filters)
+	require.NoError(t, err)
+	return filter
+}
+
+func widgetNames(widgets []*widget) []string {
+	names := make([]string, len(widgets))
+	for i, w := range widgets {
+		names[i] = w.Name
+	}
+	return names
+}
+
+func TestRepository_CRUD(t *testing.T) {
+	repo := NewRepository[widget, uint](setupTestDB(t), widgetOptions)
+	ctx := t.Context()
+
+	w := &widget{Name: "alpha", Color: "blue"}
+	require.NoError(t, repo.Create(ctx, w))
+	require.NotZero(t, w.ID)
+
+	got, err := repo.GetByID(ctx, w.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alpha", got.Name)
+
+	got.Color = "green"
+	require.NoError(t, repo.Update(ctx, got))
+	got, err = repo.GetByID(ctx, w.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "green", got.Color)
+
+	require.NoError(t, repo.Delete(ctx, w.ID))
+	_, err = repo.GetByID(ctx, w.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRepository_NotFound(t *testing.T) {
+	repo := NewRepository[widget, uint](setupTestDB(t), widgetOptions)
+	ctx := t.Context()
+
+	_, err := repo.GetByID(ctx, 99)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, repo.Update(ctx, &widget{ID: 99, Name: "ghost"}), ErrNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, 99), ErrNotFound)
+}
+
+func TestRepository_List(t *testing.T) {
+	repo := NewRepository[widget, uint](setupTestDB(t), widgetOptions)
+	seedWidgets(t, repo)
+
+	testCases := []struct {
+		name          string
+		query         Query
+		expectedNames []string
+		expectedTotal int64
+		expectedError error
+	}{
+		{
+			name:          "default sort",
+			query:         Query{Limit: 10},
+			expectedNames: []string{"alpha", "bravo", "charlie", "delta"},
+			expectedTotal: 4,
+		},
+		{
+			name:          "offset and limit",
+			query:         Query{Offset: 1, Limit: 2, Sort: "-name"},
+			expectedNames: []string{"charlie", "bravo"},
+			expectedTotal: 4,
+		},
+		{
+			name:          "filter",
+			query:         Query{Limit: 10, Filter: mustFilter(t, map[string]string{"color[eq]": "red"})},
+			expectedNames: []string{"bravo", "charlie", "delta"},
+			expectedTotal: 3,
+		},
+		{
+			name: "scope counts",
+			query: Query{
+				Limit: 10,
+				Scope: func(db *gorm.DB) *gorm.DB { return db.Where("name <> ?", "alpha") },
+			},
+			expectedNames: []string{"bravo", "charlie", "delta"},
+			expectedTotal: 3,
+		},
+		{
+			name: "seek skips the count",
+			query: Query{
+				Limit: 10,
+				Scope: func(db *gorm.DB) *gorm.DB { return db.Where("name <> ?", "alpha") },
+				Seek:  func(db *gorm.DB) *gorm.DB { return db.Where("name > ?", "bravo") },
+			},
+			expectedNames: []string{"charlie", "delta"},
+			expectedTotal: 0,
+		},
+		{
+			name:          "unknown sort field",
+			query:         Query{Limit: 10, Sort: "color"},
+			expectedError: listquery.ErrInvalidSort,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			widgets, total, err := repo.List(t.Context(), &tc.query)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedNames, widgetNames(widgets))
+			assert.Equal(t, tc.expectedTotal, total)
+		})
+	}
+}
+
+func TestOptions_SortFields(t *testing.T) {
+	testCases := []struct {
+		name     string
+		raw      string
+		expected []listquery.SortField
+	}{
+		{name: "default", raw: "", expected: []listquery.SortField{{Field: "name"}, {Field: "id"}}},
+		{name: "descending", raw: "-name", expected: []listquery.SortField{{Field: "name", Desc: true}, {Field: "id", Desc: true}}},
+		{name: "primary key only", raw: "id", expected: []listquery.SortField{{Field: "id"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fields, err := widgetOptions.SortFields(tc.raw)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, fields)
+		})
+	}
+}
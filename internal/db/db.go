@@ -116,14 +116,15 @@ func logConnectionSuccess(cfg *config.Config) {
 }
 
 // HealthCheck 데이터베이스 상태 확인 / Check database health
-func HealthCheck(db *gorm.DB) error {
+// ctx가 먼저 끝나면 ping도 중단 / The ping is aborted when ctx ends first
+func HealthCheck(ctx context.Context, db *gorm.DB) error {
 	sqlDB, err := db.DB()
 	if err != nil {
 		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
 
 	// 1초 타임아웃으로 ping 실행 / Execute ping with 1 second timeout
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
 
 	return sqlDB.PingContext(ctx)
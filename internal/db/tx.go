@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultTxMaxAttempts = 3
+	defaultTxBackoff     = 50 * time.Millisecond
+	maxTxBackoff         = time.Second
+
+	// MySQL 교착 상태 오류 번호 / MySQL deadlock error number
+	mysqlDeadlock = 1213
+	// Postgres 직렬화 실패와 교착 상태 SQLSTATE / Postgres serialization failure and deadlock SQLSTATEs
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+// TxManager 컨텍스트에 트랜잭션을 싣는 작업 단위 관리자 / Unit-of-work manager that carries the transaction in the context
+type TxManager interface {
+	// Do fn을 트랜잭션 안에서 실행, fn이 받는 ctx에 트랜잭션이 실림 / Run fn in a transaction carried by the ctx fn receives
+	// 이미 트랜잭션 안이면 세이브포인트로 중첩되고, 최상위에서만 직렬화 실패와 교착 상태를 재시도
+	// Inside a transaction it nests as a savepoint; only the outermost call retries serialization failures and deadlocks
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// TxConfig 트랜잭션 재시도 설정 / Transaction retry settings
+type TxConfig struct {
+	// MaxAttempts 최초 시도를 포함한 최대 시도 횟수 / Maximum attempts including the first
+	MaxAttempts int
+	// Backoff 첫 재시도 전 대기 시간, 재시도마다 두 배 / Wait before the first retry, doubled on each retry
+	Backoff time.Duration
+}
+
+// txManager GORM 기반 구현체 / GORM-backed implementation
+type txManager struct {
+	db  *gorm.DB
+	cfg TxConfig
+}
+
+// txKey 컨텍스트에 실린 트랜잭션의 키 / Context key of the carried transaction
+type txKey struct{}
+
+// NewTxManager 새 트랜잭션 관리자 생성 / Create new transaction manager
+func NewTxManager(db *gorm.DB, cfg TxConfig) TxManager {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultTxMaxAttempts
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = defaultTxBackoff
+	}
+	return &txManager{db: db, cfg: cfg}
+}
+
+// Conn ctx에 실린 트랜잭션, 없으면 fallback을 ctx와 함께 반환 / The transaction carried by ctx, or fallback bound to ctx
+// 저장소는 이 함수로 연결을 얻어 진행 중인 트랜잭션에 자동으로 참여 / Repositories get their handle here to join an ambient transaction
+func Conn(ctx context.Context, fallback *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return fallback.WithContext(ctx)
+}
+
+// Do 트랜잭션 실행 / Run a transaction
+func (m *txManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// 진행 중인 트랜잭션이 있으면 세이브포인트 / Savepoint within an ambient transaction
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, tx))
+		})
+	}
+
+	backoff := m.cfg.Backoff
+	for attempt := 1; ; attempt++ {
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, tx))
+		})
+		if err == nil || !IsRetryable(err) || attempt >= m.cfg.MaxAttempts {
+			return err
+		}
+
+		zap.L().Warn("Retrying transaction",
+			zap.String("method", "db.txManager.Do"),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, maxTxBackoff)
+	}
+}
+
+// IsRetryable 트랜잭션 전체를 다시 실행하면 성공할 수 있는 오류인지 확인 / Check whether rerunning the whole transaction may succeed
+// MySQL 교착 상태와 Postgres 직렬화 실패, 교착 상태가 해당 / Covers MySQL deadlocks and Postgres serialization failures and deadlocks
+func IsRetryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDeadlock
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+	}
+	return false
+}
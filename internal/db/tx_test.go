@@ -0,0 +1,192 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type txRecord struct {
+	ID   uint
+	Name string
+}
+
+func setupTxDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	database, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	sqlDB, err := database.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, database.AutoMigrate(&txRecord{}))
+	return database
+}
+
+func recordNames(t *testing.T, database *gorm.DB) []string {
+	t.Helper()
+
+	var names []string
+	require.NoError(t, database.Model(&txRecord{}).Order("id").Pluck("name", &names).Error)
+	return names
+}
+
+func TestTxManager_Do(t *testing.T) {
+	errRollback := errors.New("rollback")
+
+	testCases := []struct {
+		name          string
+		fn            func(tx TxManager, database *gorm.DB) func(ctx context.Context) error
+		expectedError error
+		expectedNames []string
+	}{
+		{
+			name: "commits",
+			fn: func(_ TxManager, database *gorm.DB) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return Conn(ctx, database).Create(&txRecord{Name: "a"}).Error
+				}
+			},
+			expectedNames: []string{"a"},
+		},
+		{
+			name: "rolls back on error",
+			fn: func(_ TxManager, database *gorm.DB) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					require.NoError(t, Conn(ctx, database).Create(&txRecord{Name: "a"}).Error)
+					return errRollback
+				}
+			},
+			expectedError: errRollback,
+		},
+		{
+			name: "nested failure rolls back only its savepoint",
+			fn: func(tx TxManager, database *gorm.DB) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					require.NoError(t, Conn(ctx, database).Create(&txRecord{Name: "outer"}).Error)
+					err := tx.Do(ctx, func(ctx context.Context) error {
+						require.NoError(t, Conn(ctx, database).Create(&txRecord{Name: "inner"}).Error)
+						return errRollback
+					})
+					require.ErrorIs(t, err, errRollback)
+					return nil
+				}
+			},
+			expectedNames: []string{"outer"},
+		},
+		{
+			name: "nested success commits with the outer transaction",
+			fn: func(tx TxManager, database *gorm.DB) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					require.NoError(t, tx.Do(ctx, func(ctx context.Context) error {
+						return Conn(ctx, database).Create(&txRecord{Name: "inner"}).Error
+					}))
+					return errRollback
+				}
+			},
+			expectedError: errRollback,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			database := setupTxDB(t)
+			tx := NewTxManager(database, TxConfig{})
+
+			err := tx.Do(t.Context(), tc.fn(tx, database))
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.ElementsMatch(t, tc.expectedNames, recordNames(t, database))
+		})
+	}
+}
+
+func TestTxManager_Retries(t *testing.T) {
+	serializationFailure := &pgconn.PgError{Code: pgSerializationFailure}
+	errPermanent := errors.New("permanent")
+
+	testCases := []struct {
+		name             string
+		failures         int
+		err              error
+		maxAttempts      int
+		expectedError    error
+		expectedAttempts int
+		expectedNames    []string
+	}{
+		{name: "retries until success", failures: 2, err: serializationFailure, maxAttempts: 3, expectedAttempts: 3, expectedNames: []string{"attempt-3"}},
+		{name: "gives up after max attempts", failures: 5, err: serializationFailure, maxAttempts: 2, expectedError: serializationFailure, expectedAttempts: 2},
+		{name: "does not retry other errors", failures: 5, err: errPermanent, maxAttempts: 3, expectedError: errPermanent, expectedAttempts: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			database := setupTxDB(t)
+			tx := NewTxManager(database, TxConfig{MaxAttempts: tc.maxAttempts, Backoff: time.Millisecond})
+
+			attempts := 0
+			err := tx.Do(t.Context(), func(ctx context.Context) error {
+				attempts++
+				if err := Conn(ctx, database).Create(&txRecord{Name: fmt.Sprintf("attempt-%d", attempts)}).Error; err != nil {
+					return err
+				}
+				if attempts <= tc.failures {
+					return fmt.Errorf("failed to write: %w", tc.err)
+				}
+				return nil
+			})
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedAttempts, attempts)
+			assert.ElementsMatch(t, tc.expectedNames, recordNames(t, database))
+		})
+	}
+}
+
+func TestConn_WithoutTransaction(t *testing.T) {
+	database := setupTxDB(t)
+
+	require.NoError(t, Conn(t.Context(), database).Create(&txRecord{Name: "a"}).Error)
+	assert.Equal(t, []string{"a"}, recordNames(t, database))
+}
+
+func TestIsRetryable(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "mysql deadlock", err: &mysql.MySQLError{Number: mysqlDeadlock}, expected: true},
+		{name: "mysql duplicate key", err: &mysql.MySQLError{Number: 1062}},
+		{name: "postgres serialization failure", err: &pgconn.PgError{Code: pgSerializationFailure}, expected: true},
+		{name: "postgres deadlock", err: &pgconn.PgError{Code: pgDeadlockDetected}, expected: true},
+		{name: "postgres unique violation", err: &pgconn.PgError{Code: "23505"}},
+		{name: "wrapped", err: fmt.Errorf("failed to update user: %w", &pgconn.PgError{Code: pgDeadlockDetected}), expected: true},
+		{name: "other", err: errors.New("boom")},
+		{name: "nil"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsRetryable(tc.err))
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidCredentials is returned when the email or password is wrong or the account cannot log in.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	// ErrInvalidRefreshToken is returned when a refresh token is unknown, expired or revoked.
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+	// ErrRefreshTokenReused is returned when an already rotated refresh token is presented again; its whole family is revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+
+	// ErrTokenNotFound is returned when a refresh token lookup cannot find a matching row.
+	ErrTokenNotFound = errors.New("refresh token not found")
+
+	// ErrLockoutNotFound is returned when a lockout lookup cannot find a matching row.
+	ErrLockoutNotFound = errors.New("login lockout not found")
+)
+
+// ValidationError 요청 필드 검증 오류 / Request field validation error
+// Message는 클라이언트에 그대로 노출됨 / Message is returned to clients as-is
+type ValidationError struct {
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// LockedError 잠긴 계정이나 IP의 로그인 시도 / Login attempt against a locked account or IP
+// 어느 쪽이 잠겼는지는 알리지 않음 / Which of the two is locked is not disclosed
+type LockedError struct {
+	Until time.Time
+}
+
+// Error implements the error interface.
+func (e *LockedError) Error() string {
+	return "too many failed login attempts"
+}
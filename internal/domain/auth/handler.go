@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/middleware"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/password"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/resp"
+)
+
+const (
+	invalidCredentialsMessage  = "Invalid email or password"
+	invalidRefreshTokenMessage = "Invalid refresh token"
+	lockedMessage              = "Too many failed login attempts, try again later"
+	busyMessage                = "Too many logins in progress, try again shortly"
+)
+
+// Handler 인증 HTTP 핸들러 / Authentication HTTP handler
+type Handler struct {
+	service  Service
+	throttle Throttle
+}
+
+// NewHandler 새 인증 핸들러 생성 / Create new authentication handler
+func NewHandler(service Service, throttle Throttle) *Handler {
+	return &Handler{service: service, throttle: throttle}
+}
+
+// Login 로그인 / Log in
+// @Summary Log in
+// @Description Exchange an email and password for a short-lived access token and a rotating refresh token. Every credential failure returns the same 401. Repeated failures lock the email or the client IP and return 429 with Retry-After. When every password hashing slot is busy the request returns 503 with Retry-After.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body LoginRequest true "Login request"
+// @Success 200 {object} resp.SuccessResponse{data=TokenResponse}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 401 {object} resp.ErrorResponse
+// @Failure 429 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Failure 503 {object} resp.ErrorResponse
+// @Router /v1/auth/login [post]
+func (h *Handler) Login(c *fiber.Ctx) error {
+	var req LoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return resp.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	tokens, err := h.service.Login(c.UserContext(), &req, middleware.GetClientIP(c))
+	if err != nil {
+		var validationErr *ValidationError
+		var lockedErr *LockedError
+		switch {
+		case errors.As(err, &validationErr):
+			return resp.BadRequest(c, validationErr.Message)
+		case errors.Is(err, ErrInvalidCredentials):
+			return resp.Unauthorized(c, invalidCredentialsMessage)
+		case errors.As(err, &lockedErr):
+			retryAfter := math.Ceil(time.Until(lockedErr.Until).Seconds())
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(max(int(retryAfter), 1)))
+			return resp.TooManyRequests(c, lockedMessage)
+		case errors.Is(err, password.ErrBusy):
+			c.Set(fiber.HeaderRetryAfter, "1")
+			return resp.ServiceUnavailable(c, busyMessage)
+		}
+		zap.L().Error("Failed to log in", zap.Error(err))
+		return resp.InternalServerError(c, "Failed to log in")
+	}
+
+	return resp.Success(c, tokens)
+}
+
+// Refresh 토큰 갱신 / Refresh tokens
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new token pair. Each refresh token works once; presenting a used one revokes the whole session.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body RefreshRequest true "Refresh request"
+// @Success 200 {object} resp.SuccessResponse{data=TokenResponse}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 401 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/auth/refresh [post]
+func (h *Handler) Refresh(c *fiber.Ctx) error {
+	var req RefreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return resp.BadRequest(c, "Invalid request body", err.Error())
+	}
+	if err := req.Validate(); err != nil {
+		return resp.BadRequest(c, err.Error())
+	}
+
+	tokens, err := h.service.Refresh(c.UserContext(), req.RefreshToken)
+	if err != nil {
+		if isRefreshTokenError(err) {
+			return resp.Unauthorized(c, invalidRefreshTokenMessage)
+		}
+		zap.L().Error("Failed to refresh tokens", zap.Error(err))
+		return resp.InternalServerError(c, "Failed to refresh tokens")
+	}
+
+	return resp.Success(c, tokens)
+}
+
+// Logout 로그아웃 / Log out
+// @Summary Log out
+// @Description Revoke the session the refresh token belongs to. Access tokens already issued stay valid until they expire.
+// @Tags auth
+// @Accept json
+// @Param token body RefreshRequest true "Logout request"
+// @Success 204
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 401 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/auth/logout [post]
+func (h *Handler) Logout(c *fiber.Ctx) error {
+	var req RefreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return resp.BadRequest(c, "Invalid request body", err.Error())
+	}
+	if err := req.Validate(); err != nil {
+		return resp.BadRequest(c, err.Error())
+	}
+
+	if err := h.service.Logout(c.UserContext(), req.RefreshToken); err != nil {
+		if isRefreshTokenError(err) {
+			return resp.Unauthorized(c, invalidRefreshTokenMessage)
+		}
+		zap.L().Error("Failed to log out", zap.Error(err))
+		return resp.InternalServerError(c, "Failed to log out")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// isRefreshTokenError 재사용도 같은 응답으로 숨김 / Reuse is hidden behind the same response
+func isRefreshTokenError(err error) bool {
+	return errors.Is(err, ErrInvalidRefreshToken) || errors.Is(err, ErrRefreshTokenReused)
+}
+
+// ListLockouts 잠금 목록 조회 / List lockouts
+// @Summary List login lockouts
+// @Description List emails and client IPs that are locked out of login, latest expiry first.
+// @Tags auth
+// @Produce json
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination" default(20)
+// @Success 200 {object} resp.PaginatedResponse{data=[]Lockout}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/admin/lockouts [get]
+func (h *Handler) ListLockouts(c *fiber.Ctx) error {
+	var query ListLockoutsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return resp.BadRequest(c, "Invalid query parameters", err.Error())
+	}
+
+	lockouts, total, err := h.throttle.List(c.UserContext(), &query)
+	if err != nil {
+		zap.L().Error("Failed to list login lockouts", zap.Error(err))
+		return resp.InternalServerError(c, "Failed to list login lockouts")
+	}
+
+	return resp.SuccessWithPagination(c, lockouts, query.Offset, query.Limit, total)
+}
+
+// Unlock 잠금 해제 / Unlock
+// @Summary Unlock login
+// @Description Lift the lockout of an email, a client IP or both, and forget their recent failures and lockout level.
+// @Tags auth
+// @Accept json
+// @Param unlock body UnlockRequest true "Unlock request"
+// @Success 204
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/admin/lockouts/unlock [post]
+func (h *Handler) Unlock(c *fiber.Ctx) error {
+	var req UnlockRequest
+	if err := c.BodyParser(&req); err != nil {
+		return resp.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	if err := h.throttle.Unlock(c.UserContext(), &req); err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return resp.BadRequest(c, validationErr.Message)
+		}
+		zap.L().Error("Failed to unlock login", zap.Error(err))
+		return resp.InternalServerError(c, "Failed to unlock login")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+)
+
+// LockoutStore 로그인 실패와 잠금 저장소 인터페이스 / Login failure and lockout store interface
+type LockoutStore interface {
+	AddFailure(ctx context.Context, kind LockoutKind, identifier string, at time.Time) error
+	// CountFailures since 이후 실패 수 / Number of failures after since
+	CountFailures(ctx context.Context, kind LockoutKind, identifier string, since time.Time) (int64, error)
+	ClearFailures(ctx context.Context, kind LockoutKind, identifier string) error
+	GetLockout(ctx context.Context, kind LockoutKind, identifier string) (*Lockout, error)
+	// SaveLockout 잠금 생성 또는 갱신 / Create or update a lockout
+	SaveLockout(ctx context.Context, lockout *Lockout) error
+	DeleteLockout(ctx context.Context, kind LockoutKind, identifier string) error
+	// ListActive now 시점에 잠금 중인 항목, 늦게 풀리는 순 / Lockouts in force at now, latest expiry first
+	ListActive(ctx context.Context, now time.Time, offset, limit int) ([]*Lockout, int64, error)
+	// Purge failedBefore 이전 실패와 releasedBefore 이전에 풀린 잠금 삭제
+	// Delete failures before failedBefore and lockouts released before releasedBefore
+	Purge(ctx context.Context, failedBefore, releasedBefore time.Time) error
+}
+
+// lockoutStore GORM 기반 저장소 구현체 / GORM-backed store implementation
+type lockoutStore struct {
+	db *gorm.DB
+}
+
+// NewLockoutStore 새 잠금 저장소 생성 / Create new lockout store
+func NewLockoutStore(db *gorm.DB) LockoutStore {
+	return &lockoutStore{db: db}
+}
+
+// AddFailure 실패 기록 / Record failure
+func (s *lockoutStore) AddFailure(ctx context.Context, kind LockoutKind, identifier string, at time.Time) error {
+	failure := &LoginFailure{Kind: kind, Identifier: identifier, CreatedAt: at}
+	if err := db.Conn(ctx, s.db).Create(failure).Error; err != nil {
+		return fmt.Errorf("failed to record login failure: %w", err)
+	}
+	return nil
+}
+
+// CountFailures 실패 수 조회 / Count failures
+func (s *lockoutStore) CountFailures(ctx context.Context, kind LockoutKind, identifier string, since time.Time) (int64, error) {
+	var count int64
+	err := db.Conn(ctx, s.db).Model(&LoginFailure{}).
+		Where("kind = ? AND identifier = ? AND created_at > ?", kind, identifier, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count login failures: %w", err)
+	}
+	return count, nil
+}
+
+// ClearFailures 실패 기록 삭제 / Clear failures
+func (s *lockoutStore) ClearFailures(ctx context.Context, kind LockoutKind, identifier string) error {
+	err := db.Conn(ctx, s.db).Where("kind = ? AND identifier = ?", kind, identifier).Delete(&LoginFailure{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to clear login failures: %w", err)
+	}
+	return nil
+}
+
+// GetLockout 잠금 조회 / Get lockout
+func (s *lockoutStore) GetLockout(ctx context.Context, kind LockoutKind, identifier string) (*Lockout, error) {
+	var lockout Lockout
+	if err := db.Conn(ctx, s.db).Where("kind = ? AND identifier = ?", kind, identifier).First(&lockout).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLockoutNotFound
+		}
+		return nil, fmt.Errorf("failed to get login lockout: %w", err)
+	}
+	return &lockout, nil
+}
+
+// SaveLockout 잠금 저장 / Save lockout
+func (s *lockoutStore) SaveLockout(ctx context.Context, lockout *Lockout) error {
+	if err := db.Conn(ctx, s.db).Save(lockout).Error; err != nil {
+		return fmt.Errorf("failed to save login lockout: %w", err)
+	}
+	return nil
+}
+
+// DeleteLockout 잠금 삭제 / Delete lockout
+func (s *lockoutStore) DeleteLockout(ctx context.Context, kind LockoutKind, identifier string) error {
+	err := db.Conn(ctx, s.db).Where("kind = ? AND identifier = ?", kind, identifier).Delete(&Lockout{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete login lockout: %w", err)
+	}
+	return nil
+}
+
+// ListActive 잠금 목록 조회 / List lockouts
+func (s *lockoutStore) ListActive(ctx context.Context, now time.Time, offset, limit int) ([]*Lockout, int64, error) {
+	conn := db.Conn(ctx, s.db)
+
+	var total int64
+	if err := conn.Model(&Lockout{}).Where("locked_until > ?", now).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count login lockouts: %w", err)
+	}
+
+	var lockouts []*Lockout
+	err := conn.Where("locked_until > ?", now).Order("locked_until DESC, id").Offset(offset).Limit(limit).Find(&lockouts).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list login lockouts: %w", err)
+	}
+	return lockouts, total, nil
+}
+
+// Purge 오래된 기록 삭제 / Delete old records
+func (s *lockoutStore) Purge(ctx context.Context, failedBefore, releasedBefore time.Time) error {
+	conn := db.Conn(ctx, s.db)
+	if err := conn.Where("created_at < ?", failedBefore).Delete(&LoginFailure{}).Error; err != nil {
+		return fmt.Errorf("failed to purge login failures: %w", err)
+	}
+	if err := conn.Where("locked_until < ?", releasedBefore).Delete(&Lockout{}).Error; err != nil {
+		return fmt.Errorf("failed to purge login lockouts: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// RefreshTokenPrefix 리프레시 토큰 접두사 / Refresh token prefix
+	RefreshTokenPrefix = "spr"
+	// TokenType 액세스 토큰 유형 / Access token type
+	TokenType = "Bearer"
+
+	refreshTokenBytes = 32
+	familyIDBytes     = 16
+)
+
+// RefreshToken 서버에 저장되는 리프레시 토큰 모델 / Server-side refresh token model
+// 같은 로그인에서 교체된 토큰은 한 FamilyID(세션)를 공유 / Tokens rotated from the same login share one FamilyID (session)
+type RefreshToken struct {
+	ID       uint   `json:"id" gorm:"primarykey"`
+	FamilyID string `json:"family_id" gorm:"not null;size:32;index"`
+	UserID   uint   `json:"user_id" gorm:"not null;index"`
+	// Hash 토큰 전체의 SHA-256 hex, 원문은 저장하지 않음 / SHA-256 hex of the whole token; the plaintext is never stored
+	Hash      string     `json:"-" gorm:"not null;size:64;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null;index"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName 테이블 이름 지정 / Specify table name
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// IsUsable 사용·폐기·만료되지 않은 토큰인지 확인 / Report whether the token is unused, unrevoked and unexpired
+func (t *RefreshToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// LoginRequest 로그인 요청 / Login request
+type LoginRequest struct {
+	Email    string `json:"email" example:"john@example.com"`
+	Password string `json:"password" example:"correct horse battery staple"`
+}
+
+// Validate 필수 필드 확인 / Check required fields
+func (r *LoginRequest) Validate() error {
+	r.Email = strings.TrimSpace(r.Email)
+	if r.Email == "" || r.Password == "" {
+		return &ValidationError{Message: "Email and password are required"}
+	}
+	return nil
+}
+
+// RefreshRequest 토큰 갱신과 로그아웃 요청 / Token refresh and logout request
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" example:"spr_3q2-7wEXAMPLEtokenVALUEonlyRETURNEDonce"`
+}
+
+// Validate 필수 필드 확인 / Check required fields
+func (r *RefreshRequest) Validate() error {
+	if r.RefreshToken == "" {
+		return &ValidationError{Message: "refresh_token is required"}
+	}
+	return nil
+}
+
+// TokenResponse 발급된 토큰 쌍 / Issued token pair
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type" example:"Bearer"`
+	// ExpiresIn 액세스 토큰 유효 시간(초) / Access token lifetime in seconds
+	ExpiresIn        int64     `json:"expires_in" example:"900"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// generateRefreshToken 새 리프레시 토큰 생성 / Generate a new refresh token
+func generateRefreshToken() (string, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return RefreshTokenPrefix + "_" + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// generateFamilyID 새 세션 ID 생성 / Generate a new session ID
+func generateFamilyID() (string, error) {
+	buf := make([]byte, familyIDBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate session id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hashToken 저장용 토큰 해시 / Token hash for storage
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
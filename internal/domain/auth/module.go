@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/domain/user"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/jwt"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/metrics"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/middleware"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/module"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/scheduler"
+)
+
+// purgeInterval 만료 리프레시 토큰과 오래된 로그인 실패 정리 주기 / How often expired refresh tokens and stale login failures are cleaned up
+const purgeInterval = time.Hour
+
+func init() {
+	module.Register(module.Registration{Name: "auth", New: NewModule})
+}
+
+// Module 인증 도메인 모듈 / Authentication domain module
+type Module struct {
+	module.Base
+	deps     module.Deps
+	service  Service
+	throttle Throttle
+	handler  *Handler
+}
+
+// NewModule 새 인증 모듈 생성 / Create new authentication module
+func NewModule(deps module.Deps) module.Module {
+	cfg := deps.Config
+	users := user.NewService(user.NewRepository(deps.DB), deps.Tx, deps.Passwords)
+	throttle := NewThrottle(NewLockoutStore(deps.DB), deps.Tx, ThrottleConfig{
+		Window:             cfg.AuthFailureWindow,
+		MaxAccountFailures: cfg.AuthMaxAccountFailures,
+		MaxIPFailures:      cfg.AuthMaxIPFailures,
+		LockoutBase:        cfg.AuthLockoutBase,
+		LockoutMax:         cfg.AuthLockoutMax,
+		LockoutReset:       cfg.AuthLockoutReset,
+	})
+	service := NewService(users, NewStore(deps.DB), deps.Tx, throttle, Config{
+		Secret:     []byte(cfg.JWTHMACSecret),
+		Issuer:     cfg.JWTIssuer,
+		Audience:   cfg.JWTAudience,
+		AccessTTL:  cfg.AuthAccessTokenTTL,
+		RefreshTTL: cfg.AuthRefreshTokenTTL,
+		Scopes:     cfg.AuthTokenScopes,
+	})
+	return &Module{deps: deps, service: service, throttle: throttle, handler: NewHandler(service, throttle)}
+}
+
+// Name 모듈 이름 / Module name
+func (m *Module) Name() string { return "auth" }
+
+// Models 리프레시 토큰, 로그인 실패, 잠금 모델 / Refresh token, login failure, and lockout models
+func (m *Module) Models() []any { return []any{&RefreshToken{}, &LoginFailure{}, &Lockout{}} }
+
+// RegisterPublicRoutes 인증 없이 호출하는 로그인 라우트 등록 / Register login routes that are called without credentials
+func (m *Module) RegisterPublicRoutes(v1 fiber.Router) {
+	if !m.deps.Config.AuthLoginEnabled {
+		return
+	}
+	h := m.handler
+
+	public := middleware.Public()
+
+	auth := v1.Group("/auth")
+	auth.Post("/login", public, h.Login)     // POST /v1/auth/login
+	auth.Post("/refresh", public, h.Refresh) // POST /v1/auth/refresh
+	auth.Post("/logout", public, h.Logout)   // POST /v1/auth/logout
+}
+
+// RegisterRoutes 잠금 관리 라우트 등록 / Register lockout admin routes
+func (m *Module) RegisterRoutes(v1 fiber.Router) {
+	if !m.deps.Config.AuthLoginEnabled {
+		return
+	}
+	h := m.handler
+
+	read := middleware.RequireScopes("lockouts:read")
+	write := middleware.RequireScopes("lockouts:write")
+
+	lockouts := v1.Group("/admin/lockouts")
+	lockouts.Get("/", read, h.ListLockouts)   // GET /v1/admin/lockouts
+	lockouts.Post("/unlock", write, h.Unlock) // POST /v1/admin/lockouts/unlock
+}
+
+// SessionChecker 로그아웃하거나 폐기된 세션의 액세스 토큰 거부 / Reject access tokens of logged-out or revoked sessions
+func (m *Module) SessionChecker() jwt.SessionChecker {
+	if !m.deps.Config.AuthLoginEnabled {
+		return nil
+	}
+	return m.service
+}
+
+// Collectors 로그인 잠금 메트릭 / Login lockout metrics
+func (m *Module) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{metrics.LoginLockoutsTotal}
+}
+
+// Jobs 만료 리프레시 토큰과 오래된 로그인 실패 정리 작업 / Expired refresh token and stale login failure cleanup jobs
+func (m *Module) Jobs() []scheduler.Job {
+	if !m.deps.Config.AuthLoginEnabled {
+		return nil
+	}
+	return []scheduler.Job{
+		{
+			Name:     "auth.purge-expired-refresh-tokens",
+			Interval: purgeInterval,
+			Run: func(ctx context.Context) error {
+				_, err := m.service.PurgeExpired(ctx)
+				return err
+			},
+		},
+		{
+			Name:     "auth.purge-login-failures",
+			Interval: purgeInterval,
+			Run:      m.throttle.Purge,
+		},
+	}
+}
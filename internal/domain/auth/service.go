@@ -0,0 +1,276 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/domain/user"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/jwt"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/password"
+)
+
+// Users 인증에 필요한 사용자 서비스 기능 / User service features needed for authentication
+type Users interface {
+	Authenticate(ctx context.Context, email, password string) (*user.User, error)
+	GetByID(ctx context.Context, id uint) (*user.User, error)
+}
+
+// Service 인증 서비스 인터페이스 / Authentication service interface
+type Service interface {
+	// Login 비밀번호 확인 후 새 세션의 토큰 쌍 발급, 잠긴 이메일이나 IP는 *LockedError
+	// Check the password and issue a token pair for a new session; a locked email or IP yields *LockedError
+	// 해시 슬롯이 없으면 password.ErrBusy / password.ErrBusy when no hashing slot is free
+	Login(ctx context.Context, req *LoginRequest, ip string) (*TokenResponse, error)
+	// Refresh 리프레시 토큰을 교체하고 새 액세스 토큰 발급, 재사용이 감지되면 세션 전체 폐기
+	// Rotate the refresh token and issue a new access token; reuse revokes the whole session
+	Refresh(ctx context.Context, refreshToken string) (*TokenResponse, error)
+	// Logout 리프레시 토큰의 세션 폐기 / Revoke the session of a refresh token
+	Logout(ctx context.Context, refreshToken string) error
+	// PurgeExpired 만료된 리프레시 토큰 삭제 / Delete expired refresh tokens
+	PurgeExpired(ctx context.Context) (int64, error)
+	// SessionRevoked 액세스 토큰의 sid 세션이 폐기되었는지 확인 / Report whether the sid session of an access token was revoked
+	SessionRevoked(ctx context.Context, sessionID string) (bool, error)
+}
+
+// Config 토큰 발급 설정 / Token issuing settings
+type Config struct {
+	// Secret 액세스 토큰 HS256 서명 키 / HS256 signing key for access tokens
+	Secret     []byte
+	Issuer     string
+	Audience   string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+	// Scopes 액세스 토큰에 부여하는 범위 / Scopes granted to access tokens
+	Scopes []string
+}
+
+// service 인증 서비스 구현체 / Authentication service implementation
+type service struct {
+	users    Users
+	store    Store
+	tx       db.TxManager
+	throttle Throttle
+	cfg      Config
+	now      func() time.Time
+}
+
+// NewService 새 인증 서비스 생성 / Create new authentication service
+func NewService(users Users, store Store, tx db.TxManager, throttle Throttle, cfg Config) Service {
+	return &service{users: users, store: store, tx: tx, throttle: throttle, cfg: cfg, now: time.Now}
+}
+
+// Login 로그인 / Log in
+func (s *service) Login(ctx context.Context, req *LoginRequest, ip string) (*TokenResponse, error) {
+	logger := zap.L().With(zap.String("method", "auth.service.Login"))
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 잠긴 동안에는 비밀번호를 확인하지 않음 / Passwords are not checked while locked
+	if err := s.throttle.Check(ctx, req.Email, ip); err != nil {
+		var lockedErr *LockedError
+		if !errors.As(err, &lockedErr) {
+			logger.Error("Failed to check login lockout", zap.Error(err))
+		}
+		return nil, err
+	}
+
+	u, err := s.users.Authenticate(ctx, req.Email, req.Password)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidCredentials) {
+			if err := s.throttle.Fail(ctx, req.Email, ip); err != nil {
+				logger.Error("Failed to record login failure", zap.Error(err))
+			}
+			return nil, ErrInvalidCredentials
+		}
+		// 해시 슬롯 부족은 자격 증명 실패로 세지 않음 / Running out of hashing slots does not count as a credential failure
+		if errors.Is(err, password.ErrBusy) {
+			return nil, err
+		}
+		logger.Error("Failed to authenticate user", zap.Error(err))
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+	if err := s.throttle.Succeed(ctx, req.Email); err != nil {
+		logger.Warn("Failed to reset login failures", zap.Uint("user_id", u.ID), zap.Error(err))
+	}
+
+	familyID, err := generateFamilyID()
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := s.issue(ctx, u.ID, familyID)
+	if err != nil {
+		logger.Error("Failed to issue tokens", zap.Uint("user_id", u.ID), zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("User logged in", zap.Uint("user_id", u.ID), zap.String("session_id", familyID))
+	return tokens, nil
+}
+
+// Refresh 토큰 갱신 / Refresh tokens
+func (s *service) Refresh(ctx context.Context, refreshToken string) (*TokenResponse, error) {
+	logger := zap.L().With(zap.String("method", "auth.service.Refresh"))
+
+	current, err := s.find(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if current.UsedAt != nil {
+		return nil, s.revokeReused(ctx, logger, current, now)
+	}
+	if !current.IsUsable(now) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	var tokens *TokenResponse
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		// 동시 요청 중 하나만 교체에 성공 / Only one of several concurrent requests wins the rotation
+		claimed, err := s.store.MarkUsed(ctx, current.ID, now)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return ErrRefreshTokenReused
+		}
+
+		u, err := s.users.GetByID(ctx, current.UserID)
+		if err != nil {
+			if errors.Is(err, user.ErrUserNotFound) {
+				return ErrInvalidRefreshToken
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if u.Status != user.StatusActive {
+			return ErrInvalidRefreshToken
+		}
+		// 비밀번호 변경 전에 발급된 토큰은 탈취되었을 수 있음 / A token issued before the password changed may be stolen
+		if u.PasswordChangedAt != nil && current.CreatedAt.Before(*u.PasswordChangedAt) {
+			return ErrInvalidRefreshToken
+		}
+
+		tokens, err = s.issue(ctx, u.ID, current.FamilyID)
+		return err
+	})
+	switch {
+	case errors.Is(err, ErrRefreshTokenReused):
+		return nil, s.revokeReused(ctx, logger, current, now)
+	case errors.Is(err, ErrInvalidRefreshToken):
+		// 삭제·비활성 사용자나 비밀번호가 바뀐 사용자의 세션 종료 / End the session of a deleted or inactive user, or one whose password changed
+		if err := s.store.RevokeFamily(ctx, current.FamilyID, now); err != nil {
+			logger.Error("Failed to revoke session", zap.String("session_id", current.FamilyID), zap.Error(err))
+		}
+		return nil, ErrInvalidRefreshToken
+	case err != nil:
+		logger.Error("Failed to rotate refresh token", zap.String("session_id", current.FamilyID), zap.Error(err))
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// Logout 로그아웃 / Log out
+func (s *service) Logout(ctx context.Context, refreshToken string) error {
+	logger := zap.L().With(zap.String("method", "auth.service.Logout"))
+
+	current, err := s.find(ctx, refreshToken)
+	if err != nil {
+		return err
+	}
+	if err := s.store.RevokeFamily(ctx, current.FamilyID, s.now()); err != nil {
+		logger.Error("Failed to revoke session", zap.String("session_id", current.FamilyID), zap.Error(err))
+		return err
+	}
+
+	logger.Info("User logged out", zap.Uint("user_id", current.UserID), zap.String("session_id", current.FamilyID))
+	return nil
+}
+
+// PurgeExpired 만료 토큰 정리 / Clean up expired tokens
+func (s *service) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.store.PurgeExpired(ctx, s.now())
+}
+
+// SessionRevoked 세션 폐기 여부 / Report whether a session was revoked
+func (s *service) SessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	return s.store.FamilyRevoked(ctx, sessionID)
+}
+
+// find 토큰 원문으로 저장된 토큰 조회 / Look up the stored token for a plaintext token
+func (s *service) find(ctx context.Context, refreshToken string) (*RefreshToken, error) {
+	if !strings.HasPrefix(refreshToken, RefreshTokenPrefix+"_") {
+		return nil, ErrInvalidRefreshToken
+	}
+	token, err := s.store.FindByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, ErrTokenNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	return token, nil
+}
+
+// revokeReused 이미 교체된 토큰이 다시 쓰이면 탈취로 보고 세션 전체 폐기
+// An already rotated token being presented again is treated as theft, so the whole session is revoked
+func (s *service) revokeReused(ctx context.Context, logger *zap.Logger, token *RefreshToken, now time.Time) error {
+	logger.Warn("Refresh token reuse detected, revoking session",
+		zap.Uint("user_id", token.UserID), zap.String("session_id", token.FamilyID))
+	if err := s.store.RevokeFamily(ctx, token.FamilyID, now); err != nil {
+		logger.Error("Failed to revoke session", zap.String("session_id", token.FamilyID), zap.Error(err))
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// issue 세션에 새 액세스 토큰과 리프레시 토큰 발급 / Issue a new access and refresh token for the session
+func (s *service) issue(ctx context.Context, userID uint, familyID string) (*TokenResponse, error) {
+	now := s.now()
+
+	claims := &jwt.Claims{
+		Issuer:    s.cfg.Issuer,
+		Subject:   strconv.FormatUint(uint64(userID), 10),
+		ExpiresAt: jwt.NewNumericDate(now.Add(s.cfg.AccessTTL)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		Scope:     strings.Join(s.cfg.Scopes, " "),
+		SessionID: familyID,
+	}
+	if s.cfg.Audience != "" {
+		claims.Audience = jwt.Audience{s.cfg.Audience}
+	}
+	accessToken, err := jwt.SignHS256(claims, s.cfg.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	stored := &RefreshToken{
+		FamilyID:  familyID,
+		UserID:    userID,
+		Hash:      hashToken(refreshToken),
+		ExpiresAt: now.Add(s.cfg.RefreshTTL),
+	}
+	if err := s.store.Create(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	return &TokenResponse{
+		AccessToken:      accessToken,
+		TokenType:        TokenType,
+		ExpiresIn:        int64(s.cfg.AccessTTL / time.Second),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: stored.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,272 @@
+package auth
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/audit"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/domain/user"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/jwt"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/password"
+)
+
+var (
+	testSecret = []byte("0123456789abcdef0123456789abcdef")
+	testHasher = password.NewHasher(password.Params{Memory: 1024, Iterations: 1, Parallelism: 1}, 0)
+	testConfig = Config{
+		Secret:     testSecret,
+		Issuer:     "spindle",
+		Audience:   "spindle-api",
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: 24 * time.Hour,
+		Scopes:     []string{"users:read", "users:write"},
+	}
+	testThrottleConfig = ThrottleConfig{
+		Window:             15 * time.Minute,
+		MaxAccountFailures: 3,
+		MaxIPFailures:      5,
+		LockoutBase:        time.Minute,
+		LockoutMax:         10 * time.Minute,
+		LockoutReset:       time.Hour,
+	}
+)
+
+const testIP = "203.0.113.7"
+
+// testEnv 실제 사용자 서비스와 연결된 인증 서비스 / Authentication service wired to the real user service
+type testEnv struct {
+	service  *service
+	throttle *throttle
+	users    user.Service
+	user     *user.User
+}
+
+func setupTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	database, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, database.AutoMigrate(&user.User{}, &audit.Event{}, &outbox.Message{}, &RefreshToken{}, &LoginFailure{}, &Lockout{}))
+
+	hash, err := testHasher.Hash("correct horse")
+	require.NoError(t, err)
+	u := &user.User{Name: "Login", Email: "login@example.com", Status: user.StatusActive, PasswordHash: hash}
+	require.NoError(t, user.NewRepository(database).Create(t.Context(), u))
+
+	tx := db.NewTxManager(database, db.TxConfig{})
+	users := user.NewService(user.NewRepository(database), tx, testHasher)
+	throttle := NewThrottle(NewLockoutStore(database), tx, testThrottleConfig).(*throttle)
+	return &testEnv{
+		service:  NewService(users, NewStore(database), tx, throttle, testConfig).(*service),
+		throttle: throttle,
+		users:    users,
+		user:     u,
+	}
+}
+
+func (e *testEnv) login(t *testing.T) *TokenResponse {
+	t.Helper()
+	tokens, err := e.service.Login(t.Context(), &LoginRequest{Email: "login@example.com", Password: "correct horse"}, testIP)
+	require.NoError(t, err)
+	return tokens
+}
+
+func TestService_Login(t *testing.T) {
+	env := setupTestEnv(t)
+
+	testCases := []struct {
+		name    string
+		request LoginRequest
+		wantErr error
+	}{
+		{name: "correct", request: LoginRequest{Email: " login@example.com ", Password: "correct horse"}},
+		{name: "wrong password", request: LoginRequest{Email: "login@example.com", Password: "wrong horse"}, wantErr: ErrInvalidCredentials},
+		{name: "unknown email", request: LoginRequest{Email: "nobody@example.com", Password: "correct horse"}, wantErr: ErrInvalidCredentials},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokens, err := env.service.Login(t.Context(), &tc.request, testIP)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, TokenType, tokens.TokenType)
+			assert.Equal(t, int64(900), tokens.ExpiresIn)
+			assert.Regexp(t, `^spr_[A-Za-z0-9_-]{43}$`, tokens.RefreshToken)
+
+			claims, err := jwt.NewVerifier(jwt.Config{Issuer: "spindle", Audience: "spindle-api", Secret: testSecret}).
+				Verify(t.Context(), tokens.AccessToken)
+			require.NoError(t, err)
+			assert.Equal(t, strconv.FormatUint(uint64(env.user.ID), 10), claims.Subject)
+			assert.NotEmpty(t, claims.SessionID)
+			assert.Equal(t, []string{"users:read", "users:write"}, claims.Scopes())
+		})
+	}
+
+	_, err := env.service.Login(t.Context(), &LoginRequest{Email: "login@example.com"}, testIP)
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestService_RefreshRotates(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := t.Context()
+	first := env.login(t)
+
+	second, err := env.service.Refresh(ctx, first.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+	assert.Equal(t, sessionID(t, first), sessionID(t, second), "rotation keeps the session")
+
+	third, err := env.service.Refresh(ctx, second.RefreshToken)
+	require.NoError(t, err)
+
+	// 교체된 토큰 재사용은 세션 전체를 폐기 / Reusing a rotated token revokes the whole session
+	_, err = env.service.Refresh(ctx, first.RefreshToken)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	_, err = env.service.Refresh(ctx, third.RefreshToken)
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken, "the latest token of the family is revoked too")
+
+	// 다른 세션은 영향받지 않음 / Other sessions are unaffected
+	other := env.login(t)
+	_, err = env.service.Refresh(ctx, other.RefreshToken)
+	assert.NoError(t, err)
+}
+
+func TestService_RefreshRejects(t *testing.T) {
+	testCases := []struct {
+		name    string
+		prepare func(t *testing.T, env *testEnv, tokens *TokenResponse) string
+		wantErr error
+	}{
+		{
+			name:    "unknown token",
+			prepare: func(*testing.T, *testEnv, *TokenResponse) string { return "spr_unknown" },
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name:    "access token",
+			prepare: func(_ *testing.T, _ *testEnv, tokens *TokenResponse) string { return tokens.AccessToken },
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "expired",
+			prepare: func(_ *testing.T, env *testEnv, tokens *TokenResponse) string {
+				env.service.now = func() time.Time { return time.Now().Add(testConfig.RefreshTTL + time.Minute) }
+				return tokens.RefreshToken
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "logged out",
+			prepare: func(t *testing.T, env *testEnv, tokens *TokenResponse) string {
+				require.NoError(t, env.service.Logout(t.Context(), tokens.RefreshToken))
+				return tokens.RefreshToken
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "deactivated user",
+			prepare: func(t *testing.T, env *testEnv, tokens *TokenResponse) string {
+				_, err := env.users.Transition(t.Context(), env.user.ID, user.StatusInactive, &user.TransitionRequest{Reason: "left"}, 0)
+				require.NoError(t, err)
+				return tokens.RefreshToken
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "password changed",
+			prepare: func(t *testing.T, env *testEnv, tokens *TokenResponse) string {
+				_, err := env.users.SetPassword(t.Context(), env.user.ID, &user.SetPasswordRequest{Password: "battery staple"}, 0)
+				require.NoError(t, err)
+				return tokens.RefreshToken
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := setupTestEnv(t)
+			token := tc.prepare(t, env, env.login(t))
+
+			_, err := env.service.Refresh(t.Context(), token)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestService_PasswordChangeEndsSessions(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := t.Context()
+	stolen := env.login(t)
+	rotated, err := env.service.Refresh(ctx, stolen.RefreshToken)
+	require.NoError(t, err)
+
+	_, err = env.users.SetPassword(ctx, env.user.ID, &user.SetPasswordRequest{Password: "battery staple"}, 0)
+	require.NoError(t, err)
+
+	_, err = env.service.Refresh(ctx, rotated.RefreshToken)
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	// 새 비밀번호로 시작한 세션은 계속 갱신됨 / A session started with the new password keeps refreshing
+	fresh, err := env.service.Login(ctx, &LoginRequest{Email: "login@example.com", Password: "battery staple"}, testIP)
+	require.NoError(t, err)
+	_, err = env.service.Refresh(ctx, fresh.RefreshToken)
+	assert.NoError(t, err)
+}
+
+func TestService_Logout(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := t.Context()
+	tokens := env.login(t)
+	rotated, err := env.service.Refresh(ctx, tokens.RefreshToken)
+	require.NoError(t, err)
+
+	revoked, err := env.service.SessionRevoked(ctx, sessionID(t, rotated))
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// 세션의 어느 토큰으로도 로그아웃 가능 / Any token of the session can log out
+	require.NoError(t, env.service.Logout(ctx, tokens.RefreshToken))
+	_, err = env.service.Refresh(ctx, rotated.RefreshToken)
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	// 이미 발급된 액세스 토큰도 세션 확인에서 거부 / Access tokens already issued fail the session check too
+	revoked, err = env.service.SessionRevoked(ctx, sessionID(t, rotated))
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	assert.ErrorIs(t, env.service.Logout(ctx, "spr_unknown"), ErrInvalidRefreshToken)
+}
+
+func TestService_PurgeExpired(t *testing.T) {
+	env := setupTestEnv(t)
+	env.login(t)
+	env.service.now = func() time.Time { return time.Now().Add(testConfig.RefreshTTL + time.Minute) }
+
+	purged, err := env.service.PurgeExpired(t.Context())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+}
+
+func sessionID(t *testing.T, tokens *TokenResponse) string {
+	t.Helper()
+	claims, err := jwt.NewVerifier(jwt.Config{Secret: testSecret}).Verify(t.Context(), tokens.AccessToken)
+	require.NoError(t, err)
+	return claims.SessionID
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+)
+
+// Store 리프레시 토큰 저장소 인터페이스 / Refresh token store interface
+type Store interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	// FindByHash 토큰 해시로 조회 / Find a token by its hash
+	FindByHash(ctx context.Context, hash string) (*RefreshToken, error)
+	// MarkUsed 미사용·미폐기 토큰만 사용 처리, 다른 요청이 먼저 처리했으면 false
+	// Mark an unused, unrevoked token as used; false when another request got there first
+	MarkUsed(ctx context.Context, id uint, at time.Time) (bool, error)
+	// RevokeFamily 세션의 모든 토큰 폐기 / Revoke every token of a session
+	RevokeFamily(ctx context.Context, familyID string, at time.Time) error
+	// FamilyRevoked 세션이 폐기되었는지 확인 / Report whether a session was revoked
+	FamilyRevoked(ctx context.Context, familyID string) (bool, error)
+	// PurgeExpired before 이전에 만료된 토큰 삭제 / Delete tokens that expired before the given time
+	PurgeExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// store GORM 기반 저장소 구현체 / GORM-backed store implementation
+type store struct {
+	db *gorm.DB
+}
+
+// NewStore 새 리프레시 토큰 저장소 생성 / Create new refresh token store
+func NewStore(db *gorm.DB) Store {
+	return &store{db: db}
+}
+
+// Create 토큰 생성 / Create token
+func (s *store) Create(ctx context.Context, token *RefreshToken) error {
+	if err := db.Conn(ctx, s.db).Create(token).Error; err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+// FindByHash 해시로 토큰 조회 / Find token by hash
+func (s *store) FindByHash(ctx context.Context, hash string) (*RefreshToken, error) {
+	var token RefreshToken
+	if err := db.Conn(ctx, s.db).Where("hash = ?", hash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTokenNotFound
+		}
+		return nil, fmt.Errorf("failed to find refresh token: %w", err)
+	}
+	return &token, nil
+}
+
+// MarkUsed 토큰 사용 처리 / Mark token used
+func (s *store) MarkUsed(ctx context.Context, id uint, at time.Time) (bool, error) {
+	result := db.Conn(ctx, s.db).Model(&RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		UpdateColumn("used_at", at)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to mark refresh token used: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeFamily 세션 폐기 / Revoke session
+func (s *store) RevokeFamily(ctx context.Context, familyID string, at time.Time) error {
+	err := db.Conn(ctx, s.db).Model(&RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		UpdateColumn("revoked_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	return nil
+}
+
+// FamilyRevoked 세션 폐기 여부 / Report whether a session was revoked
+func (s *store) FamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	var count int64
+	err := db.Conn(ctx, s.db).Model(&RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NOT NULL", familyID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check refresh token family: %w", err)
+	}
+	return count > 0, nil
+}
+
+// PurgeExpired 만료 토큰 삭제 / Delete expired tokens
+func (s *store) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := db.Conn(ctx, s.db).Where("expires_at < ?", before).Delete(&RefreshToken{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge expired refresh tokens: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/metrics"
+)
+
+// Throttle 로그인 실패 추적과 잠금 인터페이스 / Login failure tracking and lockout interface
+type Throttle interface {
+	// Check 계정이나 IP가 잠겨 있으면 *LockedError / *LockedError when the account or the IP is locked
+	Check(ctx context.Context, email, ip string) error
+	// Fail 실패를 기록하고 윈도우 안의 실패가 한도에 닿으면 잠금
+	// Record a failure and lock once the failures in the window reach the threshold
+	Fail(ctx context.Context, email, ip string) error
+	// Succeed 계정의 실패 기록과 잠금 단계 초기화, IP 기록은 유지
+	// Reset the account's failures and lockout level; the IP's record is kept
+	Succeed(ctx context.Context, email string) error
+	// Unlock 관리자 잠금 해제, 실패 기록과 잠금 단계도 초기화 / Admin unlock; also resets failures and the lockout level
+	Unlock(ctx context.Context, req *UnlockRequest) error
+	// List 잠금 중인 항목 조회 / List lockouts in force
+	List(ctx context.Context, query *ListLockoutsQuery) ([]*Lockout, int64, error)
+	// Purge 윈도우를 벗어난 실패와 단계가 초기화된 잠금 삭제 / Delete failures outside the window and lockouts whose level has reset
+	Purge(ctx context.Context) error
+}
+
+// ThrottleConfig 로그인 제한 설정 / Login throttling settings
+type ThrottleConfig struct {
+	// Window 실패를 세는 슬라이딩 윈도우 / Sliding window failures are counted in
+	Window             time.Duration
+	MaxAccountFailures int
+	MaxIPFailures      int
+	// LockoutBase 첫 잠금 기간, 이후 잠금마다 두 배 / First lockout duration, doubled on each further lockout
+	LockoutBase time.Duration
+	LockoutMax  time.Duration
+	// LockoutReset 잠금이 풀린 뒤 이 시간이 지나면 단계를 처음부터 / The level starts over once a lockout has been over for this long
+	LockoutReset time.Duration
+}
+
+// throttleTarget 실패를 세는 대상 하나 / One subject failures are counted against
+type throttleTarget struct {
+	kind        LockoutKind
+	identifier  string
+	maxFailures int
+}
+
+// throttle 로그인 제한 구현체 / Login throttling implementation
+type throttle struct {
+	store LockoutStore
+	tx    db.TxManager
+	cfg   ThrottleConfig
+	now   func() time.Time
+}
+
+// NewThrottle 새 로그인 제한기 생성 / Create new login throttle
+func NewThrottle(store LockoutStore, tx db.TxManager, cfg ThrottleConfig) Throttle {
+	return &throttle{store: store, tx: tx, cfg: cfg, now: time.Now}
+}
+
+// Check 잠금 확인 / Check lockouts
+func (t *throttle) Check(ctx context.Context, email, ip string) error {
+	now := t.now()
+
+	var until time.Time
+	for _, target := range t.targets(email, ip) {
+		lockout, err := t.store.GetLockout(ctx, target.kind, target.identifier)
+		if errors.Is(err, ErrLockoutNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if lockout.IsActive(now) && lockout.LockedUntil.After(until) {
+			until = lockout.LockedUntil
+		}
+	}
+	if !until.IsZero() {
+		return &LockedError{Until: until}
+	}
+	return nil
+}
+
+// Fail 실패 기록 / Record failure
+func (t *throttle) Fail(ctx context.Context, email, ip string) error {
+	logger := zap.L().With(zap.String("method", "auth.throttle.Fail"))
+	now := t.now()
+
+	for _, target := range t.targets(email, ip) {
+		lockout, err := t.fail(ctx, target, now)
+		if err != nil {
+			return err
+		}
+		if lockout != nil {
+			metrics.LoginLockoutsTotal.WithLabelValues(string(lockout.Kind)).Inc()
+			logger.Warn("Login locked after repeated failures",
+				zap.Uint("lockout_id", lockout.ID),
+				zap.String("kind", string(lockout.Kind)),
+				zap.Int("level", lockout.Level),
+				zap.Time("locked_until", lockout.LockedUntil))
+		}
+	}
+	return nil
+}
+
+// fail 대상 하나의 실패 기록, 새로 잠갔으면 잠금 반환 / Record a failure for one target; returns the lockout when this call locked it
+func (t *throttle) fail(ctx context.Context, target throttleTarget, now time.Time) (*Lockout, error) {
+	var locked *Lockout
+	err := t.tx.Do(ctx, func(ctx context.Context) error {
+		locked = nil
+		if err := t.store.AddFailure(ctx, target.kind, target.identifier, now); err != nil {
+			return err
+		}
+		count, err := t.store.CountFailures(ctx, target.kind, target.identifier, now.Add(-t.cfg.Window))
+		if err != nil {
+			return err
+		}
+		if count < int64(target.maxFailures) {
+			return nil
+		}
+
+		lockout, err := t.store.GetLockout(ctx, target.kind, target.identifier)
+		switch {
+		case errors.Is(err, ErrLockoutNotFound):
+			lockout = &Lockout{Kind: target.kind, Identifier: target.identifier}
+		case err != nil:
+			return err
+		case lockout.IsActive(now):
+			// 다른 요청이 먼저 잠금 / Another request locked it first
+			return nil
+		}
+
+		if now.Sub(lockout.LockedUntil) > t.cfg.LockoutReset {
+			lockout.Level = 0
+		}
+		lockout.Level++
+		lockout.LockedUntil = now.Add(t.lockoutDuration(lockout.Level))
+		if err := t.store.SaveLockout(ctx, lockout); err != nil {
+			return err
+		}
+		// 잠금이 풀리면 처음부터 다시 셈 / Counting starts over once the lockout ends
+		if err := t.store.ClearFailures(ctx, target.kind, target.identifier); err != nil {
+			return err
+		}
+		locked = lockout
+		return nil
+	})
+	return locked, err
+}
+
+// Succeed 성공 기록 / Record success
+func (t *throttle) Succeed(ctx context.Context, email string) error {
+	return t.reset(ctx, LockoutAccount, normalizeEmail(email))
+}
+
+// Unlock 잠금 해제 / Unlock
+func (t *throttle) Unlock(ctx context.Context, req *UnlockRequest) error {
+	logger := zap.L().With(zap.String("method", "auth.throttle.Unlock"))
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	if req.Email != "" {
+		if err := t.reset(ctx, LockoutAccount, req.Email); err != nil {
+			return err
+		}
+	}
+	if req.IP != "" {
+		if err := t.reset(ctx, LockoutIP, req.IP); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Login lockout cleared", zap.Bool("account", req.Email != ""), zap.Bool("ip", req.IP != ""))
+	return nil
+}
+
+// List 잠금 목록 조회 / List lockouts
+func (t *throttle) List(ctx context.Context, query *ListLockoutsQuery) ([]*Lockout, int64, error) {
+	query.Validate()
+	return t.store.ListActive(ctx, t.now(), query.Offset, query.Limit)
+}
+
+// Purge 오래된 기록 정리 / Clean up old records
+func (t *throttle) Purge(ctx context.Context) error {
+	now := t.now()
+	return t.store.Purge(ctx, now.Add(-t.cfg.Window), now.Add(-t.cfg.LockoutReset))
+}
+
+// reset 실패 기록과 잠금 삭제 / Delete failures and the lockout
+func (t *throttle) reset(ctx context.Context, kind LockoutKind, identifier string) error {
+	return t.tx.Do(ctx, func(ctx context.Context) error {
+		if err := t.store.ClearFailures(ctx, kind, identifier); err != nil {
+			return err
+		}
+		return t.store.DeleteLockout(ctx, kind, identifier)
+	})
+}
+
+// targets 이메일과 IP 대상, IP를 모르면 계정만 / Email and IP targets; only the account when the IP is unknown
+func (t *throttle) targets(email, ip string) []throttleTarget {
+	targets := []throttleTarget{{kind: LockoutAccount, identifier: normalizeEmail(email), maxFailures: t.cfg.MaxAccountFailures}}
+	if ip != "" {
+		targets = append(targets, throttleTarget{kind: LockoutIP, identifier: ip, maxFailures: t.cfg.MaxIPFailures})
+	}
+	return targets
+}
+
+// lockoutDuration 단계별 잠금 기간, LockoutBase에서 두 배씩 LockoutMax까지 / Lockout duration for a level, doubling from LockoutBase up to LockoutMax
+func (t *throttle) lockoutDuration(level int) time.Duration {
+	d := t.cfg.LockoutBase
+	for i := 1; i < level && d < t.cfg.LockoutMax; i++ {
+		d *= 2
+	}
+	return min(d, t.cfg.LockoutMax)
+}
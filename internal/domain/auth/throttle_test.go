@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/domain/user"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/metrics"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/password"
+)
+
+// useClock 서비스와 제한기가 같은 가짜 시계를 쓰도록 설정 / Make the service and the throttle share a fake clock
+func (e *testEnv) useClock(now *time.Time) {
+	clock := func() time.Time { return *now }
+	e.service.now = clock
+	e.throttle.now = clock
+}
+
+func (e *testEnv) attempt(t *testing.T, email, password, ip string) error {
+	t.Helper()
+	_, err := e.service.Login(t.Context(), &LoginRequest{Email: email, Password: password}, ip)
+	return err
+}
+
+func TestService_LoginLocksAccount(t *testing.T) {
+	testCases := []struct {
+		name  string
+		email string
+	}{
+		{name: "existing account", email: "login@example.com"},
+		{name: "unknown email", email: "nobody@example.com"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := setupTestEnv(t)
+			now := time.Now()
+			env.useClock(&now)
+			before := testutil.ToFloat64(metrics.LoginLockoutsTotal.WithLabelValues(string(LockoutAccount)))
+
+			// 존재 여부와 관계없이 같은 오류와 같은 잠금 / The same errors and the same lockout whether or not the account exists
+			for range testThrottleConfig.MaxAccountFailures {
+				assert.ErrorIs(t, env.attempt(t, tc.email, "wrong horse", testIP), ErrInvalidCredentials)
+			}
+			var lockedErr *LockedError
+			require.ErrorAs(t, env.attempt(t, tc.email, "correct horse", testIP), &lockedErr)
+			assert.WithinDuration(t, now.Add(testThrottleConfig.LockoutBase), lockedErr.Until, time.Millisecond)
+			assert.Equal(t, before+1, testutil.ToFloat64(metrics.LoginLockoutsTotal.WithLabelValues(string(LockoutAccount))))
+
+			// 이메일 대소문자를 바꿔도 같은 계정 / Changing the email's case hits the same account
+			require.ErrorAs(t, env.attempt(t, " "+strings.ToUpper(tc.email), "correct horse", "198.51.100.1"), &lockedErr)
+
+			now = now.Add(testThrottleConfig.LockoutBase)
+			if tc.email == "login@example.com" {
+				assert.NoError(t, env.attempt(t, tc.email, "correct horse", testIP))
+			} else {
+				assert.ErrorIs(t, env.attempt(t, tc.email, "correct horse", testIP), ErrInvalidCredentials)
+			}
+		})
+	}
+}
+
+func TestService_LoginLocksIP(t *testing.T) {
+	env := setupTestEnv(t)
+	now := time.Now()
+	env.useClock(&now)
+
+	// 서로 다른 이메일로 같은 IP에서 시도 / Attempts for different emails from one IP
+	for i := range testThrottleConfig.MaxIPFailures {
+		assert.ErrorIs(t, env.attempt(t, fmt.Sprintf("guess%d@example.com", i), "wrong horse", testIP), ErrInvalidCredentials)
+	}
+
+	var lockedErr *LockedError
+	assert.ErrorAs(t, env.attempt(t, "login@example.com", "correct horse", testIP), &lockedErr)
+	assert.NoError(t, env.attempt(t, "login@example.com", "correct horse", "198.51.100.1"), "other IPs are unaffected")
+}
+
+// busyUsers 해시 슬롯이 항상 부족한 사용자 서비스 / User service whose hasher never has a free slot
+type busyUsers struct {
+	Users
+}
+
+func (busyUsers) Authenticate(context.Context, string, string) (*user.User, error) {
+	return nil, fmt.Errorf("failed to verify password: %w", password.ErrBusy)
+}
+
+func TestService_LoginBusyIsNotAFailure(t *testing.T) {
+	env := setupTestEnv(t)
+	users := env.service.users
+	env.service.users = busyUsers{Users: users}
+
+	for range testThrottleConfig.MaxAccountFailures + 1 {
+		assert.ErrorIs(t, env.attempt(t, "login@example.com", "wrong horse", testIP), password.ErrBusy)
+	}
+
+	// 바쁨 응답은 잠금으로 이어지지 않음 / Busy responses never lead to a lockout
+	env.service.users = users
+	assert.NoError(t, env.attempt(t, "login@example.com", "correct horse", testIP))
+}
+
+func TestThrottle_Escalates(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := t.Context()
+	now := time.Now()
+	env.useClock(&now)
+	const email = "login@example.com"
+
+	lock := func() time.Duration {
+		t.Helper()
+		for range testThrottleConfig.MaxAccountFailures {
+			require.NoError(t, env.throttle.Fail(ctx, email, ""))
+		}
+		var lockedErr *LockedError
+		require.ErrorAs(t, env.throttle.Check(ctx, email, ""), &lockedErr)
+		return lockedErr.Until.Sub(now)
+	}
+
+	assert.Equal(t, time.Minute, lock())
+	now = now.Add(time.Minute)
+	assert.Equal(t, 2*time.Minute, lock())
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, 4*time.Minute, lock())
+	now = now.Add(4 * time.Minute)
+	assert.Equal(t, 8*time.Minute, lock())
+	now = now.Add(8 * time.Minute)
+	assert.Equal(t, 10*time.Minute, lock(), "capped at LockoutMax")
+
+	// 잠금 해제 후 오래 지나면 첫 단계부터 / The level starts over long after the lockout ended
+	now = now.Add(10*time.Minute + testThrottleConfig.LockoutReset + time.Second)
+	assert.Equal(t, time.Minute, lock())
+}
+
+func TestThrottle_SlidingWindow(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := t.Context()
+	now := time.Now()
+	env.useClock(&now)
+	const email = "login@example.com"
+
+	for range testThrottleConfig.MaxAccountFailures - 1 {
+		require.NoError(t, env.throttle.Fail(ctx, email, ""))
+	}
+	// 윈도우를 벗어난 실패는 세지 않음 / Failures outside the window no longer count
+	now = now.Add(testThrottleConfig.Window)
+	require.NoError(t, env.throttle.Fail(ctx, email, ""))
+	assert.NoError(t, env.throttle.Check(ctx, email, ""))
+
+	// 성공하면 계정 실패 기록 초기화 / A success resets the account's failures
+	for range testThrottleConfig.MaxAccountFailures - 1 {
+		require.NoError(t, env.throttle.Fail(ctx, email, ""))
+	}
+	require.NoError(t, env.throttle.Succeed(ctx, email))
+	require.NoError(t, env.throttle.Fail(ctx, email, ""))
+	assert.NoError(t, env.throttle.Check(ctx, email, ""))
+}
+
+func TestThrottle_Unlock(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := t.Context()
+	for range testThrottleConfig.MaxIPFailures {
+		require.NoError(t, env.throttle.Fail(ctx, "login@example.com", testIP))
+	}
+
+	lockouts, total, err := env.throttle.List(ctx, &ListLockoutsQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, lockouts, 2)
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, env.throttle.Unlock(ctx, &UnlockRequest{}), &validationErr)
+
+	require.NoError(t, env.throttle.Unlock(ctx, &UnlockRequest{Email: "Login@Example.com"}))
+	var lockedErr *LockedError
+	assert.ErrorAs(t, env.throttle.Check(ctx, "login@example.com", testIP), &lockedErr, "the IP is still locked")
+	assert.NoError(t, env.throttle.Check(ctx, "login@example.com", "198.51.100.1"))
+
+	require.NoError(t, env.throttle.Unlock(ctx, &UnlockRequest{IP: testIP}))
+	assert.NoError(t, env.throttle.Check(ctx, "login@example.com", testIP))
+	_, total, err = env.throttle.List(ctx, &ListLockoutsQuery{})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
@@ -0,0 +1,74 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/audit"
+)
+
+// AuditEntityType 사용자 감사 이벤트의 엔터티 종류 / Entity type of user audit events
+const AuditEntityType = "user"
+
+const (
+	// AuditActionCreate records a user creation.
+	AuditActionCreate = "create"
+	// AuditActionUpdate records a field update through PUT, PATCH, batch or import.
+	AuditActionUpdate = "update"
+	// AuditActionStatusChange records a status transition.
+	AuditActionStatusChange = "status_change"
+	// AuditActionDelete records a soft delete.
+	AuditActionDelete = "delete"
+	// AuditActionHardDelete records a permanent delete.
+	AuditActionHardDelete = "hard_delete"
+	// AuditActionRestore records a restore of a soft-deleted user.
+	AuditActionRestore = "restore"
+	// AuditActionPasswordChange records a password set or change; the hash never appears in the changes.
+	AuditActionPasswordChange = "password_change"
+)
+
+// auditIgnoredFields 변경 내역에서 제외하는 관리 필드 / Bookkeeping fields left out of audit changes
+var auditIgnoredFields = []string{"id", "version", "created_at", "updated_at", "deleted_at"}
+
+// HistoryQuery 변경 이력 조회 쿼리 / Change history query
+type HistoryQuery struct {
+	Offset int `query:"offset"`
+	Limit  int `query:"limit"`
+}
+
+// Validate 쿼리 파라미터 검증 및 기본값 설정 / Validate query parameters and set defaults
+func (q *HistoryQuery) Validate() {
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 20
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+}
+
+// recordEvent 변경 내역을 감사 이벤트와 아웃박스 메시지로 기록 / Record the change as an audit event and outbox messages
+// before가 nil이면 생성, after가 nil이면 삭제 / A nil before is a create, a nil after is a delete
+func (s *service) recordEvent(ctx context.Context, action string, id uint, before, after *User) error {
+	changes, err := audit.Diff(before, after, auditIgnoredFields...)
+	if err != nil {
+		return fmt.Errorf("failed to diff user for audit: %w", err)
+	}
+
+	if err := s.repo.Audit(ctx).Record(&audit.Event{
+		EntityType: AuditEntityType,
+		EntityID:   strconv.FormatUint(uint64(id), 10),
+		Action:     action,
+		Actor:      actor(ctx),
+		RequestID:  audit.MetaFromContext(ctx).RequestID,
+		Changes:    changes,
+	}); err != nil {
+		return err
+	}
+
+	messages, err := s.domainEvents(ctx, action, id, before, after, changes)
+	if err != nil {
+		return err
+	}
+	return s.repo.Outbox(ctx).Add(messages...)
+}
@@ -9,6 +9,56 @@ var (
 	// ErrUserNotFound is returned when a user lookup cannot find a matching row.
 	ErrUserNotFound = errors.New("user not found")
 
+	// ErrUserNotDeleted is returned when restoring a user that is not soft-deleted.
+	ErrUserNotDeleted = errors.New("user is not deleted")
+
 	// ErrInvalidStatus is returned when a user status is outside the supported enum values.
 	ErrInvalidStatus = errors.New("invalid user status")
+
+	// ErrIllegalTransition is returned when the status transition table does not allow a change.
+	ErrIllegalTransition = errors.New("illegal status transition")
+
+	// ErrStatusChangeNotAllowed is returned when an update, batch update, or import tries to change the status instead of using a transition endpoint.
+	ErrStatusChangeNotAllowed = errors.New("status can only change through a transition")
+
+	// ErrInitialStatus is returned when a create, batch create, or import asks for a new user to start in a status other than active.
+	ErrInitialStatus = errors.New("new users must start active")
+
+	// ErrPreconditionFailed is returned when an If-Match version does not match the stored version.
+	ErrPreconditionFailed = errors.New("user version precondition failed")
+
+	// ErrVersionConflict is returned when a conditional write finds the row was changed concurrently.
+	ErrVersionConflict = errors.New("user was modified concurrently")
+
+	// ErrInvalidBatch is returned when a batch request has an unknown mode or an invalid item count.
+	ErrInvalidBatch = errors.New("invalid batch request")
+
+	// ErrBatchAborted is returned for items rolled back or skipped after an atomic batch failed.
+	ErrBatchAborted = errors.New("batch aborted")
+
+	// ErrReadOnlyField is returned when a patch modifies a field clients cannot change.
+	ErrReadOnlyField = errors.New("field is read-only")
+
+	// ErrUnknownField is returned when a patch adds a member users do not have.
+	ErrUnknownField = errors.New("unknown field")
+
+	// ErrInvalidCredentials is returned for an unknown email, a wrong password, a user without a password, or a user that is not active.
+	ErrInvalidCredentials = errors.New("invalid credentials")
 )
+
+// ValidationError 요청 필드 검증 오류 / Request field validation error
+// Message는 클라이언트에 그대로 노출됨 / Message is returned to clients as-is
+type ValidationError struct {
+	Message string
+	Err     error
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Unwrap exposes the underlying sentinel error, if any.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,116 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/audit"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+)
+
+// 아웃박스로 발행되는 사용자 도메인 이벤트 종류 / User domain event types published through the outbox
+const (
+	// EventUserCreated is published when a user is created.
+	EventUserCreated = "UserCreated"
+	// EventUserUpdated is published when user fields change, including a restore.
+	EventUserUpdated = "UserUpdated"
+	// EventUserStatusChanged is published when the status moves through a transition or the suspension sweep.
+	EventUserStatusChanged = "UserStatusChanged"
+	// EventUserDeleted is published on soft and hard delete.
+	EventUserDeleted = "UserDeleted"
+)
+
+// EventTypes 발행되는 모든 사용자 이벤트 종류 / Every published user event type
+func EventTypes() []string {
+	return []string{EventUserCreated, EventUserUpdated, EventUserStatusChanged, EventUserDeleted}
+}
+
+// UserCreated 사용자 생성 이벤트 / User created event
+type UserCreated struct {
+	User *User `json:"user"`
+}
+
+// UserUpdated 사용자 변경 이벤트 / User updated event
+type UserUpdated struct {
+	User *User `json:"user"`
+	// Changed 변경된 필드의 JSON 이름 / JSON names of the changed fields
+	Changed []string `json:"changed"`
+}
+
+// UserStatusChanged 사용자 상태 전이 이벤트 / User status transition event
+type UserStatusChanged struct {
+	UserID         uint       `json:"user_id"`
+	From           Status     `json:"from"`
+	To             Status     `json:"to"`
+	Reason         string     `json:"reason,omitempty"`
+	Actor          string     `json:"actor"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+}
+
+// UserDeleted 사용자 삭제 이벤트 / User deleted event
+type UserDeleted struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+	// Hard 영구 삭제 여부 / Whether the user was permanently deleted
+	Hard bool `json:"hard"`
+}
+
+// domainEvent 발행 전 이벤트 / Event before it becomes an outbox message
+type domainEvent struct {
+	kind    string
+	payload interface{}
+}
+
+// domainEvents 감사 동작을 아웃박스 메시지로 변환 / Turn an audited action into outbox messages
+// 상태가 바뀌면 동작과 관계없이 UserStatusChanged도 발행 / Any action that moves the status also publishes UserStatusChanged
+func (s *service) domainEvents(ctx context.Context, action string, id uint, before, after *User, changes audit.Changes) ([]*outbox.Message, error) {
+	var events []domainEvent
+	add := func(kind string, payload interface{}) {
+		events = append(events, domainEvent{kind: kind, payload: payload})
+	}
+
+	switch action {
+	case AuditActionCreate:
+		add(EventUserCreated, UserCreated{User: after})
+	case AuditActionUpdate, AuditActionRestore:
+		changed := make([]string, 0, len(changes)+1)
+		for field := range changes {
+			changed = append(changed, field)
+		}
+		if action == AuditActionRestore {
+			changed = append(changed, "deleted_at")
+		}
+		slices.Sort(changed)
+		add(EventUserUpdated, UserUpdated{User: after, Changed: changed})
+	case AuditActionDelete, AuditActionHardDelete:
+		add(EventUserDeleted, UserDeleted{UserID: id, Email: before.Email, Hard: action == AuditActionHardDelete})
+	}
+
+	if before != nil && after != nil && before.Status != after.Status {
+		changedBy := after.StatusChangedBy
+		if changedBy == "" {
+			changedBy = actor(ctx)
+		}
+		add(EventUserStatusChanged, UserStatusChanged{
+			UserID:         id,
+			From:           before.Status,
+			To:             after.Status,
+			Reason:         after.StatusReason,
+			Actor:          changedBy,
+			SuspendedUntil: after.SuspendedUntil,
+		})
+	}
+
+	messages := make([]*outbox.Message, 0, len(events))
+	for _, event := range events {
+		message, err := outbox.NewMessage(AuditEntityType, strconv.FormatUint(uint64(id), 10), event.kind, event.payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build user event: %w", err)
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
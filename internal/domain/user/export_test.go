@@ -0,0 +1,172 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+)
+
+func TestExportOptions_Resolve(t *testing.T) {
+	testCases := []struct {
+		name            string
+		options         ExportOptions
+		expectedColumns []string
+		expectedHeader  bool
+		expectedFormat  ExportFormat
+		expectedError   bool
+	}{
+		{
+			name:            "defaults",
+			expectedColumns: ExportColumns,
+			expectedHeader:  true,
+			expectedFormat:  ExportFormatCSV,
+		},
+		{
+			name:            "selected columns without header",
+			options:         ExportOptions{Format: ExportFormatNDJSON, Columns: "email, id,email", Header: "false"},
+			expectedColumns: []string{"email", "id"},
+			expectedHeader:  false,
+			expectedFormat:  ExportFormatNDJSON,
+		},
+		{name: "unknown format", options: ExportOptions{Format: "xml"}, expectedError: true},
+		{name: "unknown column", options: ExportOptions{Columns: "id,password"}, expectedError: true},
+		{name: "invalid header", options: ExportOptions{Header: "maybe"}, expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			columns, header, err := tc.options.Resolve()
+			if tc.expectedError {
+				var validationErr *ValidationError
+				assert.ErrorAs(t, err, &validationErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedColumns, columns)
+			assert.Equal(t, tc.expectedHeader, header)
+			assert.Equal(t, tc.expectedFormat, tc.options.Format)
+		})
+	}
+}
+
+func TestExportWriter(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	users := []*User{
+		{ID: 1, Name: "Kim, Minsu", Email: "kim@example.com", Status: StatusActive, CreatedAt: createdAt},
+		{ID: 2, Name: "Lee \"Jay\"", Email: "lee@example.com", Status: StatusSuspended, CreatedAt: createdAt},
+	}
+	columns := []string{"id", "name", "status", "created_at"}
+
+	testCases := []struct {
+		name     string
+		format   ExportFormat
+		header   bool
+		batches  [][]*User
+		expected string
+	}{
+		{
+			name:    "csv with header across batches",
+			format:  ExportFormatCSV,
+			header:  true,
+			batches: [][]*User{users[:1], users[1:]},
+			expected: "id,name,status,created_at\n" +
+				"1,\"Kim, Minsu\",active,2024-01-02T03:04:05Z\n" +
+				"2,\"Lee \"\"Jay\"\"\",suspended,2024-01-02T03:04:05Z\n",
+		},
+		{
+			name:     "csv header only for empty result",
+			format:   ExportFormatCSV,
+			header:   true,
+			expected: "id,name,status,created_at\n",
+		},
+		{
+			name:   "csv neutralizes formulas",
+			format: ExportFormatCSV,
+			batches: [][]*User{{
+				{ID: 3, Name: "=HYPERLINK(\"http://evil.example\")", Status: StatusActive, CreatedAt: createdAt},
+				{ID: 4, Name: "@SUM(A1)", Status: StatusActive, CreatedAt: createdAt},
+				{ID: 5, Name: "O'Brien - Sales", Status: StatusActive, CreatedAt: createdAt},
+			}},
+			expected: "3,\"'=HYPERLINK(\"\"http://evil.example\"\")\",active,2024-01-02T03:04:05Z\n" +
+				"4,'@SUM(A1),active,2024-01-02T03:04:05Z\n" +
+				"5,O'Brien - Sales,active,2024-01-02T03:04:05Z\n",
+		},
+		{
+			name:    "ndjson keeps column order",
+			format:  ExportFormatNDJSON,
+			header:  true,
+			batches: [][]*User{users},
+			expected: `{"id":1,"name":"Kim, Minsu","status":"active","created_at":"2024-01-02T03:04:05Z"}` + "\n" +
+				`{"id":2,"name":"Lee \"Jay\"","status":"suspended","created_at":"2024-01-02T03:04:05Z"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer := NewExportWriter(&buf, tc.format, columns, tc.header)
+
+			for _, batch := range tc.batches {
+				require.NoError(t, writer.Write(batch))
+			}
+			require.NoError(t, writer.Flush())
+
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+// slowRepository 내보내기 배치마다 지연되는 저장소 / Repository that stalls on every export batch
+type slowRepository struct {
+	Repository
+	delay time.Duration
+}
+
+func (r *slowRepository) Export(ctx context.Context, query *ListUsersQuery, _ int, fn func(users []*User) error) error {
+	return r.Repository.Export(ctx, query, 1, func(users []*User) error {
+		time.Sleep(r.delay)
+		return fn(users)
+	})
+}
+
+func TestHandler_ExportOutlivesWriteTimeout(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	const total = 6
+	for i := range total {
+		require.NoError(t, repo.Create(t.Context(), &User{Name: fmt.Sprintf("User %d", i), Email: fmt.Sprintf("user%d@example.com", i), Status: StatusActive}))
+	}
+
+	service := NewService(&slowRepository{Repository: repo, delay: 100 * time.Millisecond}, db.NewTxManager(database, db.TxConfig{}), testHasher)
+	handler := NewHandler(service, &Stream{WriteTimeout: 300 * time.Millisecond})
+	// 내보내기 전체가 서버 쓰기 타임아웃보다 오래 걸림 / The whole export takes longer than the server write timeout
+	app := fiber.New(fiber.Config{WriteTimeout: 300 * time.Millisecond, DisableStartupMessage: true})
+	app.Get("/export", handler.Export)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(listener) }()
+	t.Cleanup(func() { _ = app.Shutdown() })
+
+	res, err := http.Get("http://" + listener.Addr().String() + "/export?format=ndjson")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(body)), "\n"), total)
+}
@@ -2,30 +2,156 @@
 package user
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"errors"
+	"io"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/audit"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/crud"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/middleware"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/etag"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/jsonpatch"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/listquery"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/password"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/resp"
 )
 
-const statusValidationMessage = "Status must be one of: active, inactive, suspended"
+const (
+	statusValidationMessage = "Status must be one of: active, inactive, suspended"
+	statusChangeMessage     = "Status cannot be changed here; use POST /v1/users/{id}/activate, /deactivate, or /suspend"
+	initialStatusMessage    = "New users start active; use POST /v1/users/{id}/deactivate or /suspend after creating them"
+	acceptPatch             = jsonpatch.MergePatchContentType + ", " + jsonpatch.JSONPatchContentType
+	ifMatchFormatMessage    = "If-Match must be \"*\" or a single strong entity tag"
+)
 
 // Handler 사용자 HTTP 핸들러 / User HTTP handler
 type Handler struct {
-	service Service
+	service  Service
+	stream   *Stream
+	resource *crud.Handler[User, uint, CreateUserRequest, UpdateUserRequest]
 }
 
 // NewHandler 새 사용자 핸들러 생성 / Create new user handler
-func NewHandler(service Service) *Handler {
-	return &Handler{service: service}
+// 기본 CRUD와 목록은 범용 핸들러에 서비스 훅을 연결해 처리 / Basic CRUD and listing run on the generic handler wired to service hooks
+func NewHandler(service Service, stream *Stream) *Handler {
+	h := &Handler{service: service, stream: stream}
+	h.resource = crud.NewHandler(crud.HandlerConfig[User, uint, CreateUserRequest, UpdateUserRequest]{
+		Options: listOptions,
+		ParseID: crud.ParseUint,
+		Errors:  errorMappings,
+		Hooks: crud.Hooks[User, uint, CreateUserRequest, UpdateUserRequest]{
+			Create: h.create,
+			Get:    h.get,
+			Update: h.update,
+			Delete: h.delete,
+			List:   h.list,
+			Render: renderUser,
+		},
+	})
+	return h
+}
+
+// errorMappings 사용자 오류의 HTTP 응답 / HTTP responses for user errors
+var errorMappings = []crud.ErrorMapping{
+	{Err: ErrUserNotFound, Respond: resp.NotFound, Message: "User not found"},
+	{Err: etag.ErrInvalidETag, Respond: resp.PreconditionFailed, Message: ifMatchFormatMessage},
+	{Err: ErrPreconditionFailed, Respond: resp.PreconditionFailed, Message: "User version does not match If-Match"},
+	{Err: ErrVersionConflict, Respond: resp.Conflict, Message: "User was modified concurrently"},
+	{Err: ErrEmailAlreadyExists, Respond: resp.Conflict, Message: "Email already exists"},
+	{Err: ErrIllegalTransition, Respond: resp.Conflict, Message: "Status transition is not allowed", Details: true},
+	{Err: ErrInvalidStatus, Respond: resp.BadRequest, Message: statusValidationMessage},
+	{Err: ErrStatusChangeNotAllowed, Respond: resp.BadRequest, Message: statusChangeMessage},
+	{Err: ErrInitialStatus, Respond: resp.BadRequest, Message: initialStatusMessage},
+}
+
+// auditContext 요청 주체와 요청 ID를 담은 요청 컨텍스트 / Request context carrying the actor and request ID for audit events
+func auditContext(c *fiber.Ctx) context.Context {
+	return audit.WithMeta(c.UserContext(), audit.Meta{
+		Actor:     middleware.GetActor(c),
+		RequestID: middleware.GetRequestID(c),
+	})
+}
+
+// create 생성 훅 / Create hook
+func (h *Handler) create(c *fiber.Ctx, req *CreateUserRequest) (*User, error) {
+	return h.service.Create(auditContext(c), req)
+}
+
+// get 조회 훅 / Get hook
+func (h *Handler) get(c *fiber.Ctx, id uint) (*User, error) {
+	return h.service.GetByID(c.UserContext(), id)
+}
+
+// update If-Match 조건부 업데이트 훅 / If-Match conditional update hook
+func (h *Handler) update(c *fiber.Ctx, id uint, req *UpdateUserRequest) (*User, error) {
+	expectedVersion, err := etag.ParseIfMatch(c.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		return nil, err
+	}
+	return h.service.Update(auditContext(c), id, req, expectedVersion)
+}
+
+// delete If-Match 조건부 삭제 훅 / If-Match conditional delete hook
+func (h *Handler) delete(c *fiber.Ctx, id uint) error {
+	expectedVersion, err := etag.ParseIfMatch(c.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		return err
+	}
+
+	// hard=true 이면 영구 삭제 / Permanently delete when hard=true
+	if c.QueryBool("hard") {
+		return h.service.HardDelete(auditContext(c), id, expectedVersion)
+	}
+	return h.service.Delete(auditContext(c), id, expectedVersion)
+}
+
+// list 상태, 검색, 삭제 범위, 커서를 더한 목록 훅 / List hook adding status, search, deletion scope, and cursors
+func (h *Handler) list(c *fiber.Ctx, q *crud.Query) (*crud.Page[User], error) {
+	query := ListUsersQuery{
+		Offset:         q.Offset,
+		Limit:          q.Limit,
+		Status:         Status(c.Query("status")),
+		Search:         c.Query("search"),
+		Cursor:         q.Cursor,
+		Sort:           q.Sort,
+		IncludeDeleted: c.QueryBool("include_deleted"),
+		OnlyDeleted:    c.QueryBool("only_deleted"),
+		Filter:         q.Filter,
+	}
+
+	users, total, err := h.service.List(c.UserContext(), &query)
+	if err != nil {
+		return nil, err
+	}
+	return &crud.Page[User]{Items: users, Total: total, NextCursor: query.NextCursor(users)}, nil
+}
+
+// renderUser ETag와 함께 사용자 응답, 조회는 If-None-Match 일치 시 304
+// Render a user with its ETag; reads answer 304 when If-None-Match matches
+func renderUser(c *fiber.Ctx, status int, user *User) error {
+	if status == fiber.StatusCreated {
+		return c.Status(status).JSON(resp.SuccessResponse{Data: user})
+	}
+
+	c.Set(fiber.HeaderETag, etag.Format(user.Version))
+	if c.Method() == fiber.MethodGet && etag.MatchIfNoneMatch(c.Get(fiber.HeaderIfNoneMatch), user.Version) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	return resp.Success(c, user)
 }
 
 // Create 사용자 생성 / Create user
 // @Summary Create user
-// @Description Create a new user
+// @Description Create a new user. New users start active; any other status returns 400
 // @Tags users
 // @Accept json
 // @Produce json
@@ -36,202 +162,799 @@ func NewHandler(service Service) *Handler {
 // @Failure 500 {object} resp.ErrorResponse
 // @Router /v1/users [post]
 func (h *Handler) Create(c *fiber.Ctx) error {
-	var req CreateUserRequest
+	return h.resource.Create(c)
+}
 
-	// 요청 바디 파싱 / Parse request body
-	if err := c.BodyParser(&req); err != nil {
-		return resp.BadRequest(c, "Invalid request body", err.Error())
-	}
+// GetByID ID로 사용자 조회 / Get user by ID
+// @Summary Get user by ID
+// @Description Get user information by ID
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Success 200 {object} resp.SuccessResponse{data=User}
+// @Success 304
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/users/{id} [get]
+func (h *Handler) GetByID(c *fiber.Ctx) error {
+	return h.resource.GetByID(c)
+}
 
-	// 기본 필드 검증 / Basic field validation
-	if req.Name == "" {
-		return resp.BadRequest(c, "Name is required")
-	}
-	if req.Email == "" {
-		return resp.BadRequest(c, "Email is required")
-	}
-	if len(req.Name) < 2 || len(req.Name) > 100 {
-		return resp.BadRequest(c, "Name must be between 2 and 100 characters")
+// Update 사용자 업데이트 / Update user
+// @Summary Update user
+// @Description Update user information. The status must match the current one; change it with the activate, deactivate, and suspend endpoints.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param user body UpdateUserRequest true "User update request"
+// @Param If-Match header string false "ETag the update is conditional on"
+// @Success 200 {object} resp.SuccessResponse{data=User}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 409 {object} resp.ErrorResponse
+// @Failure 412 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/users/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	return h.resource.Update(c)
+}
+
+// Patch 사용자 부분 업데이트 / Partially update user
+// @Summary Patch user
+// @Description Partially update user with JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902). The status cannot change; use the activate, deactivate, and suspend endpoints.
+// @Tags users
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param patch body object true "Merge patch object or JSON Patch operation array"
+// @Param If-Match header string false "ETag the patch is conditional on"
+// @Success 200 {object} resp.SuccessResponse{data=User}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 409 {object} resp.ErrorResponse
+// @Failure 412 {object} resp.ErrorResponse
+// @Failure 415 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/users/{id} [patch]
+func (h *Handler) Patch(c *fiber.Ctx) error {
+	c.Set("Accept-Patch", acceptPatch)
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return resp.BadRequest(c, "Invalid user ID")
 	}
-	if req.Status != "" && !req.Status.IsValid() {
-		return resp.BadRequest(c, statusValidationMessage)
+
+	// 미디어 타입 확인 (파라미터 제외) / Check media type (ignoring parameters)
+	contentType := strings.TrimSpace(strings.Split(c.Get(fiber.HeaderContentType), ";")[0])
+	if contentType != jsonpatch.MergePatchContentType && contentType != jsonpatch.JSONPatchContentType {
+		return resp.UnsupportedMediaType(c, "Content-Type must be one of: "+acceptPatch)
 	}
 
-	// TODO: 더 정교한 검증 로직 추가 가능 / Can add more sophisticated validation logic
-	// - Email 형식 검증 (정규표현식)
-	// - 비밀번호 강도 검증 (향후 추가 시)
-	// - 사용자 정의 검증 규칙
+	expectedVersion, err := etag.ParseIfMatch(c.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		return resp.PreconditionFailed(c, ifMatchFormatMessage)
+	}
 
-	user, err := h.service.Create(&req)
+	user, err := h.service.Patch(auditContext(c), uint(id), contentType, c.Body(), expectedVersion)
 	if err != nil {
-		if errors.Is(err, ErrEmailAlreadyExists) {
+		var validationErr *ValidationError
+		switch {
+		case errors.Is(err, ErrUserNotFound):
+			return resp.NotFound(c, "User not found")
+		case errors.Is(err, ErrPreconditionFailed):
+			return resp.PreconditionFailed(c, "User version does not match If-Match")
+		case errors.Is(err, ErrVersionConflict):
+			return resp.Conflict(c, "User was modified concurrently")
+		case errors.Is(err, jsonpatch.ErrTestFailed):
+			return resp.Conflict(c, "Patch test operation failed", err.Error())
+		case errors.Is(err, jsonpatch.ErrInvalidPatch):
+			return resp.BadRequest(c, "Invalid patch document", err.Error())
+		case errors.Is(err, ErrReadOnlyField):
+			return resp.BadRequest(c, "Patch modifies a read-only field", err.Error())
+		case errors.Is(err, ErrUnknownField):
+			return resp.BadRequest(c, "Patch adds an unknown field", err.Error())
+		case errors.As(err, &validationErr):
+			return resp.BadRequest(c, validationErr.Message)
+		case errors.Is(err, ErrEmailAlreadyExists):
 			return resp.Conflict(c, "Email already exists")
+		case errors.Is(err, ErrIllegalTransition):
+			return resp.Conflict(c, "Status transition is not allowed", err.Error())
 		}
-		if errors.Is(err, ErrInvalidStatus) {
-			return resp.BadRequest(c, statusValidationMessage)
-		}
-		zap.L().Error("Failed to create user", zap.Error(err))
-		return resp.InternalServerError(c, "Failed to create user")
+		zap.L().Error("Failed to patch user", zap.Error(err), zap.Uint64("user_id", id))
+		return resp.InternalServerError(c, "Failed to patch user")
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(resp.SuccessResponse{Data: user})
+	c.Set(fiber.HeaderETag, etag.Format(user.Version))
+	return resp.Success(c, user)
 }
 
-// GetByID ID로 사용자 조회 / Get user by ID
-// @Summary Get user by ID
-// @Description Get user information by ID
+// Delete 사용자 삭제 / Delete user
+// @Summary Delete user
+// @Description Delete user by ID
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
+// @Param If-Match header string false "ETag the delete is conditional on"
+// @Param hard query bool false "Permanently delete, including an already soft-deleted user" default(false)
+// @Success 204
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 412 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/users/{id} [delete]
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	return h.resource.Delete(c)
+}
+
+// Restore 소프트 삭제된 사용자 복원 / Restore a soft-deleted user
+// @Summary Restore user
+// @Description Restore a soft-deleted user; its version comes from a list with include_deleted=true
+// @Tags users
+// @Produce json
+// @Param id path int true "User ID"
+// @Param If-Match header string false "ETag the restore is conditional on"
 // @Success 200 {object} resp.SuccessResponse{data=User}
 // @Failure 400 {object} resp.ErrorResponse
 // @Failure 404 {object} resp.ErrorResponse
+// @Failure 409 {object} resp.ErrorResponse
+// @Failure 412 {object} resp.ErrorResponse
 // @Failure 500 {object} resp.ErrorResponse
-// @Router /v1/users/{id} [get]
-func (h *Handler) GetByID(c *fiber.Ctx) error {
+// @Router /v1/users/{id}/restore [post]
+func (h *Handler) Restore(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
 		return resp.BadRequest(c, "Invalid user ID")
 	}
 
-	user, err := h.service.GetByID(uint(id))
+	expectedVersion, err := etag.ParseIfMatch(c.Get(fiber.HeaderIfMatch))
 	if err != nil {
-		if errors.Is(err, ErrUserNotFound) {
+		return resp.PreconditionFailed(c, ifMatchFormatMessage)
+	}
+
+	user, err := h.service.Restore(auditContext(c), uint(id), expectedVersion)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUserNotFound):
 			return resp.NotFound(c, "User not found")
+		case errors.Is(err, ErrUserNotDeleted):
+			return resp.Conflict(c, "User is not deleted")
+		case errors.Is(err, ErrPreconditionFailed):
+			return resp.PreconditionFailed(c, "User version does not match If-Match")
+		case errors.Is(err, ErrVersionConflict):
+			return resp.Conflict(c, "User was modified concurrently")
 		}
-		zap.L().Error("Failed to get user", zap.Error(err), zap.Uint64("user_id", id))
-		return resp.InternalServerError(c, "Failed to get user")
+		zap.L().Error("Failed to restore user", zap.Error(err), zap.Uint64("user_id", id))
+		return resp.InternalServerError(c, "Failed to restore user")
 	}
 
+	c.Set(fiber.HeaderETag, etag.Format(user.Version))
 	return resp.Success(c, user)
 }
 
-// Update 사용자 업데이트 / Update user
-// @Summary Update user
-// @Description Update user information
+// History 사용자 변경 이력 조회 / Get user change history
+// @Summary Get user history
+// @Description List audit events for a user, newest first, including after a hard delete
+// @Tags users
+// @Produce json
+// @Param id path int true "User ID"
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination" default(20)
+// @Success 200 {object} resp.PaginatedResponse{data=[]audit.Event}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/users/{id}/history [get]
+func (h *Handler) History(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return resp.BadRequest(c, "Invalid user ID")
+	}
+
+	var query HistoryQuery
+	if err := c.QueryParser(&query); err != nil {
+		return resp.BadRequest(c, "Invalid query parameters", err.Error())
+	}
+
+	events, total, err := h.service.History(c.UserContext(), uint(id), &query)
+	if err != nil {
+		zap.L().Error("Failed to get user history", zap.Error(err), zap.Uint64("user_id", id))
+		return resp.InternalServerError(c, "Failed to get user history")
+	}
+
+	return resp.SuccessWithPagination(c, events, query.Offset, query.Limit, total)
+}
+
+// SetPassword 사용자 비밀번호 설정 / Set user password
+// @Summary Set user password
+// @Description Set or replace the password used by POST /v1/auth/login
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
-// @Param user body UpdateUserRequest true "User update request"
+// @Param password body SetPasswordRequest true "New password"
+// @Param If-Match header string false "ETag the change is conditional on"
 // @Success 200 {object} resp.SuccessResponse{data=User}
 // @Failure 400 {object} resp.ErrorResponse
 // @Failure 404 {object} resp.ErrorResponse
 // @Failure 409 {object} resp.ErrorResponse
+// @Failure 412 {object} resp.ErrorResponse
 // @Failure 500 {object} resp.ErrorResponse
-// @Router /v1/users/{id} [put]
-func (h *Handler) Update(c *fiber.Ctx) error {
+// @Failure 503 {object} resp.ErrorResponse
+// @Router /v1/users/{id}/password [put]
+func (h *Handler) SetPassword(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
 		return resp.BadRequest(c, "Invalid user ID")
 	}
 
-	var req UpdateUserRequest
-	if parseErr := c.BodyParser(&req); parseErr != nil {
-		return resp.BadRequest(c, "Invalid request body", parseErr.Error())
+	expectedVersion, err := etag.ParseIfMatch(c.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		return resp.PreconditionFailed(c, ifMatchFormatMessage)
 	}
 
-	// 기본 필드 검증 / Basic field validation
-	if req.Name != nil && (*req.Name == "" || len(*req.Name) < 2 || len(*req.Name) > 100) {
-		return resp.BadRequest(c, "Name must be between 2 and 100 characters")
-	}
-	if req.Email != nil && *req.Email == "" {
-		return resp.BadRequest(c, "Email cannot be empty")
-	}
-	if req.Status != nil && !req.Status.IsValid() {
-		return resp.BadRequest(c, statusValidationMessage)
+	var req SetPasswordRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		return resp.BadRequest(c, "Invalid request body", parseErr.Error())
 	}
 
-	user, err := h.service.Update(uint(id), &req)
+	user, err := h.service.SetPassword(auditContext(c), uint(id), &req, expectedVersion)
 	if err != nil {
-		if errors.Is(err, ErrUserNotFound) {
+		var validationErr *ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			return resp.BadRequest(c, validationErr.Message)
+		case errors.Is(err, ErrUserNotFound):
 			return resp.NotFound(c, "User not found")
+		case errors.Is(err, ErrPreconditionFailed):
+			return resp.PreconditionFailed(c, "User version does not match If-Match")
+		case errors.Is(err, ErrVersionConflict):
+			return resp.Conflict(c, "User was modified concurrently")
+		case errors.Is(err, password.ErrBusy):
+			c.Set(fiber.HeaderRetryAfter, "1")
+			return resp.ServiceUnavailable(c, "Too many password changes in progress, try again shortly")
 		}
-		if errors.Is(err, ErrEmailAlreadyExists) {
-			return resp.Conflict(c, "Email already exists")
-		}
-		if errors.Is(err, ErrInvalidStatus) {
-			return resp.BadRequest(c, statusValidationMessage)
-		}
-		zap.L().Error("Failed to update user", zap.Error(err), zap.Uint64("user_id", id))
-		return resp.InternalServerError(c, "Failed to update user")
+		zap.L().Error("Failed to set user password", zap.Error(err), zap.Uint64("user_id", id))
+		return resp.InternalServerError(c, "Failed to set user password")
 	}
 
+	c.Set(fiber.HeaderETag, etag.Format(user.Version))
 	return resp.Success(c, user)
 }
 
-// Delete 사용자 삭제 / Delete user
-// @Summary Delete user
-// @Description Delete user by ID
+// Activate 사용자 활성화 / Activate user
+// @Summary Activate user
+// @Description Move an inactive or suspended user to active
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
-// @Success 204
+// @Param transition body TransitionRequest true "Transition reason"
+// @Param If-Match header string false "ETag the transition is conditional on"
+// @Success 200 {object} resp.SuccessResponse{data=User}
 // @Failure 400 {object} resp.ErrorResponse
 // @Failure 404 {object} resp.ErrorResponse
+// @Failure 409 {object} resp.ErrorResponse
+// @Failure 412 {object} resp.ErrorResponse
 // @Failure 500 {object} resp.ErrorResponse
-// @Router /v1/users/{id} [delete]
-func (h *Handler) Delete(c *fiber.Ctx) error {
+// @Router /v1/users/{id}/activate [post]
+func (h *Handler) Activate(c *fiber.Ctx) error {
+	return h.transition(c, StatusActive)
+}
+
+// Deactivate 사용자 비활성화 / Deactivate user
+// @Summary Deactivate user
+// @Description Move an active or suspended user to inactive
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param transition body TransitionRequest true "Transition reason"
+// @Param If-Match header string false "ETag the transition is conditional on"
+// @Success 200 {object} resp.SuccessResponse{data=User}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 409 {object} resp.ErrorResponse
+// @Failure 412 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/users/{id}/deactivate [post]
+func (h *Handler) Deactivate(c *fiber.Ctx) error {
+	return h.transition(c, StatusInactive)
+}
+
+// Suspend 사용자 정지 / Suspend user
+// @Summary Suspend user
+// @Description Suspend an active user, optionally until a given time
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param transition body TransitionRequest true "Transition reason and optional expiry"
+// @Param If-Match header string false "ETag the transition is conditional on"
+// @Success 200 {object} resp.SuccessResponse{data=User}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 409 {object} resp.ErrorResponse
+// @Failure 412 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/users/{id}/suspend [post]
+func (h *Handler) Suspend(c *fiber.Ctx) error {
+	return h.transition(c, StatusSuspended)
+}
+
+// transition 상태 전이 요청 처리 / Handle a status transition request
+func (h *Handler) transition(c *fiber.Ctx, target Status) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
 		return resp.BadRequest(c, "Invalid user ID")
 	}
 
-	err = h.service.Delete(uint(id))
+	expectedVersion, err := etag.ParseIfMatch(c.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		return resp.PreconditionFailed(c, ifMatchFormatMessage)
+	}
+
+	var req TransitionRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		return resp.BadRequest(c, "Invalid request body", parseErr.Error())
+	}
+
+	user, err := h.service.Transition(auditContext(c), uint(id), target, &req, expectedVersion)
 	if err != nil {
-		if errors.Is(err, ErrUserNotFound) {
+		var validationErr *ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			return resp.BadRequest(c, validationErr.Message)
+		case errors.Is(err, ErrUserNotFound):
 			return resp.NotFound(c, "User not found")
+		case errors.Is(err, ErrIllegalTransition):
+			return resp.Conflict(c, "Status transition is not allowed", err.Error())
+		case errors.Is(err, ErrPreconditionFailed):
+			return resp.PreconditionFailed(c, "User version does not match If-Match")
+		case errors.Is(err, ErrVersionConflict):
+			return resp.Conflict(c, "User was modified concurrently")
 		}
-		zap.L().Error("Failed to delete user", zap.Error(err), zap.Uint64("user_id", id))
-		return resp.InternalServerError(c, "Failed to delete user")
+		zap.L().Error("Failed to change user status", zap.Error(err), zap.Uint64("user_id", id))
+		return resp.InternalServerError(c, "Failed to change user status")
 	}
 
-	return c.SendStatus(fiber.StatusNoContent)
+	c.Set(fiber.HeaderETag, etag.Format(user.Version))
+	return resp.Success(c, user)
 }
 
 // List 사용자 목록 조회 / List users
 // @Summary List users
-// @Description Get list of users with pagination
+// @Description Get list of users with offset or cursor (keyset) pagination
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param offset query int false "Offset for pagination" default(0)
 // @Param limit query int false "Limit for pagination" default(20)
+// @Param cursor query string false "Opaque cursor from a previous next_cursor; pagination.total is omitted in cursor mode"
+// @Param sort query string false "Comma-separated sort fields, prefix with - for descending" default(-created_at)
 // @Param status query string false "Filter by status" Enums(active, inactive, suspended)
 // @Param search query string false "Search by name or email"
+// @Param id[in] query string false "Filter by comma-separated IDs; id also takes eq, ne, gt, gte, lt, lte, nin"
+// @Param name[contains] query string false "Filter by name substring; name also takes eq, ne, in, nin, prefix, suffix"
+// @Param email[suffix] query string false "Filter by email suffix such as @corp.com; email also takes eq, ne, in, nin, prefix, contains"
+// @Param status[in] query string false "Filter by comma-separated statuses; status also takes eq, ne, nin"
+// @Param created_at[gte] query string false "Created at or after an RFC 3339 time or YYYY-MM-DD date; created_at also takes eq, ne, gt, lt, lte"
+// @Param created_at[lt] query string false "Created before an RFC 3339 time or YYYY-MM-DD date"
+// @Param updated_at[gte] query string false "Updated at or after an RFC 3339 time or YYYY-MM-DD date; updated_at also takes eq, ne, gt, lt, lte"
+// @Param include_deleted query bool false "Include soft-deleted users" default(false)
+// @Param only_deleted query bool false "Return only soft-deleted users" default(false)
 // @Success 200 {object} resp.PaginatedResponse{data=[]User}
 // @Failure 400 {object} resp.ErrorResponse
 // @Failure 500 {object} resp.ErrorResponse
 // @Router /v1/users [get]
 func (h *Handler) List(c *fiber.Ctx) error {
-	var query ListUsersQuery
+	return h.resource.List(c)
+}
 
-	// 쿼리 파라미터 파싱 / Parse query parameters
+// Export 사용자 내보내기 / Export users
+// @Summary Export users
+// @Description Stream users matching the list filters as CSV or NDJSON in ID order; pagination and sort are ignored
+// @Tags users
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "Output format" Enums(csv, ndjson) default(csv)
+// @Param columns query string false "Comma-separated columns to include" default(id,name,email,status,version,created_at,updated_at)
+// @Param header query bool false "Write a CSV header row" default(true)
+// @Param status query string false "Filter by status" Enums(active, inactive, suspended)
+// @Param search query string false "Search by name or email"
+// @Param id[in] query string false "Filter by comma-separated IDs; id also takes eq, ne, gt, gte, lt, lte, nin"
+// @Param name[contains] query string false "Filter by name substring; name also takes eq, ne, in, nin, prefix, suffix"
+// @Param email[suffix] query string false "Filter by email suffix such as @corp.com; email also takes eq, ne, in, nin, prefix, contains"
+// @Param status[in] query string false "Filter by comma-separated statuses; status also takes eq, ne, nin"
+// @Param created_at[gte] query string false "Created at or after an RFC 3339 time or YYYY-MM-DD date; created_at also takes eq, ne, gt, lt, lte"
+// @Param created_at[lt] query string false "Created before an RFC 3339 time or YYYY-MM-DD date"
+// @Param updated_at[gte] query string false "Updated at or after an RFC 3339 time or YYYY-MM-DD date; updated_at also takes eq, ne, gt, lt, lte"
+// @Param include_deleted query bool false "Include soft-deleted users" default(false)
+// @Param only_deleted query bool false "Export only soft-deleted users" default(false)
+// @Success 200 {string} string "CSV or NDJSON stream"
+// @Failure 400 {object} resp.ErrorResponse
+// @Router /v1/users/export [get]
+func (h *Handler) Export(c *fiber.Ctx) error {
+	var query ListUsersQuery
 	if err := c.QueryParser(&query); err != nil {
 		return resp.BadRequest(c, "Invalid query parameters", err.Error())
 	}
-
-	// 쿼리 검증 및 기본값 설정 / Validate query and set defaults
-	query.Validate()
 	if query.Status != "" && !query.Status.IsValid() {
 		return resp.BadRequest(c, statusValidationMessage)
 	}
 
-	users, total, err := h.service.List(&query)
+	filter, err := listquery.ParseFilter(c.Queries(), FilterableFields)
+	if err != nil {
+		return resp.BadRequest(c, "Invalid filter", err)
+	}
+	query.Filter = filter
+
+	var opts ExportOptions
+	if err := c.QueryParser(&opts); err != nil {
+		return resp.BadRequest(c, "Invalid query parameters", err.Error())
+	}
+	columns, header, err := opts.Resolve()
+	if err != nil {
+		return resp.BadRequest(c, err.Error())
+	}
+
+	c.Set(fiber.HeaderContentType, opts.Format.ContentType())
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="users.`+string(opts.Format)+`"`)
+
+	// 핸들러 반환 후에도 쓰이므로 요청 마감 시간이 없는 컨텍스트 / Used after the handler returns, so the route has no request deadline
+	ctx := c.UserContext()
+	// 스트림 작성기에서는 RequestCtx를 쓸 수 없으므로 미리 꺼냄 / The stream writer cannot touch RequestCtx, so take the conn first
+	conn := c.Context().Conn()
+
+	// 응답 헤더 전송 후 스트리밍되므로 오류는 로그로만 남김 / Errors can only be logged once streaming has started
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		writer := NewExportWriter(w, opts.Format, columns, header)
+		err := h.service.Export(ctx, &query, func(users []*User) error {
+			// 서버 WriteTimeout보다 오래 걸리는 내보내기도 끊기지 않도록 배치마다 연장 / Extend per batch so exports longer than the server WriteTimeout are not cut off
+			if err := extendWriteDeadline(conn, h.stream.WriteTimeout); err != nil {
+				return err
+			}
+			if err := writer.Write(users); err != nil {
+				return err
+			}
+			return w.Flush()
+		})
+		if err == nil {
+			err = extendWriteDeadline(conn, h.stream.WriteTimeout)
+		}
+		if err == nil {
+			err = writer.Flush()
+		}
+		if err == nil {
+			err = w.Flush()
+		}
+		if err != nil {
+			zap.L().Error("Failed to stream user export", zap.Error(err))
+		}
+	})
+
+	return nil
+}
+
+// Stream 사용자 변경 스트림 / User change stream
+// @Summary Stream user changes
+// @Description Stream user create, update, status change and delete events as Server-Sent Events. Each event id is a change sequence number; reconnect with Last-Event-ID to resume.
+// @Tags users
+// @Produce text/event-stream
+// @Param status query string false "Comma-separated statuses to include" example(active,suspended)
+// @Param ids query string false "Comma-separated user IDs to include" example(1,2,3)
+// @Param Last-Event-ID header string false "Resume after this change sequence number"
+// @Param last_event_id query string false "Same as Last-Event-ID, for clients that cannot set headers"
+// @Success 200 {string} string "text/event-stream"
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 503 {object} resp.ErrorResponse
+// @Router /v1/users/stream [get]
+func (h *Handler) Stream(c *fiber.Ctx) error {
+	filter, err := ParseStreamFilter(c.Query("status"), c.Query("ids"))
 	if err != nil {
-		zap.L().Error("Failed to list users", zap.Error(err))
-		return resp.InternalServerError(c, "Failed to list users")
+		return resp.BadRequest(c, err.Error())
 	}
 
-	return resp.SuccessWithPagination(c, users, query.Offset, query.Limit, total)
+	lastEventID := c.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	afterID, resume, err := ParseLastEventID(lastEventID)
+	if err != nil {
+		return resp.BadRequest(c, err.Error())
+	}
+
+	sub, cursor, err := h.stream.Feed.Subscribe()
+	if err != nil {
+		if errors.Is(err, outbox.ErrFeedFull) {
+			return resp.ServiceUnavailable(c, "Too many stream connections")
+		}
+		zap.L().Error("Failed to subscribe to user stream", zap.Error(err))
+		return resp.InternalServerError(c, "Failed to open user stream")
+	}
+
+	c.Set(fiber.HeaderContentType, StreamContentType)
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set("X-Accel-Buffering", "no")
+
+	// 스트림 작성기에서는 RequestCtx를 쓸 수 없으므로 미리 꺼냄 / The stream writer cannot touch RequestCtx, so take these first
+	conn := c.Context().Conn()
+	shutdown := c.Context().Done()
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+
+		events := NewEventWriter(w, conn, h.stream.WriteTimeout)
+		err := h.streamEvents(events, sub, filter, shutdown, func() error {
+			if !resume {
+				return nil
+			}
+			return h.stream.Feed.Replay(afterID, cursor, func(envelope outbox.Envelope) error {
+				if !filter.Matches(envelope) {
+					return nil
+				}
+				return events.Event(envelope)
+			})
+		})
+		if err != nil {
+			zap.L().Debug("User stream closed", zap.Error(err))
+		}
+	})
+
+	return nil
+}
+
+// streamEvents 재생 후 실시간 이벤트와 하트비트 전송 / Send the replay, then live events and heartbeats
+// 버퍼가 가득 차 끊기면 클라이언트는 Last-Event-ID로 재연결해 이어 받음 / A client cut off for a full buffer resumes by reconnecting with Last-Event-ID
+func (h *Handler) streamEvents(events *EventWriter, sub *outbox.FeedSubscription, filter *StreamFilter, shutdown <-chan struct{}, replay func() error) error {
+	if err := events.Retry(streamRetryMillis); err != nil {
+		return err
+	}
+	if err := replay(); err != nil {
+		return err
+	}
+
+	heartbeat := time.NewTicker(h.stream.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case envelope := <-sub.Events():
+			if !filter.Matches(envelope) {
+				continue
+			}
+			if err := events.Event(envelope); err != nil {
+				return err
+			}
+		case <-heartbeat.C:
+			if err := events.Comment("heartbeat"); err != nil {
+				return err
+			}
+		case <-sub.Dropped():
+			zap.L().Warn("Slow user stream consumer dropped", zap.String("method", "user.Handler.streamEvents"))
+			return events.Comment("slow consumer, reconnect with Last-Event-ID")
+		case <-shutdown:
+			return nil
+		}
+	}
+}
+
+// Import 사용자 가져오기 / Import users
+// @Summary Import users
+// @Description Import users from a CSV (with header row) or NDJSON upload, sent as the raw body or a multipart "file" field
+// @Tags users
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Accept multipart/form-data
+// @Produce json
+// @Param format query string false "Upload format, inferred from Content-Type or file extension when omitted" Enums(csv, ndjson)
+// @Param dry_run query bool false "Validate and report without writing" default(false)
+// @Param on_conflict query string false "What to do with rows whose email already exists" Enums(fail, skip, update) default(fail)
+// @Param file formData file false "Upload file (multipart only)"
+// @Success 200 {object} resp.SuccessResponse{data=ImportReport}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/users/import [post]
+func (h *Handler) Import(c *fiber.Ctx) error {
+	var opts ImportOptions
+	if err := c.QueryParser(&opts); err != nil {
+		return resp.BadRequest(c, "Invalid query parameters", err.Error())
+	}
+
+	// 원본 본문 또는 multipart "file" 필드 / Raw body or the multipart "file" field
+	var upload io.Reader = bytes.NewReader(c.Body())
+	contentType, filename := c.Get(fiber.HeaderContentType), ""
+	if strings.HasPrefix(contentType, fiber.MIMEMultipartForm) {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return resp.BadRequest(c, "Multipart upload must include a file field")
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return resp.BadRequest(c, "Failed to open uploaded file", err.Error())
+		}
+		defer file.Close()
+
+		upload, contentType, filename = file, fileHeader.Header.Get(fiber.HeaderContentType), fileHeader.Filename
+	}
+
+	if err := opts.Resolve(contentType, filename); err != nil {
+		return resp.BadRequest(c, err.Error())
+	}
+
+	rows, err := ParseImport(upload, opts.Format)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return resp.BadRequest(c, validationErr.Message)
+		}
+		return resp.BadRequest(c, "Failed to read upload", err.Error())
+	}
+	if len(rows) == 0 {
+		return resp.BadRequest(c, "Upload contains no rows")
+	}
+
+	report, err := h.service.Import(auditContext(c), rows, opts)
+	if err != nil {
+		zap.L().Error("Failed to import users", zap.Error(err))
+		return resp.InternalServerError(c, "Failed to import users")
+	}
+
+	return resp.Success(c, report)
+}
+
+// BatchItemResponse 일괄 처리 항목별 응답 / Per-item batch response
+type BatchItemResponse struct {
+	Index  int               `json:"index"`
+	Status int               `json:"status"`
+	Data   *User             `json:"data,omitempty"`
+	Error  *resp.ErrorDetail `json:"error,omitempty"`
+}
+
+// BatchResponse 일괄 처리 응답 / Batch response
+type BatchResponse struct {
+	Mode      BatchMode           `json:"mode"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Results   []BatchItemResponse `json:"results"`
+}
+
+// BatchCreate 사용자 일괄 생성 / Create users in a batch
+// @Summary Batch create users
+// @Description Create up to 100 users atomically (default) or with partial success
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param batch body BatchCreateRequest true "Batch creation request"
+// @Success 201 {object} resp.SuccessResponse{data=BatchResponse}
+// @Success 207 {object} resp.SuccessResponse{data=BatchResponse}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/users:batch [post]
+func (h *Handler) BatchCreate(c *fiber.Ctx) error {
+	var req BatchCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return resp.BadRequest(c, "Invalid request body", err.Error())
+	}
+	req.Mode = defaultBatchMode(req.Mode)
+
+	results, err := h.service.BatchCreate(auditContext(c), req.Mode, req.Items)
+	return h.respondBatch(c, req.Mode, fiber.StatusCreated, fiber.StatusCreated, results, err)
+}
+
+// BatchUpdate 사용자 일괄 업데이트 / Update users in a batch
+// @Summary Batch update users
+// @Description Update up to 100 users atomically (default) or with partial success; a non-zero version acts like If-Match
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param batch body BatchUpdateRequest true "Batch update request"
+// @Success 200 {object} resp.SuccessResponse{data=BatchResponse}
+// @Success 207 {object} resp.SuccessResponse{data=BatchResponse}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/users:batchUpdate [post]
+func (h *Handler) BatchUpdate(c *fiber.Ctx) error {
+	var req BatchUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return resp.BadRequest(c, "Invalid request body", err.Error())
+	}
+	req.Mode = defaultBatchMode(req.Mode)
+
+	results, err := h.service.BatchUpdate(auditContext(c), req.Mode, req.Items)
+	return h.respondBatch(c, req.Mode, fiber.StatusOK, fiber.StatusOK, results, err)
+}
+
+// BatchDelete 사용자 일괄 삭제 / Delete users in a batch
+// @Summary Batch delete users
+// @Description Delete up to 100 users atomically (default) or with partial success; a non-zero version acts like If-Match
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param batch body BatchDeleteRequest true "Batch delete request"
+// @Success 200 {object} resp.SuccessResponse{data=BatchResponse}
+// @Success 207 {object} resp.SuccessResponse{data=BatchResponse}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/users:batchDelete [post]
+func (h *Handler) BatchDelete(c *fiber.Ctx) error {
+	var req BatchDeleteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return resp.BadRequest(c, "Invalid request body", err.Error())
+	}
+	req.Mode = defaultBatchMode(req.Mode)
+
+	results, err := h.service.BatchDelete(auditContext(c), req.Mode, req.Items)
+	return h.respondBatch(c, req.Mode, fiber.StatusOK, fiber.StatusNoContent, results, err)
+}
+
+func defaultBatchMode(mode BatchMode) BatchMode {
+	if mode == "" {
+		return BatchModeAtomic
+	}
+	return mode
+}
+
+// respondBatch 항목별 결과를 응답으로 변환 / Convert per-item results into a response
+// 모두 성공하면 successStatus, 하나라도 실패하면 207 Multi-Status / successStatus when all succeed, otherwise 207 Multi-Status
+// itemStatus는 성공한 항목의 상태 코드 / itemStatus is the status reported for successful items
+func (h *Handler) respondBatch(c *fiber.Ctx, mode BatchMode, successStatus, itemStatus int, results []BatchItemResult, err error) error {
+	if err != nil {
+		if errors.Is(err, ErrInvalidBatch) {
+			return resp.BadRequest(c, err.Error())
+		}
+		zap.L().Error("Failed to process batch", zap.Error(err))
+		return resp.InternalServerError(c, "Failed to process batch")
+	}
+
+	body := BatchResponse{Mode: mode, Results: make([]BatchItemResponse, len(results))}
+	for i, result := range results {
+		item := BatchItemResponse{Index: result.Index, Status: itemStatus, Data: result.User}
+		if result.Err != nil {
+			item.Status, item.Error = batchItemError(result.Err)
+			body.Failed++
+		} else {
+			body.Succeeded++
+		}
+		body.Results[i] = item
+	}
+
+	status := successStatus
+	if body.Failed > 0 {
+		status = fiber.StatusMultiStatus
+	}
+	return c.Status(status).JSON(resp.SuccessResponse{Data: body})
+}
+
+// batchItemError 항목 오류를 상태 코드와 오류 상세로 변환 / Map an item error to a status code and error detail
+func batchItemError(err error) (int, *resp.ErrorDetail) {
+	var validationErr *ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return fiber.StatusBadRequest, &resp.ErrorDetail{Code: "BAD_REQUEST", Message: validationErr.Message}
+	case errors.Is(err, ErrInvalidStatus):
+		return fiber.StatusBadRequest, &resp.ErrorDetail{Code: "BAD_REQUEST", Message: statusValidationMessage}
+	case errors.Is(err, ErrUserNotFound):
+		return fiber.StatusNotFound, &resp.ErrorDetail{Code: "NOT_FOUND", Message: "User not found"}
+	case errors.Is(err, ErrPreconditionFailed):
+		return fiber.StatusPreconditionFailed, &resp.ErrorDetail{Code: "PRECONDITION_FAILED", Message: "User version does not match"}
+	case errors.Is(err, ErrVersionConflict):
+		return fiber.StatusConflict, &resp.ErrorDetail{Code: "CONFLICT", Message: "User was modified concurrently"}
+	case errors.Is(err, ErrEmailAlreadyExists):
+		return fiber.StatusConflict, &resp.ErrorDetail{Code: "CONFLICT", Message: "Email already exists"}
+	case errors.Is(err, ErrIllegalTransition):
+		return fiber.StatusConflict, &resp.ErrorDetail{Code: "CONFLICT", Message: "Status transition is not allowed"}
+	case errors.Is(err, ErrBatchAborted):
+		return fiber.StatusFailedDependency, &resp.ErrorDetail{Code: "BATCH_ABORTED", Message: "Rolled back because another item failed"}
+	default:
+		zap.L().Error("Failed to process batch item", zap.Error(err))
+		return fiber.StatusInternalServerError, &resp.ErrorDetail{Code: "INTERNAL_SERVER_ERROR", Message: "Failed to process item"}
+	}
 }
 
 // 향후 확장 가능한 핸들러 메서드들 / Future extensible handler methods
-// - BulkCreate: 대량 사용자 생성
-// - BulkUpdate: 대량 사용자 업데이트
-// - Export: 사용자 데이터 내보내기 (CSV, Excel 등)
-// - Import: 사용자 데이터 가져오기
 // - GetProfile: 사용자 프로필 조회 (확장된 정보)
-// - UpdateStatus: 사용자 상태만 변경
@@ -0,0 +1,218 @@
+package user
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+)
+
+// ConflictPolicy 기존 이메일 처리 정책 / Policy for rows whose email already exists
+type ConflictPolicy string
+
+const (
+	// ConflictFail reports rows with an existing email as errors.
+	ConflictFail ConflictPolicy = "fail"
+	// ConflictSkip leaves existing users untouched.
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictUpdate overwrites the name and status of existing users.
+	ConflictUpdate ConflictPolicy = "update"
+)
+
+// ImportBatchSize 가져오기 시 한 번에 처리하는 행 수 / Rows processed per batch during import
+const ImportBatchSize = 500
+
+// maxImportLineBytes NDJSON 한 줄의 최대 크기 / Maximum size of one NDJSON line
+const maxImportLineBytes = 1 << 20
+
+// ImportAction 가져오기 행 처리 결과 / Import row outcome
+type ImportAction string
+
+const (
+	// ImportCreated means a new user was (or, in a dry run, would be) created.
+	ImportCreated ImportAction = "created"
+	// ImportUpdated means an existing user was (or would be) updated.
+	ImportUpdated ImportAction = "updated"
+	// ImportSkipped means the row matched an existing user and was left alone.
+	ImportSkipped ImportAction = "skipped"
+	// ImportError means the row failed validation or could not be written.
+	ImportError ImportAction = "error"
+)
+
+// ImportOptions 가져오기 쿼리 옵션 / Import query options
+type ImportOptions struct {
+	Format     ExportFormat   `query:"format"`
+	DryRun     bool           `query:"dry_run"`
+	OnConflict ConflictPolicy `query:"on_conflict"`
+}
+
+// Resolve 옵션 검증 및 형식 추론 / Validate options and infer the format
+// format이 없으면 Content-Type, 그다음 파일 확장자로 추론 / Without format, infer from Content-Type, then the file extension
+func (o *ImportOptions) Resolve(contentType, filename string) error {
+	if o.OnConflict == "" {
+		o.OnConflict = ConflictFail
+	}
+	switch o.OnConflict {
+	case ConflictFail, ConflictSkip, ConflictUpdate:
+	default:
+		return &ValidationError{Message: "on_conflict must be one of: skip, update, fail"}
+	}
+
+	if o.Format == "" {
+		o.Format = inferImportFormat(contentType, filename)
+	}
+	if o.Format != ExportFormatCSV && o.Format != ExportFormatNDJSON {
+		return &ValidationError{Message: "Format must be one of: csv, ndjson"}
+	}
+	return nil
+}
+
+func inferImportFormat(contentType, filename string) ExportFormat {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return ExportFormatCSV
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return ExportFormatNDJSON
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return ExportFormatCSV
+	case ".ndjson", ".jsonl":
+		return ExportFormatNDJSON
+	}
+	return ""
+}
+
+// ImportRow 파싱된 가져오기 행 / Parsed import row
+// Err가 있으면 파싱 단계에서 실패한 행 / A non-nil Err means the row failed to parse
+type ImportRow struct {
+	Line    int
+	Request CreateUserRequest
+	Err     error
+}
+
+// ImportRowResult 행별 가져오기 결과 / Per-row import result
+type ImportRowResult struct {
+	Line   int          `json:"line"`
+	Email  string       `json:"email,omitempty"`
+	Action ImportAction `json:"action"`
+	UserID uint         `json:"user_id,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// ImportReport 가져오기 결과 보고서 / Import report
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Skipped int               `json:"skipped"`
+	Errored int               `json:"errored"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// ParseImport 업로드를 행 목록으로 파싱 / Parse an upload into rows
+// 행 단위 오류는 ImportRow.Err에 담고, 파일 전체 오류만 반환 / Row errors go into ImportRow.Err; only file-level errors are returned
+func ParseImport(r io.Reader, format ExportFormat) ([]ImportRow, error) {
+	if format == ExportFormatNDJSON {
+		return parseNDJSONImport(r)
+	}
+	return parseCSVImport(r)
+}
+
+// parseCSVImport 헤더 행이 있는 CSV 파싱 / Parse CSV with a header row
+// 내보내기의 id, version 등 알 수 없는 컬럼은 무시 / Unknown columns such as id or version from an export are ignored
+func parseCSVImport(r io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, &ValidationError{Message: "CSV upload is missing a header row"}
+		}
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	index := map[string]int{"name": -1, "email": -1, "status": -1}
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if _, ok := index[column]; ok {
+			index[column] = i
+		}
+	}
+	if index["name"] < 0 || index["email"] < 0 {
+		return nil, &ValidationError{Message: "CSV header must include name and email columns"}
+	}
+
+	field := func(record []string, column string) string {
+		if i := index[column]; i >= 0 && i < len(record) {
+			return unescapeCSVFormula(strings.TrimSpace(record[i]))
+		}
+		return ""
+	}
+
+	var rows []ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, ImportRow{Line: parseErr.Line, Err: &ValidationError{Message: parseErr.Err.Error()}})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, ImportRow{
+			Line: line,
+			Request: CreateUserRequest{
+				Name:   field(record, "name"),
+				Email:  field(record, "email"),
+				Status: Status(field(record, "status")),
+			},
+		})
+	}
+
+	return rows, nil
+}
+
+// parseNDJSONImport 줄마다 JSON 객체 하나 파싱 / Parse one JSON object per line
+func parseNDJSONImport(r io.Reader) ([]ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineBytes)
+
+	var rows []ImportRow
+	for line := 1; scanner.Scan(); line++ {
+		data := strings.TrimSpace(scanner.Text())
+		if data == "" {
+			continue
+		}
+
+		var req CreateUserRequest
+		if err := json.Unmarshal([]byte(data), &req); err != nil {
+			rows = append(rows, ImportRow{Line: line, Err: &ValidationError{Message: "Invalid JSON object"}})
+			continue
+		}
+		rows = append(rows, ImportRow{Line: line, Request: req})
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, &ValidationError{Message: fmt.Sprintf("NDJSON lines must be at most %d bytes", maxImportLineBytes)}
+		}
+		return nil, fmt.Errorf("failed to read ndjson: %w", err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,107 @@
+package user
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportOptions_Resolve(t *testing.T) {
+	testCases := []struct {
+		name             string
+		options          ImportOptions
+		contentType      string
+		filename         string
+		expectedFormat   ExportFormat
+		expectedConflict ConflictPolicy
+		expectedError    bool
+	}{
+		{
+			name:             "format from content type",
+			contentType:      "text/csv; charset=utf-8",
+			expectedFormat:   ExportFormatCSV,
+			expectedConflict: ConflictFail,
+		},
+		{
+			name:             "format from file extension",
+			options:          ImportOptions{OnConflict: ConflictUpdate},
+			contentType:      "application/octet-stream",
+			filename:         "users.ndjson",
+			expectedFormat:   ExportFormatNDJSON,
+			expectedConflict: ConflictUpdate,
+		},
+		{
+			name:             "explicit format wins",
+			options:          ImportOptions{Format: ExportFormatNDJSON},
+			contentType:      "text/csv",
+			expectedFormat:   ExportFormatNDJSON,
+			expectedConflict: ConflictFail,
+		},
+		{name: "unknown format", contentType: "application/json", expectedError: true},
+		{name: "unknown conflict policy", options: ImportOptions{OnConflict: "merge"}, contentType: "text/csv", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.options.Resolve(tc.contentType, tc.filename)
+			if tc.expectedError {
+				var validationErr *ValidationError
+				assert.ErrorAs(t, err, &validationErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFormat, tc.options.Format)
+			assert.Equal(t, tc.expectedConflict, tc.options.OnConflict)
+		})
+	}
+}
+
+func TestParseImport(t *testing.T) {
+	t.Run("csv export round trip ignores unknown columns", func(t *testing.T) {
+		data := "\ufeffid,Name,email,status,version\n" +
+			"1,User One,one@example.com,active,3\n" +
+			"2,\"Two, User\",two@example.com,,1\n" +
+			"3,Short Row\n"
+
+		rows, err := ParseImport(strings.NewReader(data), ExportFormatCSV)
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+
+		assert.Equal(t, ImportRow{Line: 2, Request: CreateUserRequest{Name: "User One", Email: "one@example.com", Status: StatusActive}}, rows[0])
+		assert.Equal(t, ImportRow{Line: 3, Request: CreateUserRequest{Name: "Two, User", Email: "two@example.com"}}, rows[1])
+		assert.Equal(t, 4, rows[2].Line)
+		assert.Error(t, rows[2].Err)
+	})
+
+	t.Run("csv removes the formula escape added by export", func(t *testing.T) {
+		data := "name,email\n'=Formula User,formula@example.com\n'Quoted User,quoted@example.com\n"
+
+		rows, err := ParseImport(strings.NewReader(data), ExportFormatCSV)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+
+		assert.Equal(t, "=Formula User", rows[0].Request.Name)
+		assert.Equal(t, "'Quoted User", rows[1].Request.Name)
+	})
+
+	t.Run("csv without required columns", func(t *testing.T) {
+		_, err := ParseImport(strings.NewReader("id,name\n1,User\n"), ExportFormatCSV)
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("ndjson skips blank lines and reports bad objects", func(t *testing.T) {
+		data := `{"name":"User One","email":"one@example.com","id":9}` + "\n\n" + `{"name":` + "\n"
+
+		rows, err := ParseImport(strings.NewReader(data), ExportFormatNDJSON)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+
+		assert.Equal(t, ImportRow{Line: 1, Request: CreateUserRequest{Name: "User One", Email: "one@example.com"}}, rows[0])
+		assert.Equal(t, 3, rows[1].Line)
+		assert.Error(t, rows[1].Err)
+	})
+}
@@ -1,9 +1,15 @@
 package user
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/crud"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/listquery"
 )
 
 // Status 사용자 상태 열거형 / User status enumeration
@@ -30,13 +36,26 @@ func (s Status) IsValid() bool {
 
 // User 사용자 모델 / User model
 type User struct {
-	ID        uint           `json:"id" gorm:"primarykey"`
-	Name      string         `json:"name" gorm:"not null;size:100" validate:"required,min=2,max=100"`
-	Email     string         `json:"email" gorm:"uniqueIndex;not null;size:255" validate:"required,email"`
-	Status    Status         `json:"status" gorm:"not null;default:'active'" validate:"required,oneof=active inactive suspended"`
+	ID      uint   `json:"id" gorm:"primarykey"`
+	Name    string `json:"name" gorm:"not null;size:100" validate:"required,min=2,max=100"`
+	Email   string `json:"email" gorm:"uniqueIndex;not null;size:255" validate:"required,email"`
+	Status  Status `json:"status" gorm:"not null;default:'active'" validate:"required,oneof=active inactive suspended"`
+	Version uint   `json:"version" gorm:"not null;default:1"`
+
+	// 마지막 상태 전이 기록 / Record of the last status transition
+	StatusReason    string     `json:"status_reason,omitempty" gorm:"not null;default:'';size:500"`
+	StatusChangedBy string     `json:"status_changed_by,omitempty" gorm:"not null;default:'';size:100"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+	SuspendedUntil  *time.Time `json:"suspended_until,omitempty" gorm:"index"`
+
+	// PasswordHash argon2id PHC 문자열, 비어 있으면 로그인 불가 / argon2id PHC string; empty means the user cannot log in
+	PasswordHash string `json:"-" gorm:"not null;default:'';size:255"`
+	// PasswordChangedAt 마지막 비밀번호 변경 시각, 이전에 시작된 세션은 무효 / Last password change; sessions started earlier are invalid
+	PasswordChangedAt *time.Time `json:"-"`
+
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
 // TableName 테이블 이름 지정 / Specify table name
@@ -50,6 +69,10 @@ func (u *User) BeforeCreate(_ *gorm.DB) error {
 	if u.Status == "" {
 		u.Status = StatusActive
 	}
+	// 낙관적 동시성 제어용 초기 버전 / Initial version for optimistic concurrency control
+	if u.Version == 0 {
+		u.Version = 1
+	}
 	return nil
 }
 
@@ -60,6 +83,25 @@ type CreateUserRequest struct {
 	Status Status `json:"status,omitempty" validate:"omitempty,oneof=active inactive suspended"`
 }
 
+// 비밀번호 길이 제한 / Password length limits
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 128
+)
+
+// SetPasswordRequest 비밀번호 설정 요청 / Password set request
+type SetPasswordRequest struct {
+	Password string `json:"password" validate:"required,min=8,max=128"`
+}
+
+// Validate 비밀번호 길이 검증 / Validate password length
+func (r *SetPasswordRequest) Validate() error {
+	if len(r.Password) < minPasswordLength || len(r.Password) > maxPasswordLength {
+		return &ValidationError{Message: fmt.Sprintf("Password must be between %d and %d characters", minPasswordLength, maxPasswordLength)}
+	}
+	return nil
+}
+
 // UpdateUserRequest 사용자 업데이트 요청 구조체 / User update request structure
 type UpdateUserRequest struct {
 	Name   *string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
@@ -67,12 +109,110 @@ type UpdateUserRequest struct {
 	Status *Status `json:"status,omitempty" validate:"omitempty,oneof=active inactive suspended"`
 }
 
+// BatchMode 일괄 처리 모드 / Batch processing mode
+type BatchMode string
+
+const (
+	// BatchModeAtomic applies all items in one transaction and rolls back on the first failure.
+	BatchModeAtomic BatchMode = "atomic"
+	// BatchModePartial applies each item independently and keeps the ones that succeed.
+	BatchModePartial BatchMode = "partial"
+)
+
+// MaxBatchItems 일괄 요청당 최대 항목 수 / Maximum items per batch request
+const MaxBatchItems = 100
+
+// IsValid reports whether the mode is a supported batch mode.
+func (m BatchMode) IsValid() bool {
+	return m == BatchModeAtomic || m == BatchModePartial
+}
+
+// BatchCreateRequest 사용자 일괄 생성 요청 / Batch user creation request
+type BatchCreateRequest struct {
+	Mode  BatchMode           `json:"mode,omitempty"`
+	Items []CreateUserRequest `json:"items"`
+}
+
+// BatchUpdateItem 일괄 업데이트 항목 / Batch update item
+// Version이 0이 아니면 If-Match처럼 동작 / A non-zero Version acts like If-Match
+type BatchUpdateItem struct {
+	ID      uint `json:"id"`
+	Version uint `json:"version,omitempty"`
+	UpdateUserRequest
+}
+
+// BatchUpdateRequest 사용자 일괄 업데이트 요청 / Batch user update request
+type BatchUpdateRequest struct {
+	Mode  BatchMode         `json:"mode,omitempty"`
+	Items []BatchUpdateItem `json:"items"`
+}
+
+// BatchDeleteItem 일괄 삭제 항목 / Batch delete item
+type BatchDeleteItem struct {
+	ID      uint `json:"id"`
+	Version uint `json:"version,omitempty"`
+}
+
+// BatchDeleteRequest 사용자 일괄 삭제 요청 / Batch user delete request
+type BatchDeleteRequest struct {
+	Mode  BatchMode         `json:"mode,omitempty"`
+	Items []BatchDeleteItem `json:"items"`
+}
+
+// BatchItemResult 일괄 처리 항목별 결과 / Per-item batch result
+type BatchItemResult struct {
+	Index int
+	User  *User
+	Err   error
+}
+
 // ListUsersQuery 사용자 목록 조회 쿼리 구조체 / User list query structure
 type ListUsersQuery struct {
 	Offset int    `query:"offset" validate:"min=0"`
 	Limit  int    `query:"limit" validate:"min=1,max=100"`
 	Status Status `query:"status" validate:"omitempty,oneof=active inactive suspended"`
 	Search string `query:"search" validate:"omitempty,max=100"`
+	Cursor string `query:"cursor" validate:"omitempty"`
+	Sort   string `query:"sort" validate:"omitempty"`
+
+	// IncludeDeleted 소프트 삭제된 사용자 포함 / Include soft-deleted users
+	IncludeDeleted bool `query:"include_deleted"`
+	// OnlyDeleted 소프트 삭제된 사용자만 조회 / Return only soft-deleted users
+	OnlyDeleted bool `query:"only_deleted"`
+
+	// Filter "field[op]=value" 형식의 구조화된 필터 / Structured "field[op]=value" filters
+	Filter listquery.Filter `query:"-"`
+}
+
+// FilterableFields 필터 가능한 사용자 컬럼 정의 / Filterable user column definitions
+var FilterableFields = listquery.Schema{
+	"id":         {Type: listquery.TypeInt, Ops: slices.Concat(listquery.RangeOps, []listquery.Operator{listquery.OpIn, listquery.OpNin})},
+	"name":       {Type: listquery.TypeString, Ops: listquery.TextOps},
+	"email":      {Type: listquery.TypeString, Ops: listquery.TextOps},
+	"status":     {Type: listquery.TypeString, Ops: listquery.EqualityOps, Validate: validateStatusFilter},
+	"created_at": {Type: listquery.TypeTime, Ops: listquery.RangeOps},
+	"updated_at": {Type: listquery.TypeTime, Ops: listquery.RangeOps},
+}
+
+func validateStatusFilter(value string) error {
+	if !Status(value).IsValid() {
+		return ErrInvalidStatus
+	}
+	return nil
+}
+
+// SortableFields 정렬 가능한 사용자 컬럼 허용 목록 / Allowlist of sortable user columns
+var SortableFields = []string{"id", "name", "email", "status", "created_at", "updated_at"}
+
+// defaultSort 기본 정렬 (최신순) / Default sort (newest first)
+const defaultSort = "-created_at"
+
+// listOptions 범용 저장소와 핸들러의 사용자 설정 / User settings for the generic repository and handler
+var listOptions = crud.Options{
+	Name:           "user",
+	Filters:        FilterableFields,
+	SortableFields: SortableFields,
+	DefaultSort:    defaultSort,
 }
 
 // Validate 쿼리 파라미터 검증 및 기본값 설정 / Validate query parameters and set defaults
@@ -85,6 +225,74 @@ func (q *ListUsersQuery) Validate() {
 	}
 }
 
+// IsCursorMode 커서 기반 페이지네이션 여부 / Report whether keyset pagination is requested
+func (q *ListUsersQuery) IsCursorMode() bool {
+	return q.Cursor != ""
+}
+
+// IsDefaultSort 기본 정렬 사용 여부 / Report whether the default sort is used
+// 커서는 (created_at, id) 순서에서만 유효 / Cursors are only valid for the (created_at, id) order
+func (q *ListUsersQuery) IsDefaultSort() bool {
+	return q.Sort == "" || q.Sort == defaultSort
+}
+
+// SortFields 정렬 식 파싱 (id 동순위 해소 포함) / Parse sort expression including the id tie-breaker
+func (q *ListUsersQuery) SortFields() ([]listquery.SortField, error) {
+	return listOptions.SortFields(q.Sort)
+}
+
+// scope 삭제 범위, 상태, 검색 조건 적용 / Apply the deletion scope, status, and search conditions
+func (q *ListUsersQuery) scope(db *gorm.DB) *gorm.DB {
+	// 소프트 삭제된 사용자 포함 여부 / Whether to include soft-deleted users
+	if q.IncludeDeleted || q.OnlyDeleted {
+		db = db.Unscoped()
+	}
+	if q.OnlyDeleted {
+		db = db.Where("deleted_at IS NOT NULL")
+	}
+
+	// 상태 필터링 / Status filtering
+	if q.Status != "" {
+		db = db.Where("status = ?", q.Status)
+	}
+
+	// 검색 필터링 (이름 또는 이메일) / Search filtering (name or email)
+	if q.Search != "" {
+		searchTerm := "%" + strings.ToLower(q.Search) + "%"
+		db = db.Where("LOWER(name) LIKE ? OR LOWER(email) LIKE ?", searchTerm, searchTerm)
+	}
+	return db
+}
+
+// DecodeCursor 커서 파라미터 디코딩 / Decode the cursor parameter
+func (q *ListUsersQuery) DecodeCursor() (listquery.Cursor, error) {
+	return listquery.DecodeCursor(q.Cursor)
+}
+
+// NextCursor 다음 페이지 커서 생성 / Build the cursor for the next page
+// 기본 정렬에서 페이지가 가득 찬 경우에만 반환 / Returned only for full pages in the default sort
+func (q *ListUsersQuery) NextCursor(users []*User) string {
+	if !q.IsDefaultSort() || q.Limit <= 0 || len(users) < q.Limit {
+		return ""
+	}
+	last := users[len(users)-1]
+	return listquery.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+}
+
+// statusChangeError 전이 엔드포인트를 안내하는 검증 오류 / Validation error pointing at the transition endpoints
+func statusChangeError() error {
+	return &ValidationError{Message: statusChangeMessage, Err: ErrStatusChangeNotAllowed}
+}
+
+// checkInitialStatus 새 사용자는 active로만 시작, 다른 상태는 사유가 기록되는 전이로 변경
+// New users only start active; other statuses are reached through transitions, which record a reason
+func (r *CreateUserRequest) checkInitialStatus() error {
+	if r.Status != "" && r.Status != StatusActive {
+		return &ValidationError{Message: initialStatusMessage, Err: ErrInitialStatus}
+	}
+	return nil
+}
+
 // ToUser CreateUserRequest를 User 모델로 변환 / Convert CreateUserRequest to User model
 func (r *CreateUserRequest) ToUser() *User {
 	user := &User{
@@ -101,15 +309,49 @@ func (r *CreateUserRequest) ToUser() *User {
 	return user
 }
 
+// Validate 생성 요청 필드 검증 / Validate creation request fields
+func (r *CreateUserRequest) Validate() error {
+	if r.Name == "" {
+		return &ValidationError{Message: "Name is required"}
+	}
+	if r.Email == "" {
+		return &ValidationError{Message: "Email is required"}
+	}
+	if len(r.Name) < 2 || len(r.Name) > 100 {
+		return &ValidationError{Message: "Name must be between 2 and 100 characters"}
+	}
+	if r.Status != "" && !r.Status.IsValid() {
+		return &ValidationError{Message: statusValidationMessage, Err: ErrInvalidStatus}
+	}
+	return nil
+}
+
+// Validate 업데이트 요청 필드 검증 / Validate update request fields
+func (r *UpdateUserRequest) Validate() error {
+	if r.Name != nil && (*r.Name == "" || len(*r.Name) < 2 || len(*r.Name) > 100) {
+		return &ValidationError{Message: "Name must be between 2 and 100 characters"}
+	}
+	if r.Email != nil && *r.Email == "" {
+		return &ValidationError{Message: "Email cannot be empty"}
+	}
+	if r.Status != nil && !r.Status.IsValid() {
+		return &ValidationError{Message: statusValidationMessage, Err: ErrInvalidStatus}
+	}
+	return nil
+}
+
 // ApplyTo UpdateUserRequest를 기존 User 모델에 적용 / Apply UpdateUserRequest to existing User model
-func (r *UpdateUserRequest) ApplyTo(user *User) {
+// 현재와 같은 상태만 허용, 상태 변경은 사유와 주체를 남기는 전이 엔드포인트로만 가능
+// Only the current status is accepted; status changes go through the transition endpoints, which record a reason and an actor
+func (r *UpdateUserRequest) ApplyTo(user *User) error {
+	if r.Status != nil && *r.Status != user.Status {
+		return statusChangeError()
+	}
 	if r.Name != nil {
 		user.Name = *r.Name
 	}
 	if r.Email != nil {
 		user.Email = *r.Email
 	}
-	if r.Status != nil {
-		user.Status = *r.Status
-	}
+	return nil
 }
@@ -1,9 +1,12 @@
 package user
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStatus_IsValid(t *testing.T) {
@@ -25,3 +28,59 @@ func TestStatus_IsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestStatus_CanTransitionTo(t *testing.T) {
+	testCases := []struct {
+		from Status
+		to   Status
+		want bool
+	}{
+		{from: StatusActive, to: StatusInactive, want: true},
+		{from: StatusActive, to: StatusSuspended, want: true},
+		{from: StatusActive, to: StatusActive, want: false},
+		{from: StatusInactive, to: StatusActive, want: true},
+		{from: StatusInactive, to: StatusSuspended, want: false},
+		{from: StatusSuspended, to: StatusActive, want: true},
+		{from: StatusSuspended, to: StatusInactive, want: true},
+		{from: Status("pending"), to: StatusActive, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.from)+"_to_"+string(tc.to), func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.from.CanTransitionTo(tc.to))
+		})
+	}
+}
+
+func TestTransitionRequest_Validate(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+
+	testCases := []struct {
+		name    string
+		target  Status
+		request TransitionRequest
+		wantErr string
+	}{
+		{name: "reason only", target: StatusInactive, request: TransitionRequest{Reason: "left the company"}},
+		{name: "suspend with expiry", target: StatusSuspended, request: TransitionRequest{Reason: "abuse", Until: &future}},
+		{name: "missing reason", target: StatusActive, wantErr: "Reason is required"},
+		{name: "long reason", target: StatusActive, request: TransitionRequest{Reason: strings.Repeat("r", maxTransitionReasonLength+1)}, wantErr: "Reason must be at most 500 characters"},
+		{name: "expiry outside suspend", target: StatusInactive, request: TransitionRequest{Reason: "x", Until: &future}, wantErr: "Until is only allowed when suspending"},
+		{name: "expiry in the past", target: StatusSuspended, request: TransitionRequest{Reason: "x", Until: &past}, wantErr: "Until must be in the future"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.request.Validate(tc.target, now)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tc.wantErr, validationErr.Message)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/middleware"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/module"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/scheduler"
+)
+
+// streamBatchSize 스트림 피드의 폴링당 최대 메시지 수 / Maximum messages per stream feed poll
+const streamBatchSize = 500
+
+// reactivatedTotal 정지 만료로 재활성화된 사용자 수 / Users reactivated after their suspension expired
+var reactivatedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "spindle",
+	Subsystem: "users",
+	Name:      "reactivated_total",
+	Help:      "Total number of users reactivated after their suspension expired",
+})
+
+func init() {
+	module.Register(module.Registration{Name: "user", EventTypes: EventTypes(), New: NewModule})
+}
+
+// Module 사용자 도메인 모듈 / User domain module
+type Module struct {
+	module.Base
+	deps    module.Deps
+	service Service
+	handler *Handler
+}
+
+// NewModule 새 사용자 모듈 생성 / Create new user module
+func NewModule(deps module.Deps) module.Module {
+	cfg := deps.Config
+	service := NewService(NewRepository(deps.DB), deps.Tx, deps.Passwords)
+	handler := NewHandler(service, &Stream{
+		Feed: outbox.NewFeed(outbox.NewStore(deps.DB), outbox.FeedConfig{
+			PollInterval:   cfg.StreamPollInterval,
+			GapGrace:       cfg.StreamGapGrace,
+			BatchSize:      streamBatchSize,
+			BufferSize:     cfg.StreamBufferSize,
+			MaxSubscribers: cfg.StreamMaxClients,
+		}),
+		Heartbeat:    cfg.StreamHeartbeat,
+		WriteTimeout: cfg.StreamWriteTimeout,
+	})
+	return &Module{deps: deps, service: service, handler: handler}
+}
+
+// Name 모듈 이름 / Module name
+func (m *Module) Name() string { return "user" }
+
+// Models 사용자 모델 / User models
+func (m *Module) Models() []any { return []any{&User{}} }
+
+// RegisterRoutes 사용자 라우트 등록 / Register user routes
+func (m *Module) RegisterRoutes(v1 fiber.Router) {
+	h := m.handler
+
+	// 읽기, 쓰기, 삭제 범위 / Read, write, and delete scopes
+	read := middleware.RequireScopes("users:read")
+	write := middleware.RequireScopes("users:write")
+	remove := middleware.RequireScopes("users:delete")
+
+	// export와 stream은 핸들러 반환 후 스트리밍하므로 마감 시간 제외 / export and stream are exempt from the deadline because they stream after the handler returns
+	users := v1.Group("/users")
+	users.Get("/", read, h.List)                                  // GET /v1/users
+	users.Get("/export", read, middleware.NoDeadline(), h.Export) // GET /v1/users/export (/:id 보다 먼저 등록 / registered before /:id)
+	users.Get("/stream", read, middleware.NoDeadline(), h.Stream) // GET /v1/users/stream (SSE)
+	users.Get("/:id", read, h.GetByID)                            // GET /v1/users/:id
+	users.Post("/", write, h.Create)                              // POST /v1/users
+	users.Post("/import", write, h.Import)                        // POST /v1/users/import
+	users.Put("/:id", write, h.Update)                            // PUT /v1/users/:id
+	users.Patch("/:id", write, h.Patch)                           // PATCH /v1/users/:id
+	users.Delete("/:id", remove, h.Delete)                        // DELETE /v1/users/:id (?hard=true 영구 삭제 / permanent delete)
+	users.Post("/:id/restore", write, h.Restore)                  // POST /v1/users/:id/restore
+	users.Get("/:id/history", read, h.History)                    // GET /v1/users/:id/history
+	users.Put("/:id/password", write, h.SetPassword)              // PUT /v1/users/:id/password
+
+	// 상태 전이 라우트 / Status transition routes
+	users.Post("/:id/activate", write, h.Activate)     // POST /v1/users/:id/activate
+	users.Post("/:id/deactivate", write, h.Deactivate) // POST /v1/users/:id/deactivate
+	users.Post("/:id/suspend", write, h.Suspend)       // POST /v1/users/:id/suspend
+
+	// 일괄 처리 라우트 (콜론은 이스케이프) / Batch routes (colons are escaped)
+	v1.Post("/users\\:batch", write, h.BatchCreate)        // POST /v1/users:batch
+	v1.Post("/users\\:batchUpdate", write, h.BatchUpdate)  // POST /v1/users:batchUpdate
+	v1.Post("/users\\:batchDelete", remove, h.BatchDelete) // POST /v1/users:batchDelete
+}
+
+// Collectors 사용자 메트릭 / User metrics
+func (m *Module) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{reactivatedTotal}
+}
+
+// Jobs 정지 만료 재활성화 작업 / Expired suspension reactivation job
+func (m *Module) Jobs() []scheduler.Job {
+	cfg := m.deps.Config
+	return []scheduler.Job{{
+		Name:     "user.reactivate-expired-suspensions",
+		Interval: cfg.SuspensionSweepInterval,
+		Run: func(ctx context.Context) error {
+			_, err := m.service.ReactivateExpired(ctx, time.Now(), cfg.SuspensionSweepBatchSize)
+			return err
+		},
+	}}
+}
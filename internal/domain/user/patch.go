@@ -0,0 +1,139 @@
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/jsonpatch"
+)
+
+// patchDocument PATCH로 바꿀 수 있는 필드 / Fields a PATCH can change
+// 패치는 직렬화된 사용자 전체에 적용되므로 test 연산은 읽기 전용 필드도 사용 가능
+// Patches apply to the whole serialized user, so test operations can use read-only fields too
+type patchDocument struct {
+	Name   *string `json:"name"`
+	Email  *string `json:"email"`
+	Status *Status `json:"status"`
+}
+
+// writableFields patchDocument의 JSON 이름 / JSON names of patchDocument
+var writableFields = jsonFields(reflect.TypeFor[patchDocument]())
+
+// userFields 직렬화된 사용자의 모든 JSON 이름, omitempty로 빠진 필드 포함 / Every JSON name of a serialized user, including ones omitempty dropped
+var userFields = jsonFields(reflect.TypeFor[User]())
+
+// PatchToUpdateRequest 패치를 사용자에 적용하여 업데이트 요청으로 변환 / Apply a patch to a user and convert it to an update request
+// contentType은 jsonpatch.MergePatchContentType 또는 jsonpatch.JSONPatchContentType / contentType is a jsonpatch media type
+func PatchToUpdateRequest(user *User, contentType string, patch []byte) (*UpdateUserRequest, error) {
+	original, err := json.Marshal(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode user for patch: %w", err)
+	}
+
+	var patched []byte
+	switch contentType {
+	case jsonpatch.MergePatchContentType:
+		patched, err = jsonpatch.MergePatch(original, patch)
+	case jsonpatch.JSONPatchContentType:
+		patched, err = jsonpatch.Apply(original, patch)
+	default:
+		return nil, fmt.Errorf("%w: unsupported content type %q", jsonpatch.ErrInvalidPatch, contentType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkReadOnly(original, patched); err != nil {
+		return nil, err
+	}
+
+	var after patchDocument
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return nil, fmt.Errorf("%w: %v", jsonpatch.ErrInvalidPatch, err)
+	}
+
+	// 제거된 필수 필드는 빈 값으로 전달되어 검증에서 거부됨 / Removed required fields become empty and fail validation
+	req := &UpdateUserRequest{}
+	if after.Name == nil || *after.Name != user.Name {
+		req.Name = valueOrEmpty(after.Name)
+	}
+	if after.Email == nil || *after.Email != user.Email {
+		req.Email = valueOrEmpty(after.Email)
+	}
+	if after.Status == nil || *after.Status != user.Status {
+		status := Status("")
+		if after.Status != nil {
+			status = *after.Status
+		}
+		req.Status = &status
+	}
+
+	return req, nil
+}
+
+// checkReadOnly 쓰기 가능 필드 외의 추가, 변경, 삭제 거부 / Reject adding, changing, or removing anything but the writable fields
+// 사용자 필드는 ErrReadOnlyField, 없는 필드는 ErrUnknownField / ErrReadOnlyField for user fields, ErrUnknownField for anything else
+func checkReadOnly(original, patched []byte) error {
+	var before, after map[string]json.RawMessage
+	if err := json.Unmarshal(original, &before); err != nil {
+		return fmt.Errorf("failed to decode user for patch: %w", err)
+	}
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return fmt.Errorf("%w: %v", jsonpatch.ErrInvalidPatch, err)
+	}
+
+	keys := slices.Sorted(maps.Keys(before))
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		if slices.Contains(writableFields, key) {
+			continue
+		}
+		if !slices.Contains(userFields, key) {
+			return fmt.Errorf("%w: %q", ErrUnknownField, key)
+		}
+		if !jsonEqual(before[key], after[key]) {
+			return fmt.Errorf("%w: %q", ErrReadOnlyField, key)
+		}
+	}
+	return nil
+}
+
+// jsonEqual 두 JSON 값이 같은지 비교, 없는 값은 없는 값과만 같음 / Compare two JSON values; a missing value only equals another missing one
+func jsonEqual(a, b json.RawMessage) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	var x, y any
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// jsonFields 구조체 필드의 JSON 이름 / JSON names of a struct's fields
+func jsonFields(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func valueOrEmpty(value *string) *string {
+	if value == nil {
+		empty := ""
+		return &empty
+	}
+	return value
+}
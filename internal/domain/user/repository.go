@@ -1,45 +1,69 @@
 package user
 
 import (
+	"context"
 	"fmt"
-	"strings"
+	"time"
 
 	"gorm.io/gorm"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/audit"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/crud"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/listquery"
 )
 
+// createBatchSize INSERT 문당 최대 행 수 / Maximum rows per INSERT statement
+const createBatchSize = 100
+
 // Repository 사용자 저장소 인터페이스 / User repository interface
+// 모든 메서드는 ctx에 실린 db.TxManager 트랜잭션에 자동으로 참여 / Every method joins the db.TxManager transaction carried by ctx
 type Repository interface {
-	Create(user *User) error
-	GetByID(id uint) (*User, error)
-	GetByEmail(email string) (*User, error)
-	Update(user *User) error
-	Delete(id uint) error
-	List(query *ListUsersQuery) ([]*User, int64, error)
-	Exists(id uint) (bool, error)
+	Create(ctx context.Context, user *User) error
+	GetByID(ctx context.Context, id uint) (*User, error)
+	// GetByIDUnscoped 소프트 삭제된 사용자 포함 조회 / Get a user including soft-deleted ones
+	GetByIDUnscoped(ctx context.Context, id uint) (*User, error)
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	GetByEmails(ctx context.Context, emails []string) ([]*User, error)
+	CreateBatch(ctx context.Context, users []*User) error
+	Update(ctx context.Context, user *User) error
+	// UpdatePasswordHash 버전을 올리지 않고 해시만 교체, 로그인 시 재해시용 / Replace only the hash without bumping the version; used to rehash on login
+	UpdatePasswordHash(ctx context.Context, id uint, hash string) error
+	Delete(ctx context.Context, id uint, version uint) error
+	Restore(ctx context.Context, id uint, version uint) error
+	HardDelete(ctx context.Context, id uint, version uint) error
+	// PurgeDeleted 해당 이메일의 소프트 삭제된 사용자를 영구 삭제하고 삭제된 사용자 반환
+	// Permanently delete soft-deleted users holding these emails and return the deleted users
+	PurgeDeleted(ctx context.Context, emails []string) ([]*User, error)
+	// List 목록과 전체 개수, 커서 모드에서는 개수를 세지 않아 0 / Page and total count; the total is 0 in cursor mode, which skips the count
+	List(ctx context.Context, query *ListUsersQuery) ([]*User, int64, error)
+	// ListExpiredSuspensions 정지 기한이 지난 사용자 조회 / List suspended users whose suspension has expired
+	ListExpiredSuspensions(ctx context.Context, now time.Time, limit int) ([]*User, error)
+	Exists(ctx context.Context, id uint) (bool, error)
+	// Export 필터와 일치하는 사용자를 배치 단위로 fn에 전달 / Pass users matching the filters to fn in batches
+	Export(ctx context.Context, query *ListUsersQuery, batchSize int, fn func(users []*User) error) error
+	// Audit ctx의 트랜잭션에 참여하는 감사 이벤트 저장소 / Audit event store joining the transaction carried by ctx
+	Audit(ctx context.Context) audit.Store
+	// Outbox ctx의 트랜잭션에 참여하는 아웃박스 저장소 / Outbox store joining the transaction carried by ctx
+	Outbox(ctx context.Context) outbox.Store
 }
 
 // repository 사용자 저장소 구현체 / User repository implementation
+// Create, GetByID는 범용 저장소를 그대로 사용하고 버전 검사가 필요한 쓰기와 목록은 재정의
+// Create and GetByID come from the generic repository; versioned writes and listing are overridden
 type repository struct {
-	db *gorm.DB
+	crud.Repository[User, uint]
 }
 
 // NewRepository 새 사용자 저장소 생성 / Create new user repository
 func NewRepository(db *gorm.DB) Repository {
-	return &repository{db: db}
-}
-
-// Create 사용자 생성 / Create user
-func (r *repository) Create(user *User) error {
-	if err := r.db.Create(user).Error; err != nil {
-		return fmt.Errorf("failed to create user: %w", err)
-	}
-	return nil
+	return &repository{Repository: crud.NewRepository[User, uint](db, listOptions)}
 }
 
-// GetByID ID로 사용자 조회 / Get user by ID
-func (r *repository) GetByID(id uint) (*User, error) {
+// GetByIDUnscoped 소프트 삭제 포함 ID로 사용자 조회 / Get user by ID including soft-deleted users
+func (r *repository) GetByIDUnscoped(ctx context.Context, id uint) (*User, error) {
 	var user User
-	if err := r.db.First(&user, id).Error; err != nil {
+	if err := r.Conn(ctx).Unscoped().First(&user, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("user not found with id %d: %w", id, err)
 		}
@@ -49,9 +73,9 @@ func (r *repository) GetByID(id uint) (*User, error) {
 }
 
 // GetByEmail 이메일로 사용자 조회 / Get user by email
-func (r *repository) GetByEmail(email string) (*User, error) {
+func (r *repository) GetByEmail(ctx context.Context, email string) (*User, error) {
 	var user User
-	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+	if err := r.Conn(ctx).Where("email = ?", email).First(&user).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("user not found with email %s: %w", email, err)
 		}
@@ -60,74 +84,217 @@ func (r *repository) GetByEmail(email string) (*User, error) {
 	return &user, nil
 }
 
+// GetByEmails 여러 이메일로 사용자 조회 / Get users by several emails
+func (r *repository) GetByEmails(ctx context.Context, emails []string) ([]*User, error) {
+	var users []*User
+	if len(emails) == 0 {
+		return users, nil
+	}
+	if err := r.Conn(ctx).Where("email IN ?", emails).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to get users by email: %w", err)
+	}
+	return users, nil
+}
+
+// CreateBatch 사용자 일괄 생성 / Create users in bulk
+func (r *repository) CreateBatch(ctx context.Context, users []*User) error {
+	if len(users) == 0 {
+		return nil
+	}
+	if err := r.Conn(ctx).CreateInBatches(users, createBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to create users: %w", err)
+	}
+	return nil
+}
+
 // Update 사용자 업데이트 / Update user
-func (r *repository) Update(user *User) error {
-	if err := r.db.Save(user).Error; err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
+// 읽은 버전과 일치할 때만 저장하고 버전을 증가 / Saves only if the read version still matches, then bumps it
+func (r *repository) Update(ctx context.Context, user *User) error {
+	expected := user.Version
+	user.Version = expected + 1
+
+	result := r.Conn(ctx).Model(user).
+		Where("version = ?", expected).
+		Select("*").
+		Omit("id", "created_at", "deleted_at").
+		Updates(user)
+	if result.Error != nil {
+		user.Version = expected
+		return fmt.Errorf("failed to update user: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		user.Version = expected
+		return fmt.Errorf("failed to update user %d at version %d: %w", user.ID, expected, ErrVersionConflict)
+	}
+	return nil
+}
+
+// UpdatePasswordHash 비밀번호 해시 교체 / Replace password hash
+func (r *repository) UpdatePasswordHash(ctx context.Context, id uint, hash string) error {
+	if err := r.Conn(ctx).Model(&User{}).Where("id = ?", id).UpdateColumn("password_hash", hash).Error; err != nil {
+		return fmt.Errorf("failed to update password hash: %w", err)
 	}
 	return nil
 }
 
 // Delete 사용자 삭제 (소프트 삭제) / Delete user (soft delete)
-func (r *repository) Delete(id uint) error {
-	if err := r.db.Delete(&User{}, id).Error; err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
+// version이 0이 아니면 해당 버전일 때만 삭제 / When version is non-zero, deletes only at that version
+func (r *repository) Delete(ctx context.Context, id uint, version uint) error {
+	db := r.Conn(ctx)
+	if version != 0 {
+		db = db.Where("version = ?", version)
+	}
+
+	result := db.Delete(&User{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete user: %w", result.Error)
+	}
+	if version != 0 && result.RowsAffected == 0 {
+		return fmt.Errorf("failed to delete user %d at version %d: %w", id, version, ErrVersionConflict)
 	}
 	return nil
 }
 
-// List 사용자 목록 조회 / List users
-func (r *repository) List(query *ListUsersQuery) ([]*User, int64, error) {
+// Restore 소프트 삭제된 사용자 복원 / Restore a soft-deleted user
+// 해당 버전의 삭제된 행만 복원하고 버전을 증가 / Restores only a deleted row at that version, then bumps it
+func (r *repository) Restore(ctx context.Context, id uint, version uint) error {
+	result := r.Conn(ctx).Unscoped().Model(&User{}).
+		Where("id = ? AND version = ? AND deleted_at IS NOT NULL", id, version).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore user: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to restore user %d at version %d: %w", id, version, ErrVersionConflict)
+	}
+	return nil
+}
+
+// HardDelete 사용자 영구 삭제 / Permanently delete a user
+// version이 0이 아니면 해당 버전일 때만 삭제 / When version is non-zero, deletes only at that version
+func (r *repository) HardDelete(ctx context.Context, id uint, version uint) error {
+	db := r.Conn(ctx).Unscoped()
+	if version != 0 {
+		db = db.Where("version = ?", version)
+	}
+
+	result := db.Delete(&User{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to hard delete user: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to hard delete user %d at version %d: %w", id, version, ErrVersionConflict)
+	}
+	return nil
+}
+
+// PurgeDeleted 소프트 삭제된 사용자의 이메일 해제 / Release emails held by soft-deleted users
+// 조회와 삭제 사이에 복원된 사용자가 있으면 ErrVersionConflict / ErrVersionConflict when a user is restored between the read and the delete
+func (r *repository) PurgeDeleted(ctx context.Context, emails []string) ([]*User, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
 	var users []*User
-	var total int64
+	if err := r.Conn(ctx).Unscoped().Where("email IN ? AND deleted_at IS NOT NULL", emails).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to find deleted users: %w", err)
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
 
-	// 기본 쿼리 / Base query
-	db := r.db.Model(&User{})
+	ids := make([]uint, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	result := r.Conn(ctx).Unscoped().Where("id IN ? AND deleted_at IS NOT NULL", ids).Delete(&User{})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to purge deleted users: %w", result.Error)
+	}
+	if result.RowsAffected != int64(len(users)) {
+		return nil, fmt.Errorf("failed to purge deleted users: %w", ErrVersionConflict)
+	}
+	return users, nil
+}
 
-	// 상태 필터링 / Status filtering
-	if query.Status != "" {
-		db = db.Where("status = ?", query.Status)
+// List 사용자 목록 조회 / List users
+func (r *repository) List(ctx context.Context, query *ListUsersQuery) ([]*User, int64, error) {
+	q := &crud.Query{
+		Offset: query.Offset,
+		Limit:  query.Limit,
+		Sort:   query.Sort,
+		Filter: query.Filter,
+		Scope:  query.scope,
 	}
 
-	// 검색 필터링 (이름 또는 이메일) / Search filtering (name or email)
-	if query.Search != "" {
-		searchTerm := "%" + strings.ToLower(query.Search) + "%"
-		db = db.Where("LOWER(name) LIKE ? OR LOWER(email) LIKE ?", searchTerm, searchTerm)
+	// 커서가 있으면 오프셋 대신 키셋 조건 / Keyset condition instead of the offset when a cursor is given
+	if query.IsCursorMode() {
+		cursor, err := query.DecodeCursor()
+		if err != nil {
+			return nil, 0, err
+		}
+		q.Seek = func(db *gorm.DB) *gorm.DB {
+			return db.Where("created_at < ? OR (created_at = ? AND id < ?)", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+		}
 	}
 
-	// 총 개수 조회 / Get total count
-	if err := db.Count(&total).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	return r.Repository.List(ctx, q)
+}
+
+// Export 사용자 배치 스트리밍 (기본 키 순서) / Stream users in batches (primary key order)
+// 배치 크기만큼만 메모리에 유지 / Only one batch is held in memory at a time
+func (r *repository) Export(ctx context.Context, query *ListUsersQuery, batchSize int, fn func(users []*User) error) error {
+	var batch []*User
+	result := r.filtered(ctx, query).FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+		return fn(batch)
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to export users: %w", result.Error)
 	}
+	return nil
+}
+
+// filtered 삭제 범위, 상태, 검색, 구조화된 필터 적용 / Apply deletion scope, status, search, and structured filters
+func (r *repository) filtered(ctx context.Context, query *ListUsersQuery) *gorm.DB {
+	return listquery.ApplyFilter(query.scope(r.Conn(ctx).Model(&User{})), query.Filter)
+}
 
-	// 페이지네이션과 정렬 적용 / Apply pagination and sorting
-	if err := db.Offset(query.Offset).
-		Limit(query.Limit).
-		Order("created_at DESC").
+// ListExpiredSuspensions 만료 시각 순으로 최대 limit명 조회 / List up to limit users in expiry order
+func (r *repository) ListExpiredSuspensions(ctx context.Context, now time.Time, limit int) ([]*User, error) {
+	var users []*User
+	if err := r.Conn(ctx).
+		Where("status = ? AND suspended_until IS NOT NULL AND suspended_until <= ?", StatusSuspended, now).
+		Order("suspended_until").Order("id").
+		Limit(limit).
 		Find(&users).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+		return nil, fmt.Errorf("failed to list expired suspensions: %w", err)
 	}
-
-	return users, total, nil
+	return users, nil
 }
 
 // Exists 사용자 존재 여부 확인 / Check if user exists
-func (r *repository) Exists(id uint) (bool, error) {
+func (r *repository) Exists(ctx context.Context, id uint) (bool, error) {
 	var count int64
-	if err := r.db.Model(&User{}).Where("id = ?", id).Count(&count).Error; err != nil {
+	if err := r.Conn(ctx).Model(&User{}).Where("id = ?", id).Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check user existence: %w", err)
 	}
 	return count > 0, nil
 }
 
-// WithTx 트랜잭션과 함께 저장소 반환 / Return repository with transaction
-func (r *repository) WithTx(tx *gorm.DB) Repository {
-	return &repository{db: tx}
+// Audit 감사 이벤트 저장소 반환 / Return the audit event store
+func (r *repository) Audit(ctx context.Context) audit.Store {
+	return audit.NewStore(r.Conn(ctx))
+}
+
+// Outbox 아웃박스 저장소 반환 / Return the outbox store
+func (r *repository) Outbox(ctx context.Context) outbox.Store {
+	return outbox.NewStore(r.Conn(ctx))
 }
 
 // 향후 확장 가능한 메서드들 / Future extensible methods
-// - BulkCreate: 대량 사용자 생성
-// - BulkUpdate: 대량 사용자 업데이트
 // - GetActiveUsers: 활성 사용자만 조회
 // - SearchByTags: 태그 기반 검색
 // - GetUserStats: 사용자 통계 정보
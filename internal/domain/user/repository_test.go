@@ -1,14 +1,22 @@
 package user
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/audit"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/listquery"
 )
 
 func setupTestDB(t testing.TB) *gorm.DB {
@@ -19,7 +27,7 @@ func setupTestDB(t testing.TB) *gorm.DB {
 	})
 	require.NoError(t, err)
 
-	err = database.AutoMigrate(&User{})
+	err = database.AutoMigrate(&User{}, &audit.Event{}, &outbox.Message{})
 	require.NoError(t, err)
 
 	return database
@@ -56,7 +64,7 @@ func TestRepository_Create(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := repo.Create(tc.user)
+			err := repo.Create(t.Context(), tc.user)
 			if tc.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -77,7 +85,7 @@ func TestRepository_GetByID(t *testing.T) {
 		Email:  "test@example.com",
 		Status: StatusActive,
 	}
-	err := repo.Create(testUser)
+	err := repo.Create(t.Context(), testUser)
 	require.NoError(t, err)
 
 	testCases := []struct {
@@ -99,7 +107,7 @@ func TestRepository_GetByID(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			user, err := repo.GetByID(tc.userID)
+			user, err := repo.GetByID(t.Context(), tc.userID)
 			if tc.wantErr {
 				assert.Error(t, err)
 				assert.Nil(t, user)
@@ -122,7 +130,7 @@ func TestRepository_GetByEmail(t *testing.T) {
 		Email:  "test@example.com",
 		Status: StatusActive,
 	}
-	err := repo.Create(testUser)
+	err := repo.Create(t.Context(), testUser)
 	require.NoError(t, err)
 
 	testCases := []struct {
@@ -144,7 +152,7 @@ func TestRepository_GetByEmail(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			user, err := repo.GetByEmail(tc.email)
+			user, err := repo.GetByEmail(t.Context(), tc.email)
 			if tc.wantErr {
 				assert.Error(t, err)
 				assert.Nil(t, user)
@@ -167,18 +175,18 @@ func TestRepository_Update(t *testing.T) {
 		Email:  "test@example.com",
 		Status: StatusActive,
 	}
-	err := repo.Create(testUser)
+	err := repo.Create(t.Context(), testUser)
 	require.NoError(t, err)
 
 	// Update the user
 	testUser.Name = "Updated Name"
 	testUser.Status = StatusInactive
 
-	err = repo.Update(testUser)
+	err = repo.Update(t.Context(), testUser)
 	assert.NoError(t, err)
 
 	// Verify the update
-	updatedUser, err := repo.GetByID(testUser.ID)
+	updatedUser, err := repo.GetByID(t.Context(), testUser.ID)
 	require.NoError(t, err)
 	assert.Equal(t, "Updated Name", updatedUser.Name)
 	assert.Equal(t, StatusInactive, updatedUser.Status)
@@ -194,18 +202,63 @@ func TestRepository_Delete(t *testing.T) {
 		Email:  "test@example.com",
 		Status: StatusActive,
 	}
-	err := repo.Create(testUser)
+	err := repo.Create(t.Context(), testUser)
 	require.NoError(t, err)
 
 	// Delete the user
-	err = repo.Delete(testUser.ID)
+	err = repo.Delete(t.Context(), testUser.ID, 0)
 	assert.NoError(t, err)
 
 	// Verify the user is deleted (soft delete)
-	_, err = repo.GetByID(testUser.ID)
+	_, err = repo.GetByID(t.Context(), testUser.ID)
 	assert.Error(t, err) // Should not be found due to soft delete
 }
 
+func TestRepository_UpdateVersionConflict(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+
+	testUser := &User{Name: "Test User", Email: "test@example.com", Status: StatusActive}
+	require.NoError(t, repo.Create(t.Context(), testUser))
+	assert.Equal(t, uint(1), testUser.Version)
+
+	// 두 편집자가 같은 버전을 읽음 / Two editors read the same version
+	first, err := repo.GetByID(t.Context(), testUser.ID)
+	require.NoError(t, err)
+	second, err := repo.GetByID(t.Context(), testUser.ID)
+	require.NoError(t, err)
+
+	first.Name = "First Editor"
+	require.NoError(t, repo.Update(t.Context(), first))
+	assert.Equal(t, uint(2), first.Version)
+
+	second.Name = "Second Editor"
+	err = repo.Update(t.Context(), second)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.Equal(t, uint(1), second.Version)
+
+	stored, err := repo.GetByID(t.Context(), testUser.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "First Editor", stored.Name)
+	assert.Equal(t, uint(2), stored.Version)
+}
+
+func TestRepository_DeleteWithVersion(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+
+	testUser := &User{Name: "Test User", Email: "test@example.com", Status: StatusActive}
+	require.NoError(t, repo.Create(t.Context(), testUser))
+
+	err := repo.Delete(t.Context(), testUser.ID, 5)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	require.NoError(t, repo.Delete(t.Context(), testUser.ID, testUser.Version))
+	exists, err := repo.Exists(t.Context(), testUser.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
 func TestRepository_List(t *testing.T) {
 	database := setupTestDB(t)
 	repo := NewRepository(database)
@@ -218,7 +271,7 @@ func TestRepository_List(t *testing.T) {
 	}
 
 	for _, user := range testUsers {
-		err := repo.Create(user)
+		err := repo.Create(t.Context(), user)
 		require.NoError(t, err)
 	}
 
@@ -256,7 +309,7 @@ func TestRepository_List(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			users, total, err := repo.List(tc.query)
+			users, total, err := repo.List(t.Context(), tc.query)
 			assert.NoError(t, err)
 			assert.GreaterOrEqual(t, len(users), tc.expectedMin)
 			assert.GreaterOrEqual(t, int(total), tc.expectedMin)
@@ -264,6 +317,198 @@ func TestRepository_List(t *testing.T) {
 	}
 }
 
+func TestRepository_ListWithFilter(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+
+	testUsers := []*User{
+		{Name: "User 1", Email: "one@corp.com", Status: StatusActive},
+		{Name: "User 2", Email: "two@corp.com", Status: StatusSuspended},
+		{Name: "User 3", Email: "three@example.com", Status: StatusActive},
+		{Name: "User 4", Email: "four@corp.com", Status: StatusInactive},
+	}
+	for _, user := range testUsers {
+		require.NoError(t, repo.Create(t.Context(), user))
+	}
+
+	filter, err := listquery.ParseFilter(map[string]string{
+		"status[in]":    "active,suspended",
+		"email[suffix]": "@corp.com",
+	}, FilterableFields)
+	require.NoError(t, err)
+
+	users, total, err := repo.List(t.Context(), &ListUsersQuery{Limit: 10, Sort: "id", Filter: filter})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, users, 2)
+	assert.Equal(t, "one@corp.com", users[0].Email)
+	assert.Equal(t, "two@corp.com", users[1].Email)
+
+	_, err = listquery.ParseFilter(map[string]string{"status[in]": "active,pending"}, FilterableFields)
+	assert.ErrorIs(t, err, listquery.ErrInvalidFilter)
+}
+
+func TestRepository_Export(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+
+	for i := 1; i <= 5; i++ {
+		status := StatusActive
+		if i == 3 {
+			status = StatusInactive
+		}
+		require.NoError(t, repo.Create(t.Context(), &User{Name: fmt.Sprintf("User %d", i), Email: fmt.Sprintf("user%d@example.com", i), Status: status}))
+	}
+
+	var batches [][]uint
+	err := repo.Export(t.Context(), &ListUsersQuery{Status: StatusActive}, 2, func(users []*User) error {
+		ids := make([]uint, 0, len(users))
+		for _, user := range users {
+			ids = append(ids, user.ID)
+		}
+		batches = append(batches, ids)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]uint{{1, 2}, {4, 5}}, batches)
+}
+
+func TestRepository_ListDeletedScopes(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+
+	active := &User{Name: "Active User", Email: "active@example.com"}
+	deleted := &User{Name: "Deleted User", Email: "deleted@example.com"}
+	require.NoError(t, repo.Create(t.Context(), active))
+	require.NoError(t, repo.Create(t.Context(), deleted))
+	require.NoError(t, repo.Delete(t.Context(), deleted.ID, 0))
+
+	testCases := []struct {
+		name        string
+		query       *ListUsersQuery
+		expectedIDs []uint
+	}{
+		{name: "default hides deleted", query: &ListUsersQuery{Limit: 10, Sort: "id"}, expectedIDs: []uint{active.ID}},
+		{name: "include deleted", query: &ListUsersQuery{Limit: 10, Sort: "id", IncludeDeleted: true}, expectedIDs: []uint{active.ID, deleted.ID}},
+		{name: "only deleted", query: &ListUsersQuery{Limit: 10, Sort: "id", OnlyDeleted: true}, expectedIDs: []uint{deleted.ID}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users, total, err := repo.List(t.Context(), tc.query)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tc.expectedIDs)), total)
+
+			ids := make([]uint, 0, len(users))
+			for _, user := range users {
+				ids = append(ids, user.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestRepository_RestoreAndPurge(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+
+	user := &User{Name: "Test User", Email: "restore@example.com"}
+	require.NoError(t, repo.Create(t.Context(), user))
+
+	// 삭제되지 않은 사용자는 복원 대상이 아님 / A user that is not deleted cannot be restored
+	assert.ErrorIs(t, repo.Restore(t.Context(), user.ID, user.Version), ErrVersionConflict)
+
+	require.NoError(t, repo.Delete(t.Context(), user.ID, 0))
+	require.NoError(t, repo.Restore(t.Context(), user.ID, user.Version))
+
+	restored, err := repo.GetByID(t.Context(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, user.Version+1, restored.Version)
+
+	// 활성 사용자는 PurgeDeleted 대상이 아님 / Active users are never purged
+	purged, err := repo.PurgeDeleted(t.Context(), []string{"restore@example.com"})
+	require.NoError(t, err)
+	assert.Empty(t, purged)
+
+	require.NoError(t, repo.Delete(t.Context(), user.ID, 0))
+	purged, err = repo.PurgeDeleted(t.Context(), []string{"restore@example.com"})
+	require.NoError(t, err)
+	require.Len(t, purged, 1)
+	assert.Equal(t, user.ID, purged[0].ID)
+}
+
+func TestRepository_ListWithSort(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+
+	testUsers := []*User{
+		{Name: "Bravo", Email: "b2@example.com", Status: StatusActive},
+		{Name: "Alpha", Email: "a@example.com", Status: StatusActive},
+		{Name: "Bravo", Email: "b1@example.com", Status: StatusActive},
+	}
+	for _, user := range testUsers {
+		require.NoError(t, repo.Create(t.Context(), user))
+	}
+
+	users, _, err := repo.List(t.Context(), &ListUsersQuery{Limit: 10, Sort: "name,-email"})
+	require.NoError(t, err)
+
+	var emails []string
+	for _, user := range users {
+		emails = append(emails, user.Email)
+	}
+	assert.Equal(t, []string{"a@example.com", "b2@example.com", "b1@example.com"}, emails)
+
+	_, _, err = repo.List(t.Context(), &ListUsersQuery{Limit: 10, Sort: "password"})
+	assert.ErrorIs(t, err, listquery.ErrInvalidSort)
+}
+
+func TestRepository_ListWithCursor(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+
+	// 동일한 생성 시각으로 id 동순위 해소를 검증 / Same created_at to exercise the id tie-breaker
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 5; i++ {
+		user := &User{
+			Name:      fmt.Sprintf("User %d", i),
+			Email:     fmt.Sprintf("user%d@example.com", i),
+			Status:    StatusActive,
+			CreatedAt: createdAt,
+		}
+		require.NoError(t, repo.Create(t.Context(), user))
+	}
+
+	firstPage, total, err := repo.List(t.Context(), &ListUsersQuery{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, firstPage, 2)
+	assert.Equal(t, int64(5), total)
+
+	// 페이지 사이에 새 사용자가 추가되어도 결과가 밀리지 않아야 함 / Inserts between pages must not shift results
+	require.NoError(t, repo.Create(t.Context(), &User{Name: "Late User", Email: "late@example.com", Status: StatusActive}))
+
+	var seen []uint
+	for _, user := range firstPage {
+		seen = append(seen, user.ID)
+	}
+
+	query := &ListUsersQuery{Limit: 2}
+	cursor := query.NextCursor(firstPage)
+	for cursor != "" {
+		query = &ListUsersQuery{Limit: 2, Cursor: cursor}
+		page, total, err := repo.List(t.Context(), query)
+		require.NoError(t, err)
+		assert.Zero(t, total, "cursor pages are not counted")
+		for _, user := range page {
+			seen = append(seen, user.ID)
+		}
+		cursor = query.NextCursor(page)
+	}
+
+	assert.Equal(t, []uint{5, 4, 3, 2, 1}, seen)
+}
+
 func TestRepository_Exists(t *testing.T) {
 	database := setupTestDB(t)
 	repo := NewRepository(database)
@@ -274,7 +519,7 @@ func TestRepository_Exists(t *testing.T) {
 		Email:  "test@example.com",
 		Status: StatusActive,
 	}
-	err := repo.Create(testUser)
+	err := repo.Create(t.Context(), testUser)
 	require.NoError(t, err)
 
 	testCases := []struct {
@@ -296,13 +541,52 @@ func TestRepository_Exists(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			exists, err := repo.Exists(tc.userID)
+			exists, err := repo.Exists(t.Context(), tc.userID)
 			assert.NoError(t, err)
 			assert.Equal(t, tc.expected, exists)
 		})
 	}
 }
 
+func TestRepository_CanceledContext(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+
+	user := &User{Name: "Test User", Email: "canceled@example.com", Status: StatusActive}
+	require.NoError(t, repo.Create(t.Context(), user))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err := repo.GetByID(ctx, user.ID)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	err = db.NewTxManager(database, db.TxConfig{}).Do(ctx, func(ctx context.Context) error {
+		return repo.Update(ctx, user)
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRepository_JoinsAmbientTransaction(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	tx := db.NewTxManager(database, db.TxConfig{})
+
+	errRollback := errors.New("rollback")
+	err := tx.Do(t.Context(), func(ctx context.Context) error {
+		require.NoError(t, repo.Create(ctx, &User{Name: "Rolled Back", Email: "rollback@example.com", Status: StatusActive}))
+		require.NoError(t, repo.Audit(ctx).Record(&audit.Event{EntityType: AuditEntityType, EntityID: "1", Action: AuditActionCreate, Actor: SystemActor}))
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+
+	_, err = repo.GetByEmail(t.Context(), "rollback@example.com")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, total, err := repo.Audit(t.Context()).List(AuditEntityType, "1", 0, 10)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
 // 벤치마크 테스트 / Benchmark tests
 func BenchmarkRepository_Create(b *testing.B) {
 	database := setupTestDB(b)
@@ -315,7 +599,7 @@ func BenchmarkRepository_Create(b *testing.B) {
 			Email:  fmt.Sprintf("benchmark-%d@example.com", i),
 			Status: StatusActive,
 		}
-		if err := repo.Create(user); err != nil {
+		if err := repo.Create(b.Context(), user); err != nil {
 			b.Fatal(err)
 		}
 	}
@@ -331,11 +615,11 @@ func BenchmarkRepository_GetByID(b *testing.B) {
 		Email:  "benchmark@example.com",
 		Status: StatusActive,
 	}
-	require.NoError(b, repo.Create(testUser))
+	require.NoError(b, repo.Create(b.Context(), testUser))
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		if _, err := repo.GetByID(testUser.ID); err != nil {
+		if _, err := repo.GetByID(b.Context(), testUser.ID); err != nil {
 			b.Fatal(err)
 		}
 	}
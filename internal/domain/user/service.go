@@ -1,61 +1,97 @@
 package user
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/audit"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/listquery"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/password"
 )
 
 // Service 사용자 서비스 인터페이스 / User service interface
+// 감사 주체와 요청 ID는 audit.WithMeta로 ctx에 담아 전달 / The audit actor and request ID travel in ctx via audit.WithMeta
 type Service interface {
-	Create(req *CreateUserRequest) (*User, error)
-	GetByID(id uint) (*User, error)
-	Update(id uint, req *UpdateUserRequest) (*User, error)
-	Delete(id uint) error
-	List(query *ListUsersQuery) ([]*User, int64, error)
+	Create(ctx context.Context, req *CreateUserRequest) (*User, error)
+	GetByID(ctx context.Context, id uint) (*User, error)
+	// expectedVersion이 0이면 버전 검사 생략 / An expectedVersion of 0 skips the version check
+	Update(ctx context.Context, id uint, req *UpdateUserRequest, expectedVersion uint) (*User, error)
+	Patch(ctx context.Context, id uint, contentType string, patch []byte, expectedVersion uint) (*User, error)
+	Delete(ctx context.Context, id uint, expectedVersion uint) error
+	Restore(ctx context.Context, id uint, expectedVersion uint) (*User, error)
+	HardDelete(ctx context.Context, id uint, expectedVersion uint) error
+	// Transition 전이 표에 따라 상태 변경 / Change the status according to the transition table
+	Transition(ctx context.Context, id uint, target Status, req *TransitionRequest, expectedVersion uint) (*User, error)
+	// ReactivateExpired 기한이 지난 정지를 batchSize 단위로 해제 / Lift expired suspensions in batches of batchSize
+	ReactivateExpired(ctx context.Context, now time.Time, batchSize int) (int, error)
+	// List 목록과 전체 개수, 커서 모드에서는 0 / Page and total count; the total is 0 in cursor mode
+	List(ctx context.Context, query *ListUsersQuery) ([]*User, int64, error)
+	// Export 필터와 일치하는 사용자를 배치 단위로 fn에 전달 / Pass users matching the filters to fn in batches
+	Export(ctx context.Context, query *ListUsersQuery, fn func(users []*User) error) error
+	Import(ctx context.Context, rows []ImportRow, opts ImportOptions) (*ImportReport, error)
+	BatchCreate(ctx context.Context, mode BatchMode, items []CreateUserRequest) ([]BatchItemResult, error)
+	BatchUpdate(ctx context.Context, mode BatchMode, items []BatchUpdateItem) ([]BatchItemResult, error)
+	BatchDelete(ctx context.Context, mode BatchMode, items []BatchDeleteItem) ([]BatchItemResult, error)
+	// History 사용자 변경 이력 조회 (최신순) / List a user's change history, newest first
+	History(ctx context.Context, id uint, query *HistoryQuery) ([]*audit.Event, int64, error)
+	// SetPassword 비밀번호 설정, 변경 전에 시작된 세션은 더 이상 갱신되지 않음 / Set the password; sessions started before the change can no longer refresh
+	// 해시 슬롯이 없으면 password.ErrBusy / Returns password.ErrBusy when no hashing slot is free
+	SetPassword(ctx context.Context, id uint, req *SetPasswordRequest, expectedVersion uint) (*User, error)
+	// Authenticate 이메일과 비밀번호 확인, 실패 사유와 관계없이 ErrInvalidCredentials 반환 (해시 슬롯이 없으면 password.ErrBusy)
+	// Check an email and password; every failure reason returns ErrInvalidCredentials (password.ErrBusy when no hashing slot is free)
+	Authenticate(ctx context.Context, email, password string) (*User, error)
 }
 
 // service 사용자 서비스 구현체 / User service implementation
 type service struct {
-	repo Repository
+	repo   Repository
+	tx     db.TxManager
+	hasher password.Hasher
 }
 
 // NewService 새 사용자 서비스 생성 / Create new user service
-func NewService(repo Repository) Service {
-	return &service{repo: repo}
+func NewService(repo Repository, tx db.TxManager, hasher password.Hasher) Service {
+	return &service{repo: repo, tx: tx, hasher: hasher}
 }
 
 // Create 사용자 생성 / Create user
-func (s *service) Create(req *CreateUserRequest) (*User, error) {
+func (s *service) Create(ctx context.Context, req *CreateUserRequest) (*User, error) {
 	logger := zap.L().With(zap.String("method", "user.service.Create"))
 
 	if req.Status != "" && !req.Status.IsValid() {
 		return nil, fmt.Errorf("%w: %s", ErrInvalidStatus, req.Status)
 	}
-
-	// 이메일 중복 확인 / Check email duplication
-	existingUser, err := s.repo.GetByEmail(req.Email)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		logger.Error("Failed to check email duplication", zap.Error(err))
-		return nil, fmt.Errorf("failed to check email duplication: %w", err)
-	}
-
-	if existingUser != nil {
-		logger.Warn("Email already exists")
-		return nil, ErrEmailAlreadyExists
+	if err := req.checkInitialStatus(); err != nil {
+		return nil, err
 	}
 
-	// 사용자 모델 생성 / Create user model
-	user := req.ToUser()
+	// 이메일 중복 확인과 생성을 한 트랜잭션으로 실행 / Check email duplication and create in one transaction
+	var user *User
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.checkEmailAvailable(ctx, req.Email); err != nil {
+			return err
+		}
 
-	// 사용자 생성 / Create user
-	if err := s.repo.Create(user); err != nil {
-		logger.Error("Failed to create user", zap.Error(err))
-		if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// 재시도마다 새 모델 생성 / Build a fresh model on every attempt
+		user = req.ToUser()
+		if err := s.createReleasingEmail(ctx, logger, user); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, AuditActionCreate, user.ID, nil, user)
+	})
+	if err != nil {
+		if errors.Is(err, ErrEmailAlreadyExists) || errors.Is(err, gorm.ErrDuplicatedKey) {
+			logger.Warn("Email already exists")
 			return nil, ErrEmailAlreadyExists
 		}
+		logger.Error("Failed to create user", zap.Error(err))
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
@@ -65,10 +101,10 @@ func (s *service) Create(req *CreateUserRequest) (*User, error) {
 }
 
 // GetByID ID로 사용자 조회 / Get user by ID
-func (s *service) GetByID(id uint) (*User, error) {
+func (s *service) GetByID(ctx context.Context, id uint) (*User, error) {
 	logger := zap.L().With(zap.String("method", "user.service.GetByID"), zap.Uint("user_id", id))
 
-	user, err := s.repo.GetByID(id)
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Warn("User not found", zap.Uint("user_id", id))
@@ -82,7 +118,7 @@ func (s *service) GetByID(id uint) (*User, error) {
 }
 
 // Update 사용자 업데이트 / Update user
-func (s *service) Update(id uint, req *UpdateUserRequest) (*User, error) {
+func (s *service) Update(ctx context.Context, id uint, req *UpdateUserRequest, expectedVersion uint) (*User, error) {
 	logger := zap.L().With(
 		zap.String("method", "user.service.Update"),
 		zap.Uint("user_id", id))
@@ -92,7 +128,7 @@ func (s *service) Update(id uint, req *UpdateUserRequest) (*User, error) {
 	}
 
 	// 기존 사용자 조회 / Get existing user
-	user, err := s.repo.GetByID(id)
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Warn("User not found for update", zap.Uint("user_id", id))
@@ -102,57 +138,139 @@ func (s *service) Update(id uint, req *UpdateUserRequest) (*User, error) {
 		return nil, fmt.Errorf("failed to get user for update: %w", err)
 	}
 
-	// 이메일 중복 확인 (이메일이 변경되는 경우) / Check email duplication (if email is being changed)
-	if req.Email != nil && *req.Email != user.Email {
-		existingUser, err := s.repo.GetByEmail(*req.Email)
-		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-			logger.Error("Failed to check email duplication for update", zap.Error(err))
-			return nil, fmt.Errorf("failed to check email duplication: %w", err)
-		}
+	if err := checkVersion(user, expectedVersion); err != nil {
+		logger.Warn("Version precondition failed for update", zap.Uint("version", user.Version))
+		return nil, err
+	}
 
-		if existingUser != nil {
-			logger.Warn("Email already exists for update")
-			return nil, ErrEmailAlreadyExists
+	return s.applyUpdate(ctx, logger, user, req, expectedVersion)
+}
+
+// Patch 패치 문서로 사용자 부분 업데이트 / Partially update user with a patch document
+func (s *service) Patch(ctx context.Context, id uint, contentType string, patch []byte, expectedVersion uint) (*User, error) {
+	logger := zap.L().With(
+		zap.String("method", "user.service.Patch"),
+		zap.Uint("user_id", id))
+
+	// 기존 사용자 조회 / Get existing user
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("User not found for patch", zap.Uint("user_id", id))
+			return nil, fmt.Errorf("%w with id %d", ErrUserNotFound, id)
 		}
+		logger.Error("Failed to get user for patch", zap.Error(err))
+		return nil, fmt.Errorf("failed to get user for patch: %w", err)
+	}
+
+	if err := checkVersion(user, expectedVersion); err != nil {
+		logger.Warn("Version precondition failed for patch", zap.Uint("version", user.Version))
+		return nil, err
+	}
+
+	// 패치 적용 및 업데이트 요청 변환 / Apply patch and convert to update request
+	req, err := PatchToUpdateRequest(user, contentType, patch)
+	if err != nil {
+		logger.Warn("Failed to apply patch", zap.Error(err))
+		return nil, err
 	}
 
+	// PUT과 동일한 검증 적용 / Apply the same validation as PUT
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.applyUpdate(ctx, logger, user, req, expectedVersion)
+}
+
+// checkVersion If-Match 버전 비교 / Compare the If-Match version
+func checkVersion(user *User, expectedVersion uint) error {
+	if expectedVersion != 0 && user.Version != expectedVersion {
+		return fmt.Errorf("%w: expected %d, current %d", ErrPreconditionFailed, expectedVersion, user.Version)
+	}
+	return nil
+}
+
+// applyUpdate 이메일 중복 확인 후 업데이트 저장 / Check email duplication and persist the update
+func (s *service) applyUpdate(ctx context.Context, logger *zap.Logger, user *User, req *UpdateUserRequest, expectedVersion uint) (*User, error) {
+	emailChanged := req.Email != nil && *req.Email != user.Email
+
 	// 업데이트 요청 적용 / Apply update request
-	req.ApplyTo(user)
+	before := *user
+	if err := req.ApplyTo(user); err != nil {
+		logger.Warn("Status change rejected for update", zap.Error(err))
+		return nil, err
+	}
 
-	// 사용자 업데이트 / Update user
-	if err := s.repo.Update(user); err != nil {
-		logger.Error("Failed to update user", zap.Error(err))
-		if errors.Is(err, gorm.ErrDuplicatedKey) {
+	// 이메일 중복 확인과 업데이트를 한 트랜잭션으로 실행 / Check email duplication and update in one transaction
+	var updated User
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		if emailChanged {
+			if err := s.checkEmailAvailable(ctx, user.Email); err != nil {
+				return err
+			}
+			if err := s.releaseDeletedEmails(ctx, logger, user.Email); err != nil {
+				return err
+			}
+		}
+
+		// 재시도 시 증가된 버전이 남지 않도록 복사본 저장 / Save a copy so a retry never sees a bumped version
+		updated = *user
+		if err := s.repo.Update(ctx, &updated); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, AuditActionUpdate, user.ID, &before, &updated)
+	})
+	if err != nil {
+		if errors.Is(err, ErrEmailAlreadyExists) || errors.Is(err, gorm.ErrDuplicatedKey) {
+			logger.Warn("Email already exists for update")
 			return nil, ErrEmailAlreadyExists
 		}
+		logger.Error("Failed to update user", zap.Error(err))
+		// 읽기와 쓰기 사이의 동시 수정 / Concurrent modification between read and write
+		if errors.Is(err, ErrVersionConflict) {
+			if expectedVersion != 0 {
+				return nil, fmt.Errorf("%w: %w", ErrPreconditionFailed, err)
+			}
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
 	logger.Info("User updated successfully", zap.Uint("user_id", user.ID))
 
-	return user, nil
+	return &updated, nil
 }
 
 // Delete 사용자 삭제 / Delete user
-func (s *service) Delete(id uint) error {
+func (s *service) Delete(ctx context.Context, id uint, expectedVersion uint) error {
 	logger := zap.L().With(
 		zap.String("method", "user.service.Delete"),
 		zap.Uint("user_id", id))
 
-	// 사용자 존재 확인 / Check user existence
-	exists, err := s.repo.Exists(id)
+	// 사용자 조회 (감사 이벤트의 변경 전 값) / Get user (the audit event's before value)
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		logger.Error("Failed to check user existence for delete", zap.Error(err))
-		return fmt.Errorf("failed to check user existence: %w", err)
-	}
-
-	if !exists {
-		logger.Warn("User not found for delete", zap.Uint("user_id", id))
-		return fmt.Errorf("%w with id %d", ErrUserNotFound, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("User not found for delete", zap.Uint("user_id", id))
+			return fmt.Errorf("%w with id %d", ErrUserNotFound, id)
+		}
+		logger.Error("Failed to get user for delete", zap.Error(err))
+		return fmt.Errorf("failed to get user for delete: %w", err)
 	}
 
 	// 사용자 삭제 / Delete user
-	if err := s.repo.Delete(id); err != nil {
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id, expectedVersion); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, AuditActionDelete, id, user, nil)
+	})
+	if err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			logger.Warn("Version precondition failed for delete", zap.Uint("expected_version", expectedVersion))
+			return fmt.Errorf("%w: %w", ErrPreconditionFailed, err)
+		}
 		logger.Error("Failed to delete user", zap.Error(err))
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
@@ -162,8 +280,259 @@ func (s *service) Delete(id uint) error {
 	return nil
 }
 
+// Restore 소프트 삭제된 사용자 복원 / Restore a soft-deleted user
+// 이메일 유니크 인덱스가 삭제된 행도 포함하므로 복원 시 이메일 충돌은 없음
+// The email unique index covers deleted rows too, so a restore never collides on email
+func (s *service) Restore(ctx context.Context, id uint, expectedVersion uint) (*User, error) {
+	logger := zap.L().With(
+		zap.String("method", "user.service.Restore"),
+		zap.Uint("user_id", id))
+
+	user, err := s.repo.GetByIDUnscoped(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("User not found for restore", zap.Uint("user_id", id))
+			return nil, fmt.Errorf("%w with id %d", ErrUserNotFound, id)
+		}
+		logger.Error("Failed to get user for restore", zap.Error(err))
+		return nil, fmt.Errorf("failed to get user for restore: %w", err)
+	}
+
+	if !user.DeletedAt.Valid {
+		return nil, fmt.Errorf("%w: id %d", ErrUserNotDeleted, id)
+	}
+	if err := checkVersion(user, expectedVersion); err != nil {
+		logger.Warn("Version precondition failed for restore", zap.Uint("version", user.Version))
+		return nil, err
+	}
+
+	var restored *User
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.Restore(ctx, id, user.Version); err != nil {
+			return err
+		}
+		reloaded, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to reload restored user: %w", err)
+		}
+		restored = reloaded
+		return s.recordEvent(ctx, AuditActionRestore, id, user, restored)
+	})
+	if err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			logger.Warn("User changed during restore", zap.Error(err))
+			if expectedVersion != 0 {
+				return nil, fmt.Errorf("%w: %w", ErrPreconditionFailed, err)
+			}
+			return nil, err
+		}
+		logger.Error("Failed to restore user", zap.Error(err))
+		return nil, fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	logger.Info("User restored successfully", zap.Uint("user_id", id))
+
+	return restored, nil
+}
+
+// HardDelete 사용자 영구 삭제 (소프트 삭제된 사용자 포함) / Permanently delete user (including soft-deleted users)
+func (s *service) HardDelete(ctx context.Context, id uint, expectedVersion uint) error {
+	logger := zap.L().With(
+		zap.String("method", "user.service.HardDelete"),
+		zap.Uint("user_id", id))
+
+	user, err := s.repo.GetByIDUnscoped(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("User not found for hard delete", zap.Uint("user_id", id))
+			return fmt.Errorf("%w with id %d", ErrUserNotFound, id)
+		}
+		logger.Error("Failed to get user for hard delete", zap.Error(err))
+		return fmt.Errorf("failed to get user for hard delete: %w", err)
+	}
+
+	if err := checkVersion(user, expectedVersion); err != nil {
+		logger.Warn("Version precondition failed for hard delete", zap.Uint("version", user.Version))
+		return err
+	}
+
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.HardDelete(ctx, id, expectedVersion); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, AuditActionHardDelete, id, user, nil)
+	})
+	if err != nil {
+		// 조건 없는 삭제의 0행 결과는 동시 삭제 / Zero rows on an unconditional delete means a concurrent delete
+		if errors.Is(err, ErrVersionConflict) && expectedVersion == 0 {
+			return fmt.Errorf("%w with id %d", ErrUserNotFound, id)
+		}
+		if errors.Is(err, ErrVersionConflict) {
+			logger.Warn("Version precondition failed for hard delete", zap.Uint("expected_version", expectedVersion))
+			return fmt.Errorf("%w: %w", ErrPreconditionFailed, err)
+		}
+		logger.Error("Failed to hard delete user", zap.Error(err))
+		return fmt.Errorf("failed to hard delete user: %w", err)
+	}
+
+	logger.Info("User permanently deleted", zap.Uint("user_id", id))
+
+	return nil
+}
+
+// Transition 사용자 상태 전이 / Transition user status
+func (s *service) Transition(ctx context.Context, id uint, target Status, req *TransitionRequest, expectedVersion uint) (*User, error) {
+	logger := zap.L().With(
+		zap.String("method", "user.service.Transition"),
+		zap.Uint("user_id", id),
+		zap.String("target", string(target)))
+
+	now := time.Now()
+	if err := req.Validate(target, now); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("User not found for transition", zap.Uint("user_id", id))
+			return nil, fmt.Errorf("%w with id %d", ErrUserNotFound, id)
+		}
+		logger.Error("Failed to get user for transition", zap.Error(err))
+		return nil, fmt.Errorf("failed to get user for transition: %w", err)
+	}
+
+	if err := checkVersion(user, expectedVersion); err != nil {
+		logger.Warn("Version precondition failed for transition", zap.Uint("version", user.Version))
+		return nil, err
+	}
+
+	from := user.Status
+	if err := checkTransition(from, target); err != nil {
+		logger.Warn("Illegal status transition", zap.String("from", string(from)))
+		return nil, err
+	}
+
+	before := *user
+	user.applyStatus(target, req.Reason, actor(ctx), req.Until, now)
+
+	var updated User
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		updated = *user
+		if err := s.repo.Update(ctx, &updated); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, AuditActionStatusChange, id, &before, &updated)
+	})
+	if err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			logger.Warn("User changed during transition", zap.Error(err))
+			if expectedVersion != 0 {
+				return nil, fmt.Errorf("%w: %w", ErrPreconditionFailed, err)
+			}
+			return nil, err
+		}
+		logger.Error("Failed to transition user", zap.Error(err))
+		return nil, fmt.Errorf("failed to transition user: %w", err)
+	}
+
+	logger.Info("User status changed",
+		zap.String("from", string(from)),
+		zap.String("actor", actor(ctx)))
+
+	return &updated, nil
+}
+
+// ReactivateExpired 정지 기한이 지난 사용자 재활성화 / Reactivate users whose suspension has expired
+// 행마다 버전 조건부로 저장하므로 동시에 변경된 사용자는 건너뜀 / Each row is saved conditionally on its version, so users changed concurrently are skipped
+func (s *service) ReactivateExpired(ctx context.Context, now time.Time, batchSize int) (int, error) {
+	logger := zap.L().With(zap.String("method", "user.service.ReactivateExpired"))
+	ctx = audit.WithMeta(ctx, audit.Meta{Actor: SystemActor})
+
+	reactivated := 0
+	for {
+		users, err := s.repo.ListExpiredSuspensions(ctx, now, batchSize)
+		if err != nil {
+			logger.Error("Failed to list expired suspensions", zap.Error(err))
+			return reactivated, fmt.Errorf("failed to list expired suspensions: %w", err)
+		}
+
+		batchReactivated := 0
+		for _, user := range users {
+			suspendedUntil := *user.SuspendedUntil
+			before := *user
+			user.applyStatus(StatusActive, suspensionExpiredReason, SystemActor, nil, now)
+			err := s.tx.Do(ctx, func(ctx context.Context) error {
+				updated := *user
+				if err := s.repo.Update(ctx, &updated); err != nil {
+					return err
+				}
+				return s.recordEvent(ctx, AuditActionStatusChange, user.ID, &before, &updated)
+			})
+			if err != nil {
+				if errors.Is(err, ErrVersionConflict) {
+					logger.Warn("User changed during reactivation", zap.Uint("user_id", user.ID))
+					continue
+				}
+				logger.Error("Failed to reactivate user", zap.Uint("user_id", user.ID), zap.Error(err))
+				return reactivated, fmt.Errorf("failed to reactivate user: %w", err)
+			}
+
+			batchReactivated++
+			reactivatedTotal.Inc()
+			logger.Info("Suspension expired, user reactivated",
+				zap.Uint("user_id", user.ID),
+				zap.Time("suspended_until", suspendedUntil))
+		}
+		reactivated += batchReactivated
+
+		// 마지막 배치이거나 진척이 없으면 다음 주기로 미룸 / Stop on the last batch or when no row made progress
+		if len(users) < batchSize || batchReactivated == 0 {
+			return reactivated, nil
+		}
+	}
+}
+
+// checkEmailAvailable 활성 사용자가 이메일을 쓰고 있으면 ErrEmailAlreadyExists / ErrEmailAlreadyExists when an active user holds the email
+func (s *service) checkEmailAvailable(ctx context.Context, email string) error {
+	existingUser, err := s.repo.GetByEmail(ctx, email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to check email duplication: %w", err)
+	}
+	if existingUser != nil {
+		return ErrEmailAlreadyExists
+	}
+	return nil
+}
+
+// createReleasingEmail 소프트 삭제된 사용자의 이메일을 해제한 뒤 생성 / Release the email of a soft-deleted user, then create
+func (s *service) createReleasingEmail(ctx context.Context, logger *zap.Logger, user *User) error {
+	if err := s.releaseDeletedEmails(ctx, logger, user.Email); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, user)
+}
+
+// releaseDeletedEmails 이메일 재사용 정책: 소프트 삭제된 사용자는 이메일이 다시 등록될 때까지만 복원 가능
+// Email reuse policy: a soft-deleted user stays restorable only until its email is registered again
+func (s *service) releaseDeletedEmails(ctx context.Context, logger *zap.Logger, emails ...string) error {
+	purged, err := s.repo.PurgeDeleted(ctx, emails)
+	if err != nil {
+		return fmt.Errorf("failed to release deleted user emails: %w", err)
+	}
+
+	// 영구 삭제마다 HardDelete와 같은 감사 이벤트와 삭제 이벤트 기록 / Record the same audit and deletion events as HardDelete for each purged user
+	for _, user := range purged {
+		if err := s.recordEvent(ctx, AuditActionHardDelete, user.ID, user, nil); err != nil {
+			return err
+		}
+		logger.Info("Purged soft-deleted user to reuse its email", zap.Uint("purged_user_id", user.ID))
+	}
+	return nil
+}
+
 // List 사용자 목록 조회 / List users
-func (s *service) List(query *ListUsersQuery) ([]*User, int64, error) {
+func (s *service) List(ctx context.Context, query *ListUsersQuery) ([]*User, int64, error) {
 	logger := zap.L().With(zap.String("method", "user.service.List"))
 
 	if query == nil {
@@ -173,10 +542,22 @@ func (s *service) List(query *ListUsersQuery) ([]*User, int64, error) {
 		return nil, 0, fmt.Errorf("%w: %s", ErrInvalidStatus, query.Status)
 	}
 
+	if _, err := query.SortFields(); err != nil {
+		return nil, 0, err
+	}
+	if query.IsCursorMode() {
+		if !query.IsDefaultSort() {
+			return nil, 0, fmt.Errorf("%w: cursor requires the default sort", listquery.ErrInvalidCursor)
+		}
+		if _, err := query.DecodeCursor(); err != nil {
+			return nil, 0, err
+		}
+	}
+
 	// 쿼리 파라미터 검증 / Validate query parameters
 	query.Validate()
 
-	users, total, err := s.repo.List(query)
+	users, total, err := s.repo.List(ctx, query)
 	if err != nil {
 		logger.Error("Failed to list users", zap.Error(err))
 		return nil, 0, fmt.Errorf("failed to list users: %w", err)
@@ -186,15 +567,501 @@ func (s *service) List(query *ListUsersQuery) ([]*User, int64, error) {
 		zap.Int("count", len(users)),
 		zap.Int64("total", total),
 		zap.Int("offset", query.Offset),
-		zap.Int("limit", query.Limit))
+		zap.Int("limit", query.Limit),
+		zap.Bool("cursor", query.IsCursorMode()))
 
 	return users, total, nil
 }
 
+// Export 사용자 내보내기 / Export users
+// 페이지네이션과 정렬은 무시되고 ID 순서로 전달 / Pagination and sort are ignored; users arrive in ID order
+func (s *service) Export(ctx context.Context, query *ListUsersQuery, fn func(users []*User) error) error {
+	logger := zap.L().With(zap.String("method", "user.service.Export"))
+
+	if query.Status != "" && !query.Status.IsValid() {
+		return fmt.Errorf("%w: %s", ErrInvalidStatus, query.Status)
+	}
+
+	exported := 0
+	err := s.repo.Export(ctx, query, ExportBatchSize, func(users []*User) error {
+		exported += len(users)
+		return fn(users)
+	})
+	if err != nil {
+		logger.Error("Failed to export users", zap.Error(err), zap.Int("exported", exported))
+		return fmt.Errorf("failed to export users: %w", err)
+	}
+
+	logger.Info("Users exported successfully", zap.Int("count", exported))
+
+	return nil
+}
+
+// importPlan 가져오기 행의 예정된 쓰기 / Planned write for an import row
+type importPlan struct {
+	result   *ImportRowResult
+	req      CreateUserRequest
+	existing *User
+}
+
+// user 쓰기 시도마다 새 모델 생성 (롤백 후 재시도 대비) / Build a fresh model per write attempt so a retry after rollback starts clean
+func (p *importPlan) user() *User {
+	if p.existing == nil {
+		return p.req.ToUser()
+	}
+	user := *p.existing
+	user.Name = p.req.Name
+	return &user
+}
+
+// Import 사용자 가져오기 / Import users
+// 행마다 Create와 같은 검증을 적용하고 ImportBatchSize 단위로 저장 / Validates each row like Create and writes in ImportBatchSize chunks
+func (s *service) Import(ctx context.Context, rows []ImportRow, opts ImportOptions) (*ImportReport, error) {
+	logger := zap.L().With(
+		zap.String("method", "user.service.Import"),
+		zap.Bool("dry_run", opts.DryRun),
+		zap.String("on_conflict", string(opts.OnConflict)))
+
+	report := &ImportReport{DryRun: opts.DryRun, Rows: make([]ImportRowResult, len(rows))}
+	seen := make(map[string]int, len(rows))
+
+	for start := 0; start < len(rows); start += ImportBatchSize {
+		end := min(start+ImportBatchSize, len(rows))
+		if err := s.importChunk(ctx, logger, rows[start:end], report.Rows[start:end], opts, seen); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, row := range report.Rows {
+		switch row.Action {
+		case ImportCreated:
+			report.Created++
+		case ImportUpdated:
+			report.Updated++
+		case ImportSkipped:
+			report.Skipped++
+		case ImportError:
+			report.Errored++
+		}
+	}
+
+	logger.Info("Users imported",
+		zap.Int("created", report.Created),
+		zap.Int("updated", report.Updated),
+		zap.Int("skipped", report.Skipped),
+		zap.Int("errored", report.Errored))
+
+	return report, nil
+}
+
+// importChunk 행 묶음 검증 및 저장 / Validate and write a chunk of rows
+func (s *service) importChunk(ctx context.Context, logger *zap.Logger, rows []ImportRow, results []ImportRowResult, opts ImportOptions, seen map[string]int) error {
+	// 검증 및 업로드 내 이메일 중복 확인 / Validate and check for duplicate emails within the upload
+	pending := make([]int, 0, len(rows))
+	emails := make([]string, 0, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		results[i] = ImportRowResult{Line: row.Line, Email: row.Request.Email}
+
+		err := row.Err
+		if err == nil {
+			err = row.Request.Validate()
+		}
+		if err == nil {
+			if line, ok := seen[row.Request.Email]; ok {
+				err = &ValidationError{Message: fmt.Sprintf("Email is duplicated in the upload (line %d)", line)}
+			}
+		}
+		if err != nil {
+			results[i].Action, results[i].Error = ImportError, importErrorMessage(err)
+			continue
+		}
+
+		seen[row.Request.Email] = row.Line
+		pending = append(pending, i)
+		emails = append(emails, row.Request.Email)
+	}
+
+	existingUsers, err := s.repo.GetByEmails(ctx, emails)
+	if err != nil {
+		logger.Error("Failed to look up existing emails", zap.Error(err))
+		return fmt.Errorf("failed to look up existing emails: %w", err)
+	}
+	existing := make(map[string]*User, len(existingUsers))
+	for _, user := range existingUsers {
+		existing[user.Email] = user
+	}
+
+	// 충돌 정책 적용 / Apply the conflict policy
+	plans := make([]*importPlan, 0, len(pending))
+	for _, i := range pending {
+		result := &results[i]
+		user, exists := existing[rows[i].Request.Email]
+		switch {
+		case !exists:
+			if err := rows[i].Request.checkInitialStatus(); err != nil {
+				result.Action, result.Error = ImportError, importErrorMessage(err)
+				continue
+			}
+			result.Action = ImportCreated
+		case opts.OnConflict == ConflictSkip:
+			result.Action, result.UserID = ImportSkipped, user.ID
+			continue
+		case opts.OnConflict == ConflictUpdate:
+			// 기존 사용자의 상태는 전이 엔드포인트로만 변경 / Existing users change status only through the transition endpoints
+			if status := rows[i].Request.Status; status != "" && status != user.Status {
+				result.Action, result.Error = ImportError, importErrorMessage(statusChangeError())
+				continue
+			}
+			result.Action, result.UserID = ImportUpdated, user.ID
+		default:
+			result.Action, result.Error = ImportError, importErrorMessage(ErrEmailAlreadyExists)
+			continue
+		}
+		plans = append(plans, &importPlan{result: result, req: rows[i].Request, existing: user})
+	}
+
+	if opts.DryRun || len(plans) == 0 {
+		return nil
+	}
+
+	// 묶음 전체를 한 트랜잭션으로 저장 / Write the whole chunk in one transaction
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		creates := make([]*User, 0, len(plans))
+		createPlans := make([]*importPlan, 0, len(plans))
+		for _, plan := range plans {
+			user := plan.user()
+			if plan.existing == nil {
+				creates = append(creates, user)
+				createPlans = append(createPlans, plan)
+				continue
+			}
+			if err := s.repo.Update(ctx, user); err != nil {
+				return err
+			}
+			if err := s.recordEvent(ctx, AuditActionUpdate, user.ID, plan.existing, user); err != nil {
+				return err
+			}
+		}
+		emails := make([]string, len(creates))
+		for i, user := range creates {
+			emails[i] = user.Email
+		}
+		if err := s.releaseDeletedEmails(ctx, logger, emails...); err != nil {
+			return err
+		}
+		if err := s.repo.CreateBatch(ctx, creates); err != nil {
+			return err
+		}
+		for i, user := range creates {
+			if err := s.recordEvent(ctx, AuditActionCreate, user.ID, nil, user); err != nil {
+				return err
+			}
+			createPlans[i].result.UserID = user.ID
+		}
+		return nil
+	})
+	if err == nil {
+		return nil
+	}
+
+	// 실패한 행을 찾기 위해 행 단위로 다시 시도 / Retry row by row to isolate the failing rows
+	logger.Warn("Import chunk failed, retrying rows individually", zap.Error(err))
+	for _, plan := range plans {
+		var user *User
+		writeErr := s.tx.Do(ctx, func(ctx context.Context) error {
+			user = plan.user()
+			if plan.existing == nil {
+				if err := s.createReleasingEmail(ctx, logger, user); err != nil {
+					return err
+				}
+				return s.recordEvent(ctx, AuditActionCreate, user.ID, nil, user)
+			}
+			if err := s.repo.Update(ctx, user); err != nil {
+				return err
+			}
+			return s.recordEvent(ctx, AuditActionUpdate, user.ID, plan.existing, user)
+		})
+		if writeErr != nil {
+			logger.Warn("Failed to import row", zap.Int("line", plan.result.Line), zap.Error(writeErr))
+			plan.result.Action, plan.result.UserID, plan.result.Error = ImportError, 0, importErrorMessage(writeErr)
+			continue
+		}
+		plan.result.UserID = user.ID
+	}
+
+	return nil
+}
+
+// importErrorMessage 행 오류를 보고서 메시지로 변환 / Convert a row error into a report message
+func importErrorMessage(err error) string {
+	var validationErr *ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return validationErr.Message
+	case errors.Is(err, ErrEmailAlreadyExists), errors.Is(err, gorm.ErrDuplicatedKey):
+		return "Email already exists"
+	case errors.Is(err, ErrVersionConflict):
+		return "User was modified concurrently"
+	case errors.Is(err, ErrIllegalTransition):
+		return "Status transition is not allowed"
+	default:
+		return "Failed to import row"
+	}
+}
+
+// BatchCreate 사용자 일괄 생성 / Create users in a batch
+func (s *service) BatchCreate(ctx context.Context, mode BatchMode, items []CreateUserRequest) ([]BatchItemResult, error) {
+	return s.runBatch(ctx, "user.service.BatchCreate", mode, len(items), func(ctx context.Context, i int) (*User, error) {
+		if err := items[i].Validate(); err != nil {
+			return nil, err
+		}
+		return s.Create(ctx, &items[i])
+	})
+}
+
+// BatchUpdate 사용자 일괄 업데이트 / Update users in a batch
+func (s *service) BatchUpdate(ctx context.Context, mode BatchMode, items []BatchUpdateItem) ([]BatchItemResult, error) {
+	return s.runBatch(ctx, "user.service.BatchUpdate", mode, len(items), func(ctx context.Context, i int) (*User, error) {
+		if err := items[i].Validate(); err != nil {
+			return nil, err
+		}
+		return s.Update(ctx, items[i].ID, &items[i].UpdateUserRequest, items[i].Version)
+	})
+}
+
+// BatchDelete 사용자 일괄 삭제 / Delete users in a batch
+func (s *service) BatchDelete(ctx context.Context, mode BatchMode, items []BatchDeleteItem) ([]BatchItemResult, error) {
+	return s.runBatch(ctx, "user.service.BatchDelete", mode, len(items), func(ctx context.Context, i int) (*User, error) {
+		return nil, s.Delete(ctx, items[i].ID, items[i].Version)
+	})
+}
+
+// errBatchItemFailed 원자적 일괄 처리 롤백 신호 / Signals an atomic batch rollback
+var errBatchItemFailed = errors.New("batch item failed")
+
+// runBatch 모드에 따라 항목별 연산 실행 / Run a per-item operation according to the batch mode
+// atomic 모드는 하나의 트랜잭션에서 항목마다 세이브포인트로 실행하고 첫 실패 시 전체 롤백, partial 모드는 항목마다 독립적으로 실행
+// Atomic mode runs each item in a savepoint of one transaction and rolls it all back on the first failure; partial mode runs each item independently
+func (s *service) runBatch(ctx context.Context, method string, mode BatchMode, count int, op func(ctx context.Context, i int) (*User, error)) ([]BatchItemResult, error) {
+	logger := zap.L().With(
+		zap.String("method", method),
+		zap.String("mode", string(mode)),
+		zap.Int("count", count))
+
+	if !mode.IsValid() {
+		return nil, fmt.Errorf("%w: unknown mode %q", ErrInvalidBatch, mode)
+	}
+	if count == 0 || count > MaxBatchItems {
+		return nil, fmt.Errorf("%w: must contain between 1 and %d items", ErrInvalidBatch, MaxBatchItems)
+	}
+
+	results := make([]BatchItemResult, count)
+	for i := range results {
+		results[i].Index = i
+	}
+
+	if mode == BatchModePartial {
+		for i := range results {
+			results[i].User, results[i].Err = op(ctx, i)
+		}
+		logger.Info("Partial batch processed", zap.Int("failed", countFailed(results)))
+		return results, nil
+	}
+
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		// 재시도 시 이전 시도의 결과 초기화 / Clear the previous attempt's results on a retry
+		for i := range results {
+			results[i] = BatchItemResult{Index: i}
+		}
+		for i := range results {
+			user, err := op(ctx, i)
+			if err != nil {
+				results[i].Err = err
+				// 교착 상태 같은 재시도 가능한 오류는 유지 / Keep retryable errors such as deadlocks visible
+				return fmt.Errorf("%w: %w", errBatchItemFailed, err)
+			}
+			results[i].User = user
+		}
+		return nil
+	})
+	if err != nil {
+		// 롤백된 항목과 실행되지 않은 항목 표시 / Mark rolled-back and unattempted items
+		for i := range results {
+			if results[i].Err == nil {
+				results[i] = BatchItemResult{Index: i, Err: ErrBatchAborted}
+			}
+		}
+		if !errors.Is(err, errBatchItemFailed) {
+			logger.Error("Failed to commit batch", zap.Error(err))
+			return nil, fmt.Errorf("failed to commit batch: %w", err)
+		}
+		logger.Warn("Atomic batch rolled back")
+		return results, nil
+	}
+
+	logger.Info("Atomic batch committed")
+
+	return results, nil
+}
+
+// History 사용자 변경 이력 조회 / List user change history
+// 영구 삭제된 사용자의 이력도 조회 가능 / History stays available after a hard delete
+func (s *service) History(ctx context.Context, id uint, query *HistoryQuery) ([]*audit.Event, int64, error) {
+	logger := zap.L().With(
+		zap.String("method", "user.service.History"),
+		zap.Uint("user_id", id))
+
+	query.Validate()
+
+	events, total, err := s.repo.Audit(ctx).List(AuditEntityType, strconv.FormatUint(uint64(id), 10), query.Offset, query.Limit)
+	if err != nil {
+		logger.Error("Failed to list user history", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to list user history: %w", err)
+	}
+
+	return events, total, nil
+}
+
+// SetPassword 비밀번호 설정 / Set password
+func (s *service) SetPassword(ctx context.Context, id uint, req *SetPasswordRequest, expectedVersion uint) (*User, error) {
+	logger := zap.L().With(
+		zap.String("method", "user.service.SetPassword"),
+		zap.Uint("user_id", id))
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("User not found for password change", zap.Uint("user_id", id))
+			return nil, fmt.Errorf("%w with id %d", ErrUserNotFound, id)
+		}
+		logger.Error("Failed to get user for password change", zap.Error(err))
+		return nil, fmt.Errorf("failed to get user for password change: %w", err)
+	}
+
+	if err := checkVersion(user, expectedVersion); err != nil {
+		logger.Warn("Version precondition failed for password change", zap.Uint("version", user.Version))
+		return nil, err
+	}
+
+	// 해시는 트랜잭션 밖에서 한 번만 계산 / Hash once, outside the transaction
+	hash, err := s.hasher.Hash(req.Password)
+	if errors.Is(err, password.ErrBusy) {
+		logger.Warn("Password hasher busy", zap.Uint("user_id", id))
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	if err != nil {
+		logger.Error("Failed to hash password", zap.Error(err))
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	before := *user
+	user.PasswordHash = hash
+	// 이 시각 이전에 발급된 리프레시 토큰은 인증 모듈이 거부 / The auth module rejects refresh tokens issued before this time
+	changedAt := time.Now()
+	user.PasswordChangedAt = &changedAt
+
+	var updated User
+	err = s.tx.Do(ctx, func(ctx context.Context) error {
+		updated = *user
+		if err := s.repo.Update(ctx, &updated); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, AuditActionPasswordChange, id, &before, &updated)
+	})
+	if err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			logger.Warn("User changed during password change", zap.Error(err))
+			if expectedVersion != 0 {
+				return nil, fmt.Errorf("%w: %w", ErrPreconditionFailed, err)
+			}
+			return nil, err
+		}
+		logger.Error("Failed to change password", zap.Error(err))
+		return nil, fmt.Errorf("failed to change password: %w", err)
+	}
+
+	logger.Info("User password changed", zap.String("actor", actor(ctx)))
+
+	return &updated, nil
+}
+
+// Authenticate 자격 증명 확인 / Check credentials
+// 없는 이메일도 해시 한 번만큼 시간을 써서 응답 시간으로 계정 존재를 알 수 없게 함
+// Unknown emails also spend one hash worth of time so response times do not reveal which accounts exist
+func (s *service) Authenticate(ctx context.Context, email, plaintext string) (*User, error) {
+	logger := zap.L().With(zap.String("method", "user.service.Authenticate"))
+
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if err := s.hasher.Burn(plaintext); err != nil {
+				logger.Warn("Failed to burn password hash", zap.Error(err))
+				return nil, fmt.Errorf("failed to verify password: %w", err)
+			}
+			return nil, ErrInvalidCredentials
+		}
+		logger.Error("Failed to get user for authentication", zap.Error(err))
+		return nil, fmt.Errorf("failed to get user for authentication: %w", err)
+	}
+	if user.PasswordHash == "" {
+		if err := s.hasher.Burn(plaintext); err != nil {
+			logger.Warn("Failed to burn password hash", zap.Error(err))
+			return nil, fmt.Errorf("failed to verify password: %w", err)
+		}
+		return nil, ErrInvalidCredentials
+	}
+
+	ok, rehash, err := s.hasher.Verify(user.PasswordHash, plaintext)
+	if errors.Is(err, password.ErrBusy) {
+		logger.Warn("Password hasher busy", zap.Uint("user_id", user.ID))
+		return nil, fmt.Errorf("failed to verify password: %w", err)
+	}
+	if err != nil {
+		logger.Error("Failed to verify password", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, fmt.Errorf("failed to verify password: %w", err)
+	}
+	if !ok || user.Status != StatusActive {
+		return nil, ErrInvalidCredentials
+	}
+
+	// 비용 설정이 바뀌었으면 새 설정으로 교체, 실패해도 로그인은 진행 / Replace the hash when the cost settings changed; login goes ahead even if this fails
+	if rehash {
+		if hash, err := s.hasher.Hash(plaintext); err != nil {
+			logger.Warn("Failed to rehash password", zap.Uint("user_id", user.ID), zap.Error(err))
+		} else if err := s.repo.UpdatePasswordHash(ctx, user.ID, hash); err != nil {
+			logger.Warn("Failed to store rehashed password", zap.Uint("user_id", user.ID), zap.Error(err))
+		} else {
+			user.PasswordHash = hash
+		}
+	}
+
+	return user, nil
+}
+
+// actor 컨텍스트의 감사 주체 (미지정 시 SystemActor) / Audit actor carried by ctx, SystemActor when unset
+func actor(ctx context.Context) string {
+	if actor := audit.MetaFromContext(ctx).Actor; actor != "" {
+		return actor
+	}
+	return SystemActor
+}
+
+func countFailed(results []BatchItemResult) int {
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
 // 향후 확장 가능한 서비스 메서드들 / Future extensible service methods
-// - CreateBatch: 대량 사용자 생성 (트랜잭션 내에서)
 // - UpdateStatus: 사용자 상태 일괄 변경
 // - SearchAdvanced: 고급 검색 기능
 // - GetUserStatistics: 사용자 통계 정보
-// - ActivateUser: 사용자 활성화
-// - DeactivateUser: 사용자 비활성화
@@ -1,25 +1,106 @@
 package user
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/audit"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/jsonpatch"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/password"
 )
 
 // MockRepository 모킹된 저장소 / Mocked repository
 type MockRepository struct {
 	mock.Mock
+	events   memoryAuditStore
+	messages memoryOutboxStore
+}
+
+// testHasher 테스트를 빠르게 하는 낮은 비용의 해시기 / Low-cost hasher that keeps tests fast
+var testHasher = password.NewHasher(password.Params{Memory: 1024, Iterations: 1, Parallelism: 1}, 0)
+
+// passthroughTx 트랜잭션 없이 fn 실행 / Run fn without a transaction
+type passthroughTx struct{}
+
+func (passthroughTx) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// memoryAuditStore 메모리 감사 이벤트 저장소 / In-memory audit event store
+type memoryAuditStore struct {
+	events []*audit.Event
+}
+
+func (s *memoryAuditStore) Record(event *audit.Event) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *memoryAuditStore) List(_, _ string, _, _ int) ([]*audit.Event, int64, error) {
+	return s.events, int64(len(s.events)), nil
+}
+
+// memoryOutboxStore 메모리 아웃박스 저장소 / In-memory outbox store
+type memoryOutboxStore struct {
+	messages []*outbox.Message
+}
+
+func (s *memoryOutboxStore) Add(messages ...*outbox.Message) error {
+	s.messages = append(s.messages, messages...)
+	return nil
+}
+
+func (s *memoryOutboxStore) Pending(_ time.Time, _ int) ([]*outbox.Message, error) {
+	return s.messages, nil
+}
+
+func (s *memoryOutboxStore) PurgePublished(_ time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (s *memoryOutboxStore) After(_ uint, _ int) ([]*outbox.Message, error) {
+	return s.messages, nil
+}
+
+func (s *memoryOutboxStore) LastID() (uint, error) {
+	return uint(len(s.messages)), nil
+}
+
+func (s *memoryOutboxStore) MarkPublished(_ uint, _ time.Time) error {
+	return nil
 }
 
-func (m *MockRepository) Create(user *User) error {
+func (s *memoryOutboxStore) MarkFailed(_ uint, _ int, _ time.Time, _ string, _ bool) error {
+	return nil
+}
+
+func (m *MockRepository) Create(_ context.Context, user *User) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
-func (m *MockRepository) GetByID(id uint) (*User, error) {
+func (m *MockRepository) GetByID(_ context.Context, id uint) (*User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*User), args.Error(1)
+}
+
+func (m *MockRepository) GetByIDUnscoped(_ context.Context, id uint) (*User, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -27,7 +108,7 @@ func (m *MockRepository) GetByID(id uint) (*User, error) {
 	return args.Get(0).(*User), args.Error(1)
 }
 
-func (m *MockRepository) GetByEmail(email string) (*User, error) {
+func (m *MockRepository) GetByEmail(_ context.Context, email string) (*User, error) {
 	args := m.Called(email)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -35,17 +116,53 @@ func (m *MockRepository) GetByEmail(email string) (*User, error) {
 	return args.Get(0).(*User), args.Error(1)
 }
 
-func (m *MockRepository) Update(user *User) error {
+func (m *MockRepository) GetByEmails(_ context.Context, emails []string) ([]*User, error) {
+	args := m.Called(emails)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*User), args.Error(1)
+}
+
+func (m *MockRepository) CreateBatch(_ context.Context, users []*User) error {
+	args := m.Called(users)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Update(_ context.Context, user *User) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
-func (m *MockRepository) Delete(id uint) error {
-	args := m.Called(id)
+func (m *MockRepository) Delete(_ context.Context, id uint, version uint) error {
+	args := m.Called(id, version)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Restore(_ context.Context, id uint, version uint) error {
+	args := m.Called(id, version)
+	return args.Error(0)
+}
+
+func (m *MockRepository) HardDelete(_ context.Context, id uint, version uint) error {
+	args := m.Called(id, version)
 	return args.Error(0)
 }
 
-func (m *MockRepository) List(query *ListUsersQuery) ([]*User, int64, error) {
+func (m *MockRepository) UpdatePasswordHash(_ context.Context, id uint, hash string) error {
+	args := m.Called(id, hash)
+	return args.Error(0)
+}
+
+func (m *MockRepository) PurgeDeleted(_ context.Context, emails []string) ([]*User, error) {
+	args := m.Called(emails)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*User), args.Error(1)
+}
+
+func (m *MockRepository) List(_ context.Context, query *ListUsersQuery) ([]*User, int64, error) {
 	args := m.Called(query)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
@@ -53,11 +170,32 @@ func (m *MockRepository) List(query *ListUsersQuery) ([]*User, int64, error) {
 	return args.Get(0).([]*User), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockRepository) Exists(id uint) (bool, error) {
+func (m *MockRepository) ListExpiredSuspensions(_ context.Context, now time.Time, limit int) ([]*User, error) {
+	args := m.Called(now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*User), args.Error(1)
+}
+
+func (m *MockRepository) Exists(_ context.Context, id uint) (bool, error) {
 	args := m.Called(id)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockRepository) Export(_ context.Context, query *ListUsersQuery, batchSize int, fn func(users []*User) error) error {
+	args := m.Called(query, batchSize)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Audit(_ context.Context) audit.Store {
+	return &m.events
+}
+
+func (m *MockRepository) Outbox(_ context.Context) outbox.Store {
+	return &m.messages
+}
+
 func TestService_Create(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -76,6 +214,7 @@ func TestService_Create(t *testing.T) {
 			setupMock: func(repo *MockRepository) {
 				// Email doesn't exist
 				repo.On("GetByEmail", "test@example.com").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("PurgeDeleted", []string{"test@example.com"}).Return(nil, nil)
 				// Create succeeds
 				repo.On("Create", mock.AnythingOfType("*user.User")).Return(nil)
 			},
@@ -122,6 +261,7 @@ func TestService_Create(t *testing.T) {
 			},
 			setupMock: func(repo *MockRepository) {
 				repo.On("GetByEmail", "test@example.com").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("PurgeDeleted", []string{"test@example.com"}).Return(nil, nil)
 				repo.On("Create", mock.AnythingOfType("*user.User")).Return(errors.New("database insert error"))
 			},
 			expectedError: true,
@@ -136,6 +276,7 @@ func TestService_Create(t *testing.T) {
 			},
 			setupMock: func(repo *MockRepository) {
 				repo.On("GetByEmail", "race@example.com").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("PurgeDeleted", []string{"race@example.com"}).Return(nil, nil)
 				repo.On("Create", mock.AnythingOfType("*user.User")).Return(gorm.ErrDuplicatedKey)
 			},
 			expectedError: true,
@@ -152,6 +293,17 @@ func TestService_Create(t *testing.T) {
 			expectedError: true,
 			errorContains: "invalid user status",
 		},
+		{
+			name: "suspended initial status",
+			request: &CreateUserRequest{
+				Name:   "Test User",
+				Email:  "test@example.com",
+				Status: StatusSuspended,
+			},
+			setupMock:     func(_ *MockRepository) {},
+			expectedError: true,
+			errorContains: initialStatusMessage,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -159,10 +311,10 @@ func TestService_Create(t *testing.T) {
 			// Setup
 			mockRepo := new(MockRepository)
 			tc.setupMock(mockRepo)
-			service := NewService(mockRepo)
+			service := NewService(mockRepo, passthroughTx{}, testHasher)
 
 			// Execute
-			user, err := service.Create(tc.request)
+			user, err := service.Create(t.Context(), tc.request)
 
 			// Assert
 			if tc.expectedError {
@@ -237,10 +389,10 @@ func TestService_GetByID(t *testing.T) {
 			// Setup
 			mockRepo := new(MockRepository)
 			tc.setupMock(mockRepo)
-			service := NewService(mockRepo)
+			service := NewService(mockRepo, passthroughTx{}, testHasher)
 
 			// Execute
-			user, err := service.GetByID(tc.userID)
+			user, err := service.GetByID(t.Context(), tc.userID)
 
 			// Assert
 			if tc.expectedError {
@@ -267,7 +419,7 @@ func TestService_GetByID(t *testing.T) {
 func TestService_Update(t *testing.T) {
 	newName := "Updated Name"
 	newEmail := "updated@example.com"
-	newStatus := StatusInactive
+	sameStatus := StatusActive
 
 	testCases := []struct {
 		name          string
@@ -283,7 +435,7 @@ func TestService_Update(t *testing.T) {
 			request: &UpdateUserRequest{
 				Name:   &newName,
 				Email:  &newEmail,
-				Status: &newStatus,
+				Status: &sameStatus,
 			},
 			setupMock: func(repo *MockRepository) {
 				existingUser := &User{
@@ -294,6 +446,7 @@ func TestService_Update(t *testing.T) {
 				}
 				repo.On("GetByID", uint(1)).Return(existingUser, nil)
 				repo.On("GetByEmail", "updated@example.com").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("PurgeDeleted", []string{"updated@example.com"}).Return(nil, nil)
 				repo.On("Update", mock.AnythingOfType("*user.User")).Return(nil)
 			},
 			expectedError: false,
@@ -350,6 +503,7 @@ func TestService_Update(t *testing.T) {
 				}
 				repo.On("GetByID", uint(1)).Return(existingUser, nil)
 				repo.On("GetByEmail", "updated@example.com").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("PurgeDeleted", []string{"updated@example.com"}).Return(nil, nil)
 				repo.On("Update", mock.AnythingOfType("*user.User")).Return(gorm.ErrDuplicatedKey)
 			},
 			expectedError: true,
@@ -365,6 +519,18 @@ func TestService_Update(t *testing.T) {
 			expectedError: true,
 			errorContains: "invalid user status",
 		},
+		{
+			name:   "status change",
+			userID: 1,
+			request: &UpdateUserRequest{
+				Status: ptr(StatusInactive),
+			},
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetByID", uint(1)).Return(&User{ID: 1, Name: "Test User", Email: "old@example.com", Status: StatusActive}, nil)
+			},
+			expectedError: true,
+			errorContains: "Status cannot be changed",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -372,10 +538,10 @@ func TestService_Update(t *testing.T) {
 			// Setup
 			mockRepo := new(MockRepository)
 			tc.setupMock(mockRepo)
-			service := NewService(mockRepo)
+			service := NewService(mockRepo, passthroughTx{}, testHasher)
 
 			// Execute
-			user, err := service.Update(tc.userID, tc.request)
+			user, err := service.Update(t.Context(), tc.userID, tc.request, 0)
 
 			// Assert
 			if tc.expectedError {
@@ -391,6 +557,8 @@ func TestService_Update(t *testing.T) {
 					assert.ErrorIs(t, err, ErrEmailAlreadyExists)
 				case "invalid user status":
 					assert.ErrorIs(t, err, ErrInvalidStatus)
+				case "Status cannot be changed":
+					assert.ErrorIs(t, err, ErrStatusChangeNotAllowed)
 				}
 			} else {
 				assert.NoError(t, err)
@@ -413,6 +581,234 @@ func TestService_Update(t *testing.T) {
 	}
 }
 
+func TestService_Patch(t *testing.T) {
+	testCases := []struct {
+		name        string
+		userID      uint
+		contentType string
+		patch       string
+		setupMock   func(*MockRepository)
+		wantErr     error
+		wantName    string
+		wantEmail   string
+	}{
+		{
+			name:        "merge patch updates name",
+			userID:      1,
+			contentType: jsonpatch.MergePatchContentType,
+			patch:       `{"name":"Patched Name"}`,
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetByID", uint(1)).Return(createTestUser(), nil)
+				repo.On("Update", mock.AnythingOfType("*user.User")).Return(nil)
+			},
+			wantName:  "Patched Name",
+			wantEmail: "test@example.com",
+		},
+		{
+			name:        "json patch with passing test",
+			userID:      1,
+			contentType: jsonpatch.JSONPatchContentType,
+			patch:       `[{"op":"test","path":"/email","value":"test@example.com"},{"op":"replace","path":"/email","value":"new@example.com"}]`,
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetByID", uint(1)).Return(createTestUser(), nil)
+				repo.On("GetByEmail", "new@example.com").Return(nil, gorm.ErrRecordNotFound)
+				repo.On("PurgeDeleted", []string{"new@example.com"}).Return(nil, nil)
+				repo.On("Update", mock.AnythingOfType("*user.User")).Return(nil)
+			},
+			wantName:  "Test User",
+			wantEmail: "new@example.com",
+		},
+		{
+			name:        "json patch with failing test",
+			userID:      1,
+			contentType: jsonpatch.JSONPatchContentType,
+			patch:       `[{"op":"test","path":"/email","value":"other@example.com"},{"op":"replace","path":"/name","value":"X Y"}]`,
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetByID", uint(1)).Return(createTestUser(), nil)
+			},
+			wantErr: jsonpatch.ErrTestFailed,
+		},
+		{
+			name:        "merge patch removing name fails validation",
+			userID:      1,
+			contentType: jsonpatch.MergePatchContentType,
+			patch:       `{"name":null}`,
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetByID", uint(1)).Return(createTestUser(), nil)
+			},
+			wantErr: &ValidationError{},
+		},
+		{
+			name:        "invalid status",
+			userID:      1,
+			contentType: jsonpatch.MergePatchContentType,
+			patch:       `{"status":"pending"}`,
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetByID", uint(1)).Return(createTestUser(), nil)
+			},
+			wantErr: ErrInvalidStatus,
+		},
+		{
+			name:        "read-only field",
+			userID:      1,
+			contentType: jsonpatch.JSONPatchContentType,
+			patch:       `[{"op":"replace","path":"/id","value":2}]`,
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetByID", uint(1)).Return(createTestUser(), nil)
+			},
+			wantErr: ErrReadOnlyField,
+		},
+		{
+			name:        "merge patch adding an omitted read-only field",
+			userID:      1,
+			contentType: jsonpatch.MergePatchContentType,
+			patch:       `{"name":"Patched Name","status_reason":"spam"}`,
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetByID", uint(1)).Return(createTestUser(), nil)
+			},
+			wantErr: ErrReadOnlyField,
+		},
+		{
+			name:        "json patch setting deleted_at",
+			userID:      1,
+			contentType: jsonpatch.JSONPatchContentType,
+			patch:       `[{"op":"replace","path":"/deleted_at","value":"2024-01-01T00:00:00Z"}]`,
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetByID", uint(1)).Return(createTestUser(), nil)
+			},
+			wantErr: ErrReadOnlyField,
+		},
+		{
+			name:        "unknown field",
+			userID:      1,
+			contentType: jsonpatch.MergePatchContentType,
+			patch:       `{"name":"Patched Name","nickname":"tester"}`,
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetByID", uint(1)).Return(createTestUser(), nil)
+			},
+			wantErr: ErrUnknownField,
+		},
+		{
+			name:        "json patch testing a read-only field",
+			userID:      1,
+			contentType: jsonpatch.JSONPatchContentType,
+			patch:       `[{"op":"test","path":"/id","value":1},{"op":"replace","path":"/name","value":"Patched Name"}]`,
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetByID", uint(1)).Return(createTestUser(), nil)
+				repo.On("Update", mock.AnythingOfType("*user.User")).Return(nil)
+			},
+			wantName:  "Patched Name",
+			wantEmail: "test@example.com",
+		},
+		{
+			name:        "email already exists",
+			userID:      1,
+			contentType: jsonpatch.MergePatchContentType,
+			patch:       `{"email":"taken@example.com"}`,
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetByID", uint(1)).Return(createTestUser(), nil)
+				repo.On("GetByEmail", "taken@example.com").Return(&User{ID: 2, Email: "taken@example.com"}, nil)
+			},
+			wantErr: ErrEmailAlreadyExists,
+		},
+		{
+			name:        "user not found",
+			userID:      999,
+			contentType: jsonpatch.MergePatchContentType,
+			patch:       `{"name":"Patched Name"}`,
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetByID", uint(999)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr: ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			tc.setupMock(mockRepo)
+			service := NewService(mockRepo, passthroughTx{}, testHasher)
+
+			user, err := service.Patch(t.Context(), tc.userID, tc.contentType, []byte(tc.patch), 0)
+
+			if tc.wantErr != nil {
+				assert.Nil(t, user)
+				var validationErr *ValidationError
+				if errors.As(tc.wantErr, &validationErr) {
+					assert.ErrorAs(t, err, &validationErr)
+				} else {
+					assert.ErrorIs(t, err, tc.wantErr)
+				}
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantName, user.Name)
+				assert.Equal(t, tc.wantEmail, user.Email)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_VersionPreconditions(t *testing.T) {
+	newName := "Updated Name"
+
+	t.Run("update with stale If-Match", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetByID", uint(1)).Return(&User{ID: 1, Name: "Test User", Version: 3}, nil)
+
+		user, err := NewService(mockRepo, passthroughTx{}, testHasher).Update(t.Context(), 1, &UpdateUserRequest{Name: &newName}, 2)
+
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, ErrPreconditionFailed)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("update racing another writer with If-Match", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetByID", uint(1)).Return(&User{ID: 1, Name: "Test User", Version: 3}, nil)
+		mockRepo.On("Update", mock.AnythingOfType("*user.User")).Return(ErrVersionConflict)
+
+		_, err := NewService(mockRepo, passthroughTx{}, testHasher).Update(t.Context(), 1, &UpdateUserRequest{Name: &newName}, 3)
+
+		assert.ErrorIs(t, err, ErrPreconditionFailed)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("update racing another writer without If-Match", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetByID", uint(1)).Return(&User{ID: 1, Name: "Test User", Version: 3}, nil)
+		mockRepo.On("Update", mock.AnythingOfType("*user.User")).Return(ErrVersionConflict)
+
+		_, err := NewService(mockRepo, passthroughTx{}, testHasher).Update(t.Context(), 1, &UpdateUserRequest{Name: &newName}, 0)
+
+		assert.ErrorIs(t, err, ErrVersionConflict)
+		assert.NotErrorIs(t, err, ErrPreconditionFailed)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("patch with stale If-Match", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetByID", uint(1)).Return(&User{ID: 1, Name: "Test User", Version: 3}, nil)
+
+		_, err := NewService(mockRepo, passthroughTx{}, testHasher).Patch(t.Context(), 1, jsonpatch.MergePatchContentType, []byte(`{"name":"X Y"}`), 1)
+
+		assert.ErrorIs(t, err, ErrPreconditionFailed)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("delete with stale If-Match", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetByID", uint(1)).Return(&User{ID: 1, Name: "Test User", Version: 3}, nil)
+		mockRepo.On("Delete", uint(1), uint(2)).Return(ErrVersionConflict)
+
+		err := NewService(mockRepo, passthroughTx{}, testHasher).Delete(t.Context(), 1, 2)
+
+		assert.ErrorIs(t, err, ErrPreconditionFailed)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
 func TestService_Delete(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -425,8 +821,8 @@ func TestService_Delete(t *testing.T) {
 			name:   "successful user deletion",
 			userID: 1,
 			setupMock: func(repo *MockRepository) {
-				repo.On("Exists", uint(1)).Return(true, nil)
-				repo.On("Delete", uint(1)).Return(nil)
+				repo.On("GetByID", uint(1)).Return(createTestUser(), nil)
+				repo.On("Delete", uint(1), uint(0)).Return(nil)
 			},
 			expectedError: false,
 		},
@@ -434,26 +830,26 @@ func TestService_Delete(t *testing.T) {
 			name:   "user not found",
 			userID: 999,
 			setupMock: func(repo *MockRepository) {
-				repo.On("Exists", uint(999)).Return(false, nil)
+				repo.On("GetByID", uint(999)).Return(nil, gorm.ErrRecordNotFound)
 			},
 			expectedError: true,
 			errorContains: "user not found",
 		},
 		{
-			name:   "database error during existence check",
+			name:   "database error during lookup",
 			userID: 1,
 			setupMock: func(repo *MockRepository) {
-				repo.On("Exists", uint(1)).Return(false, errors.New("database connection error"))
+				repo.On("GetByID", uint(1)).Return(nil, errors.New("database connection error"))
 			},
 			expectedError: true,
-			errorContains: "failed to check user existence",
+			errorContains: "failed to get user for delete",
 		},
 		{
 			name:   "database error during deletion",
 			userID: 1,
 			setupMock: func(repo *MockRepository) {
-				repo.On("Exists", uint(1)).Return(true, nil)
-				repo.On("Delete", uint(1)).Return(errors.New("database delete error"))
+				repo.On("GetByID", uint(1)).Return(createTestUser(), nil)
+				repo.On("Delete", uint(1), uint(0)).Return(errors.New("database delete error"))
 			},
 			expectedError: true,
 			errorContains: "failed to delete user",
@@ -465,10 +861,10 @@ func TestService_Delete(t *testing.T) {
 			// Setup
 			mockRepo := new(MockRepository)
 			tc.setupMock(mockRepo)
-			service := NewService(mockRepo)
+			service := NewService(mockRepo, passthroughTx{}, testHasher)
 
 			// Execute
-			err := service.Delete(tc.userID)
+			err := service.Delete(t.Context(), tc.userID, 0)
 
 			// Assert
 			if tc.expectedError {
@@ -481,6 +877,8 @@ func TestService_Delete(t *testing.T) {
 				}
 			} else {
 				assert.NoError(t, err)
+				require.Len(t, mockRepo.events.events, 1)
+				assert.Equal(t, AuditActionDelete, mockRepo.events.events[0].Action)
 			}
 
 			// Verify mock expectations
@@ -565,6 +963,37 @@ func TestService_List(t *testing.T) {
 			expectedError: true,
 			errorContains: "invalid user status",
 		},
+		{
+			name: "invalid cursor",
+			query: &ListUsersQuery{
+				Limit:  10,
+				Cursor: "not-a-cursor",
+			},
+			setupMock:     func(_ *MockRepository) {},
+			expectedError: true,
+			errorContains: "invalid cursor",
+		},
+		{
+			name: "cursor with custom sort",
+			query: &ListUsersQuery{
+				Limit:  10,
+				Sort:   "name",
+				Cursor: "eyJjIjoiMjAyNC0wMS0wMVQwMDowMDowMFoiLCJpIjoxfQ",
+			},
+			setupMock:     func(_ *MockRepository) {},
+			expectedError: true,
+			errorContains: "cursor requires the default sort",
+		},
+		{
+			name: "unsupported sort field",
+			query: &ListUsersQuery{
+				Limit: 10,
+				Sort:  "name,-password",
+			},
+			setupMock:     func(_ *MockRepository) {},
+			expectedError: true,
+			errorContains: "invalid sort",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -572,10 +1001,10 @@ func TestService_List(t *testing.T) {
 			// Setup
 			mockRepo := new(MockRepository)
 			tc.setupMock(mockRepo)
-			service := NewService(mockRepo)
+			service := NewService(mockRepo, passthroughTx{}, testHasher)
 
 			// Execute
-			users, total, err := service.List(tc.query)
+			users, total, err := service.List(t.Context(), tc.query)
 
 			// Assert
 			if tc.expectedError {
@@ -600,6 +1029,329 @@ func TestService_List(t *testing.T) {
 	}
 }
 
+func TestService_BatchCreate(t *testing.T) {
+	testCases := []struct {
+		name            string
+		mode            BatchMode
+		items           []CreateUserRequest
+		expectedErrors  []error
+		expectedPersist int64
+	}{
+		{
+			name: "atomic batch commits all items",
+			mode: BatchModeAtomic,
+			items: []CreateUserRequest{
+				{Name: "User One", Email: "one@example.com"},
+				{Name: "User Two", Email: "two@example.com"},
+			},
+			expectedErrors:  []error{nil, nil},
+			expectedPersist: 2,
+		},
+		{
+			name: "atomic batch rolls back on duplicate email",
+			mode: BatchModeAtomic,
+			items: []CreateUserRequest{
+				{Name: "User One", Email: "one@example.com"},
+				{Name: "User Dup", Email: "one@example.com"},
+				{Name: "User Three", Email: "three@example.com"},
+			},
+			expectedErrors:  []error{ErrBatchAborted, ErrEmailAlreadyExists, ErrBatchAborted},
+			expectedPersist: 0,
+		},
+		{
+			name: "partial batch keeps successful items",
+			mode: BatchModePartial,
+			items: []CreateUserRequest{
+				{Name: "User One", Email: "one@example.com"},
+				{Name: "X", Email: "short@example.com"},
+				{Name: "User Three", Email: "three@example.com"},
+			},
+			expectedErrors:  []error{nil, &ValidationError{}, nil},
+			expectedPersist: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			database := setupTestDB(t)
+			service := NewService(NewRepository(database), db.NewTxManager(database, db.TxConfig{}), testHasher)
+
+			results, err := service.BatchCreate(t.Context(), tc.mode, tc.items)
+			require.NoError(t, err)
+			require.Len(t, results, len(tc.items))
+
+			for i, result := range results {
+				assert.Equal(t, i, result.Index)
+				switch expected := tc.expectedErrors[i].(type) {
+				case nil:
+					assert.NoError(t, result.Err)
+					assert.NotNil(t, result.User)
+				case *ValidationError:
+					var validationErr *ValidationError
+					assert.ErrorAs(t, result.Err, &validationErr)
+				default:
+					assert.ErrorIs(t, result.Err, expected)
+					assert.Nil(t, result.User)
+				}
+			}
+
+			var count int64
+			require.NoError(t, database.Model(&User{}).Count(&count).Error)
+			assert.Equal(t, tc.expectedPersist, count)
+		})
+	}
+}
+
+func TestService_BatchUpdateAndDelete(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	service := NewService(repo, db.NewTxManager(database, db.TxConfig{}), testHasher)
+
+	first := &User{Name: "User One", Email: "one@example.com"}
+	second := &User{Name: "User Two", Email: "two@example.com"}
+	require.NoError(t, repo.Create(t.Context(), first))
+	require.NoError(t, repo.Create(t.Context(), second))
+
+	// 두 번째 항목의 버전 불일치로 전체 롤백 / A stale version on the second item rolls back the batch
+	results, err := service.BatchUpdate(t.Context(), BatchModeAtomic, []BatchUpdateItem{
+		{ID: first.ID, UpdateUserRequest: UpdateUserRequest{Name: ptr("Renamed")}},
+		{ID: second.ID, Version: 5, UpdateUserRequest: UpdateUserRequest{Name: ptr("Renamed Too")}},
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, results[0].Err, ErrBatchAborted)
+	assert.ErrorIs(t, results[1].Err, ErrPreconditionFailed)
+
+	stored, err := repo.GetByID(t.Context(), first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "User One", stored.Name)
+
+	results, err = service.BatchDelete(t.Context(), BatchModePartial, []BatchDeleteItem{
+		{ID: first.ID},
+		{ID: 999},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, ErrUserNotFound)
+
+	exists, err := repo.Exists(t.Context(), first.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestService_BatchRejectsInvalidRequests(t *testing.T) {
+	service := NewService(new(MockRepository), passthroughTx{}, testHasher)
+
+	_, err := service.BatchCreate(t.Context(), "bogus", []CreateUserRequest{{Name: "User", Email: "u@example.com"}})
+	assert.ErrorIs(t, err, ErrInvalidBatch)
+
+	_, err = service.BatchDelete(t.Context(), BatchModeAtomic, nil)
+	assert.ErrorIs(t, err, ErrInvalidBatch)
+
+	_, err = service.BatchDelete(t.Context(), BatchModeAtomic, make([]BatchDeleteItem, MaxBatchItems+1))
+	assert.ErrorIs(t, err, ErrInvalidBatch)
+}
+
+func TestService_Import(t *testing.T) {
+	rows := []ImportRow{
+		{Line: 2, Request: CreateUserRequest{Name: "New User", Email: "new@example.com"}},
+		{Line: 3, Request: CreateUserRequest{Name: "Renamed", Email: "existing@example.com", Status: StatusActive}},
+		{Line: 4, Request: CreateUserRequest{Name: "X", Email: "short@example.com"}},
+		{Line: 5, Request: CreateUserRequest{Name: "Again", Email: "new@example.com"}},
+	}
+
+	testCases := []struct {
+		name            string
+		options         ImportOptions
+		expectedActions []ImportAction
+		expectedName    string
+		expectedCount   int64
+	}{
+		{
+			name:            "fail on existing email",
+			options:         ImportOptions{OnConflict: ConflictFail},
+			expectedActions: []ImportAction{ImportCreated, ImportError, ImportError, ImportError},
+			expectedName:    "Existing User",
+			expectedCount:   2,
+		},
+		{
+			name:            "skip existing email",
+			options:         ImportOptions{OnConflict: ConflictSkip},
+			expectedActions: []ImportAction{ImportCreated, ImportSkipped, ImportError, ImportError},
+			expectedName:    "Existing User",
+			expectedCount:   2,
+		},
+		{
+			name:            "update existing email",
+			options:         ImportOptions{OnConflict: ConflictUpdate},
+			expectedActions: []ImportAction{ImportCreated, ImportUpdated, ImportError, ImportError},
+			expectedName:    "Renamed",
+			expectedCount:   2,
+		},
+		{
+			name:            "dry run reports without writing",
+			options:         ImportOptions{OnConflict: ConflictUpdate, DryRun: true},
+			expectedActions: []ImportAction{ImportCreated, ImportUpdated, ImportError, ImportError},
+			expectedName:    "Existing User",
+			expectedCount:   1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			database := setupTestDB(t)
+			repo := NewRepository(database)
+			service := NewService(repo, db.NewTxManager(database, db.TxConfig{}), testHasher)
+
+			existing := &User{Name: "Existing User", Email: "existing@example.com"}
+			require.NoError(t, repo.Create(t.Context(), existing))
+
+			report, err := service.Import(t.Context(), rows, tc.options)
+			require.NoError(t, err)
+			require.Len(t, report.Rows, len(rows))
+
+			for i, action := range tc.expectedActions {
+				assert.Equal(t, action, report.Rows[i].Action, "line %d", report.Rows[i].Line)
+			}
+			assert.Equal(t, "Name must be between 2 and 100 characters", report.Rows[2].Error)
+			assert.Contains(t, report.Rows[3].Error, "line 2")
+			assert.Equal(t, tc.options.DryRun, report.DryRun)
+
+			stored, err := repo.GetByID(t.Context(), existing.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedName, stored.Name)
+
+			var count int64
+			require.NoError(t, database.Model(&User{}).Count(&count).Error)
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
+
+func TestService_ImportRejectsStatusChange(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	service := NewService(repo, db.NewTxManager(database, db.TxConfig{}), testHasher)
+
+	existing := &User{Name: "Existing User", Email: "existing@example.com"}
+	require.NoError(t, repo.Create(t.Context(), existing))
+
+	// 새 사용자는 active로만 시작하고 기존 사용자의 상태는 바꿀 수 없음
+	// New users only start active, and an existing user's status cannot change
+	report, err := service.Import(t.Context(), []ImportRow{
+		{Line: 2, Request: CreateUserRequest{Name: "New User", Email: "new@example.com", Status: StatusActive}},
+		{Line: 3, Request: CreateUserRequest{Name: "Renamed", Email: "existing@example.com", Status: StatusSuspended}},
+		{Line: 4, Request: CreateUserRequest{Name: "Suspended User", Email: "suspended@example.com", Status: StatusSuspended}},
+	}, ImportOptions{OnConflict: ConflictUpdate})
+	require.NoError(t, err)
+
+	assert.Equal(t, ImportCreated, report.Rows[0].Action)
+	assert.Equal(t, ImportError, report.Rows[1].Action)
+	assert.Equal(t, statusChangeMessage, report.Rows[1].Error)
+	assert.Equal(t, ImportError, report.Rows[2].Action)
+	assert.Equal(t, initialStatusMessage, report.Rows[2].Error)
+
+	stored, err := repo.GetByID(t.Context(), existing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Existing User", stored.Name)
+	assert.Equal(t, StatusActive, stored.Status)
+}
+
+func TestService_ImportIsolatesFailingRows(t *testing.T) {
+	database := setupTestDB(t)
+	service := NewService(NewRepository(database), db.NewTxManager(database, db.TxConfig{}), testHasher)
+
+	// 특정 이메일의 INSERT를 실패시켜 묶음 쓰기 실패 재현 / Fail inserts of one email to make the chunk write fail
+	require.NoError(t, database.Callback().Create().Before("gorm:create").Register("test:fail_email", func(db *gorm.DB) {
+		switch dest := db.Statement.Dest.(type) {
+		case *User:
+			if dest.Email == "broken@example.com" {
+				_ = db.AddError(errors.New("insert rejected"))
+			}
+		case []*User:
+			for _, user := range dest {
+				if user.Email == "broken@example.com" {
+					_ = db.AddError(errors.New("insert rejected"))
+				}
+			}
+		}
+	}))
+
+	report, err := service.Import(t.Context(), []ImportRow{
+		{Line: 1, Request: CreateUserRequest{Name: "User One", Email: "one@example.com"}},
+		{Line: 2, Request: CreateUserRequest{Name: "Broken User", Email: "broken@example.com"}},
+		{Line: 3, Request: CreateUserRequest{Name: "User Three", Email: "three@example.com"}},
+	}, ImportOptions{OnConflict: ConflictFail})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 1, report.Errored)
+	assert.Equal(t, ImportError, report.Rows[1].Action)
+	assert.Equal(t, "Failed to import row", report.Rows[1].Error)
+	assert.NotZero(t, report.Rows[0].UserID)
+	assert.NotZero(t, report.Rows[2].UserID)
+}
+
+func TestService_DeletedEmailReuse(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	service := NewService(repo, db.NewTxManager(database, db.TxConfig{}), testHasher)
+
+	deleted, err := service.Create(t.Context(), &CreateUserRequest{Name: "Deleted User", Email: "reuse@example.com"})
+	require.NoError(t, err)
+	require.NoError(t, service.Delete(t.Context(), deleted.ID, 0))
+
+	// 삭제된 사용자는 이메일이 재등록되기 전까지 복원 가능 / A deleted user is restorable until its email is registered again
+	restored, err := service.Restore(t.Context(), deleted.ID, deleted.Version)
+	require.NoError(t, err)
+	assert.False(t, restored.DeletedAt.Valid)
+	assert.Equal(t, deleted.Version+1, restored.Version)
+
+	_, err = service.Restore(t.Context(), deleted.ID, 0)
+	assert.ErrorIs(t, err, ErrUserNotDeleted)
+
+	require.NoError(t, service.Delete(t.Context(), deleted.ID, 0))
+	created, err := service.Create(t.Context(), &CreateUserRequest{Name: "New Owner", Email: "reuse@example.com"})
+	require.NoError(t, err)
+	assert.NotEqual(t, deleted.ID, created.ID)
+
+	_, err = repo.GetByIDUnscoped(t.Context(), deleted.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// 이메일 재사용으로 인한 영구 삭제도 감사 이력과 삭제 이벤트를 남김 / A purge for email reuse leaves an audit entry and a deletion event too
+	events, _, err := service.History(t.Context(), deleted.ID, &HistoryQuery{})
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	assert.Equal(t, AuditActionHardDelete, events[0].Action)
+
+	var messages []outbox.Message
+	require.NoError(t, database.Where("aggregate_id = ? AND event_type = ?", strconv.FormatUint(uint64(deleted.ID), 10), EventUserDeleted).
+		Order("id").Find(&messages).Error)
+	require.Len(t, messages, 3)
+	var purged UserDeleted
+	require.NoError(t, json.Unmarshal([]byte(messages[2].Payload), &purged))
+	assert.Equal(t, UserDeleted{UserID: deleted.ID, Email: "reuse@example.com", Hard: true}, purged)
+}
+
+func TestService_HardDelete(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	service := NewService(repo, db.NewTxManager(database, db.TxConfig{}), testHasher)
+
+	user := &User{Name: "Test User", Email: "hard@example.com"}
+	require.NoError(t, repo.Create(t.Context(), user))
+	require.NoError(t, repo.Delete(t.Context(), user.ID, 0))
+
+	err := service.HardDelete(t.Context(), user.ID, user.Version+1)
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+
+	require.NoError(t, service.HardDelete(t.Context(), user.ID, user.Version))
+	_, err = repo.GetByIDUnscoped(t.Context(), user.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	err = service.HardDelete(t.Context(), user.ID, 0)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
 // 테스트 헬퍼 함수들 / Test helper functions
 
 func createTestUser() *User {
@@ -611,6 +1363,283 @@ func createTestUser() *User {
 	}
 }
 
+func TestService_Transition(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	service := NewService(repo, db.NewTxManager(database, db.TxConfig{}), testHasher)
+	ctx := audit.WithMeta(t.Context(), audit.Meta{Actor: "support", RequestID: "req-1"})
+
+	user := &User{Name: "Test User", Email: "transition@example.com"}
+	require.NoError(t, repo.Create(ctx, user))
+
+	until := time.Now().Add(24 * time.Hour)
+	suspended, err := service.Transition(ctx, user.ID, StatusSuspended,
+		&TransitionRequest{Reason: "chargeback", Until: &until}, user.Version)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSuspended, suspended.Status)
+	assert.Equal(t, "chargeback", suspended.StatusReason)
+	assert.Equal(t, "support", suspended.StatusChangedBy)
+	require.NotNil(t, suspended.SuspendedUntil)
+	assert.NotNil(t, suspended.StatusChangedAt)
+
+	_, err = service.Transition(ctx, user.ID, StatusSuspended, &TransitionRequest{Reason: "again"}, 0)
+	assert.ErrorIs(t, err, ErrIllegalTransition)
+
+	_, err = service.Transition(ctx, user.ID, StatusActive, &TransitionRequest{Reason: "stale"}, user.Version)
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+
+	activated, err := service.Transition(ctx, user.ID, StatusActive, &TransitionRequest{Reason: "resolved"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, StatusActive, activated.Status)
+	assert.Nil(t, activated.SuspendedUntil)
+
+	stored, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "resolved", stored.StatusReason)
+	assert.Nil(t, stored.SuspendedUntil)
+
+	// PUT은 현재 상태만 받고 상태 변경은 거부 / PUT accepts the current status and rejects a status change
+	_, err = service.Update(ctx, user.ID, &UpdateUserRequest{Name: ptr("Renamed User"), Status: ptr(StatusActive)}, 0)
+	require.NoError(t, err)
+	_, err = service.Update(ctx, user.ID, &UpdateUserRequest{Status: ptr(StatusInactive)}, 0)
+	assert.ErrorIs(t, err, ErrStatusChangeNotAllowed)
+	_, err = service.Patch(ctx, user.ID, jsonpatch.MergePatchContentType, []byte(`{"status":"inactive"}`), 0)
+	assert.ErrorIs(t, err, ErrStatusChangeNotAllowed)
+	stored, err = repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusActive, stored.Status)
+
+	_, err = service.Transition(ctx, user.ID+1, StatusActive, &TransitionRequest{Reason: "missing"}, 0)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestService_ReactivateExpired(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	service := NewService(repo, db.NewTxManager(database, db.TxConfig{}), testHasher)
+
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+	expired := make([]*User, 3)
+	for i := range expired {
+		expired[i] = &User{Name: "Expired User", Email: fmt.Sprintf("expired%d@example.com", i), Status: StatusSuspended, SuspendedUntil: &past}
+		require.NoError(t, repo.Create(t.Context(), expired[i]))
+	}
+	pending := &User{Name: "Pending User", Email: "pending@example.com", Status: StatusSuspended, SuspendedUntil: &future}
+	require.NoError(t, repo.Create(t.Context(), pending))
+	indefinite := &User{Name: "Indefinite User", Email: "indefinite@example.com", Status: StatusSuspended}
+	require.NoError(t, repo.Create(t.Context(), indefinite))
+
+	// 배치 크기보다 많은 행도 한 번의 실행에서 처리 / Rows beyond one batch are handled in the same run
+	count, err := service.ReactivateExpired(t.Context(), now, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	for _, user := range expired {
+		stored, err := repo.GetByID(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, StatusActive, stored.Status)
+		assert.Equal(t, SystemActor, stored.StatusChangedBy)
+		assert.Nil(t, stored.SuspendedUntil)
+		assert.Equal(t, user.Version+1, stored.Version)
+	}
+	for _, user := range []*User{pending, indefinite} {
+		stored, err := repo.GetByID(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, StatusSuspended, stored.Status)
+	}
+
+	count, err = service.ReactivateExpired(t.Context(), now, 2)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestService_History(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	service := NewService(repo, db.NewTxManager(database, db.TxConfig{}), testHasher)
+	ctx := audit.WithMeta(t.Context(), audit.Meta{Actor: "api-key", RequestID: "req-1"})
+
+	user, err := service.Create(ctx, &CreateUserRequest{Name: "Test User", Email: "history@example.com"})
+	require.NoError(t, err)
+	_, err = service.Update(ctx, user.ID, &UpdateUserRequest{Email: ptr("renamed@example.com")}, 0)
+	require.NoError(t, err)
+	_, err = service.Transition(ctx, user.ID, StatusInactive, &TransitionRequest{Reason: "left"}, 0)
+	require.NoError(t, err)
+	require.NoError(t, service.Delete(ctx, user.ID, 0))
+	require.NoError(t, service.HardDelete(ctx, user.ID, 0))
+
+	// 영구 삭제 후에도 이력 유지 / History survives a hard delete
+	events, total, err := service.History(ctx, user.ID, &HistoryQuery{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, total)
+	require.Len(t, events, 5)
+
+	actions := make([]string, len(events))
+	for i, event := range events {
+		actions[i] = event.Action
+		assert.Equal(t, "api-key", event.Actor)
+		assert.Equal(t, "req-1", event.RequestID)
+	}
+	assert.Equal(t, []string{AuditActionHardDelete, AuditActionDelete, AuditActionStatusChange, AuditActionUpdate, AuditActionCreate}, actions)
+	assert.Equal(t, audit.Changes{"email": {Before: "history@example.com", After: "renamed@example.com"}}, events[3].Changes)
+	assert.Equal(t, audit.Change{Before: "active", After: "inactive"}, events[2].Changes["status"])
+	assert.Equal(t, audit.Change{After: "history@example.com"}, events[4].Changes["email"])
+
+	events, total, err = service.History(ctx, user.ID, &HistoryQuery{Offset: 1, Limit: 2})
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, total)
+	require.Len(t, events, 2)
+	assert.Equal(t, AuditActionDelete, events[0].Action)
+}
+
+func TestService_AuditFailureRollsBackMutation(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	service := NewService(repo, db.NewTxManager(database, db.TxConfig{}), testHasher)
+
+	require.NoError(t, database.Migrator().DropTable(&audit.Event{}))
+
+	_, err := service.Create(t.Context(), &CreateUserRequest{Name: "Test User", Email: "rollback@example.com"})
+	require.Error(t, err)
+
+	_, err = repo.GetByEmail(t.Context(), "rollback@example.com")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestService_OutboxEvents(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	service := NewService(repo, db.NewTxManager(database, db.TxConfig{}), testHasher)
+	ctx := audit.WithMeta(t.Context(), audit.Meta{Actor: "support"})
+
+	user, err := service.Create(ctx, &CreateUserRequest{Name: "Test User", Email: "outbox@example.com"})
+	require.NoError(t, err)
+	_, err = service.Patch(ctx, user.ID, jsonpatch.MergePatchContentType, []byte(`{"name":"Renamed User"}`), 0)
+	require.NoError(t, err)
+	_, err = service.Transition(ctx, user.ID, StatusInactive, &TransitionRequest{Reason: "left"}, 0)
+	require.NoError(t, err)
+	_, err = service.Transition(ctx, user.ID, StatusActive, &TransitionRequest{Reason: "back"}, 0)
+	require.NoError(t, err)
+	require.NoError(t, service.Delete(ctx, user.ID, 0))
+
+	publisher := outbox.NewMemoryPublisher()
+	relay := outbox.NewRelay(repo.Outbox(ctx), publisher, outbox.RelayConfig{BatchSize: 10, MaxAttempts: 3, BaseBackoff: time.Second, MaxBackoff: time.Minute})
+	published, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 5, published)
+
+	envelopes := publisher.Messages()
+	kinds := make([]string, len(envelopes))
+	for i, envelope := range envelopes {
+		kinds[i] = envelope.EventType
+		assert.Equal(t, strconv.FormatUint(uint64(user.ID), 10), envelope.AggregateID)
+	}
+	assert.Equal(t, []string{EventUserCreated, EventUserUpdated, EventUserStatusChanged, EventUserStatusChanged, EventUserDeleted}, kinds)
+
+	var updated UserUpdated
+	require.NoError(t, json.Unmarshal(envelopes[1].Payload, &updated))
+	assert.Equal(t, []string{"name"}, updated.Changed)
+
+	var changed UserStatusChanged
+	require.NoError(t, json.Unmarshal(envelopes[3].Payload, &changed))
+	assert.Equal(t, UserStatusChanged{UserID: user.ID, From: StatusInactive, To: StatusActive, Reason: "back", Actor: "support"}, changed)
+
+	// 롤백된 변경은 메시지를 남기지 않음 / A rolled-back change leaves no message
+	require.NoError(t, database.Migrator().DropTable(&audit.Event{}))
+	_, err = service.Create(ctx, &CreateUserRequest{Name: "Test User", Email: "rollback@example.com"})
+	require.Error(t, err)
+	pending, err := repo.Outbox(ctx).Pending(time.Now(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestService_Passwords(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	service := NewService(repo, db.NewTxManager(database, db.TxConfig{}), testHasher)
+	ctx := audit.WithMeta(t.Context(), audit.Meta{Actor: "support"})
+
+	user := &User{Name: "Test User", Email: "login@example.com"}
+	require.NoError(t, repo.Create(ctx, user))
+
+	_, err := service.Authenticate(ctx, "login@example.com", "correct horse")
+	assert.ErrorIs(t, err, ErrInvalidCredentials, "no password set")
+
+	_, err = service.SetPassword(ctx, user.ID, &SetPasswordRequest{Password: "short"}, 0)
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+
+	_, err = service.SetPassword(ctx, user.ID, &SetPasswordRequest{Password: "correct horse"}, user.Version+1)
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+
+	updated, err := service.SetPassword(ctx, user.ID, &SetPasswordRequest{Password: "correct horse"}, user.Version)
+	require.NoError(t, err)
+	assert.Equal(t, user.Version+1, updated.Version)
+	assert.True(t, strings.HasPrefix(updated.PasswordHash, "$argon2id$"))
+
+	// 해시는 감사 기록과 JSON에 노출되지 않음 / The hash never reaches the audit trail or JSON
+	events, _, err := service.History(ctx, user.ID, &HistoryQuery{})
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	assert.Equal(t, AuditActionPasswordChange, events[0].Action)
+	assert.Empty(t, events[0].Changes)
+	encoded, err := json.Marshal(updated)
+	require.NoError(t, err)
+	assert.NotContains(t, string(encoded), "argon2id")
+
+	testCases := []struct {
+		name     string
+		email    string
+		password string
+		wantErr  error
+	}{
+		{name: "correct", email: "login@example.com", password: "correct horse"},
+		{name: "wrong password", email: "login@example.com", password: "wrong horse", wantErr: ErrInvalidCredentials},
+		{name: "unknown email", email: "nobody@example.com", password: "correct horse", wantErr: ErrInvalidCredentials},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			authenticated, err := service.Authenticate(ctx, tc.email, tc.password)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, user.ID, authenticated.ID)
+		})
+	}
+
+	_, err = service.Transition(ctx, user.ID, StatusInactive, &TransitionRequest{Reason: "left"}, 0)
+	require.NoError(t, err)
+	_, err = service.Authenticate(ctx, "login@example.com", "correct horse")
+	assert.ErrorIs(t, err, ErrInvalidCredentials, "inactive users cannot log in")
+}
+
+func TestService_AuthenticateRehashes(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	ctx := t.Context()
+
+	weak := password.NewHasher(password.Params{Memory: 512, Iterations: 1, Parallelism: 1}, 0)
+	hash, err := weak.Hash("correct horse")
+	require.NoError(t, err)
+	user := &User{Name: "Test User", Email: "rehash@example.com", PasswordHash: hash}
+	require.NoError(t, repo.Create(ctx, user))
+
+	service := NewService(repo, db.NewTxManager(database, db.TxConfig{}), testHasher)
+	_, err = service.Authenticate(ctx, "rehash@example.com", "correct horse")
+	require.NoError(t, err)
+
+	stored, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Contains(t, stored.PasswordHash, "m=1024,")
+	assert.Equal(t, user.Version, stored.Version, "a rehash is not a user change")
+
+	_, err = service.Authenticate(ctx, "rehash@example.com", "correct horse")
+	assert.NoError(t, err)
+}
+
 func createTestCreateRequest() *CreateUserRequest {
 	return &CreateUserRequest{
 		Name:   "Test User",
@@ -627,15 +1656,16 @@ func ptr[T any](v T) *T {
 func BenchmarkService_Create(b *testing.B) {
 	mockRepo := new(MockRepository)
 	mockRepo.On("GetByEmail", mock.AnythingOfType("string")).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("PurgeDeleted", mock.Anything).Return(nil, nil)
 	mockRepo.On("Create", mock.AnythingOfType("*user.User")).Return(nil)
 
-	service := NewService(mockRepo)
+	service := NewService(mockRepo, passthroughTx{}, testHasher)
 	request := createTestCreateRequest()
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		request.Email = "benchmark@example.com" // Unique email for each iteration
-		service.Create(request)
+		service.Create(b.Context(), request)
 	}
 }
 
@@ -643,10 +1673,10 @@ func BenchmarkService_GetByID(b *testing.B) {
 	mockRepo := new(MockRepository)
 	mockRepo.On("GetByID", mock.AnythingOfType("uint")).Return(createTestUser(), nil)
 
-	service := NewService(mockRepo)
+	service := NewService(mockRepo, passthroughTx{}, testHasher)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		service.GetByID(1)
+		service.GetByID(b.Context(), 1)
 	}
 }
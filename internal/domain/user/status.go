@@ -0,0 +1,77 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// SystemActor 스케줄러 등 내부 작업의 주체 / Actor recorded for internal jobs such as the scheduler
+const SystemActor = "system"
+
+// suspensionExpiredReason 자동 재활성화 사유 / Reason recorded for automatic reactivation
+const suspensionExpiredReason = "suspension expired"
+
+// maxTransitionReasonLength 상태 전이 사유 최대 길이 / Maximum length of a transition reason
+const maxTransitionReasonLength = 500
+
+// statusTransitions 상태 전이 표: 현재 상태에서 허용되는 다음 상태 / Transition table: next statuses allowed from each status
+// 같은 상태로의 전이는 허용하지 않음 / Transitions to the same status are not allowed
+var statusTransitions = map[Status][]Status{
+	StatusActive:    {StatusInactive, StatusSuspended},
+	StatusInactive:  {StatusActive},
+	StatusSuspended: {StatusActive, StatusInactive},
+}
+
+// CanTransitionTo 상태 전이 허용 여부 / Report whether the transition table allows moving to next
+func (s Status) CanTransitionTo(next Status) bool {
+	return slices.Contains(statusTransitions[s], next)
+}
+
+// checkTransition 전이 표 확인 / Check the transition table
+func checkTransition(from, to Status) error {
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s to %s", ErrIllegalTransition, from, to)
+	}
+	return nil
+}
+
+// TransitionRequest 상태 전이 요청 / Status transition request
+// 주체는 요청 바디가 아닌 ctx의 audit.Meta로 전달 / The actor comes from the audit.Meta in ctx, not the body
+type TransitionRequest struct {
+	Reason string     `json:"reason"`
+	Until  *time.Time `json:"until,omitempty"`
+}
+
+// Validate 전이 요청 검증 / Validate a transition request
+// 만료 시각은 정지 전이에서만 허용되며 미래여야 함 / An expiry is only allowed when suspending and must be in the future
+func (r *TransitionRequest) Validate(target Status, now time.Time) error {
+	if r.Reason == "" {
+		return &ValidationError{Message: "Reason is required"}
+	}
+	if len(r.Reason) > maxTransitionReasonLength {
+		return &ValidationError{Message: fmt.Sprintf("Reason must be at most %d characters", maxTransitionReasonLength)}
+	}
+	if r.Until == nil {
+		return nil
+	}
+	if target != StatusSuspended {
+		return &ValidationError{Message: "Until is only allowed when suspending"}
+	}
+	if !r.Until.After(now) {
+		return &ValidationError{Message: "Until must be in the future"}
+	}
+	return nil
+}
+
+// applyStatus 상태와 전이 기록 필드 변경 / Change the status and its transition record
+func (u *User) applyStatus(next Status, reason, actor string, until *time.Time, now time.Time) {
+	u.Status = next
+	u.StatusReason = reason
+	u.StatusChangedBy = actor
+	u.StatusChangedAt = &now
+	u.SuspendedUntil = nil
+	if next == StatusSuspended {
+		u.SuspendedUntil = until
+	}
+}
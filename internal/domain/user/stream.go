@@ -0,0 +1,159 @@
+package user
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+)
+
+// StreamContentType SSE 응답 미디어 타입 / SSE response media type
+const StreamContentType = "text/event-stream"
+
+// streamRetryMillis 클라이언트 재연결 대기 시간 힌트 / Reconnect delay hint sent to clients
+const streamRetryMillis = 3000
+
+// Stream 변경 피드와 연결별 설정 / Change feed and per-connection settings
+type Stream struct {
+	Feed *outbox.Feed
+	// Heartbeat 이벤트가 없을 때 주석을 보내는 주기 / How often a comment is sent while idle
+	Heartbeat time.Duration
+	// WriteTimeout 한 번의 쓰기 제한 시간, 읽지 않는 클라이언트를 끊음 / Limit for one write, cutting off clients that stop reading
+	WriteTimeout time.Duration
+}
+
+// StreamFilter 연결별 이벤트 필터 / Per-connection event filter
+type StreamFilter struct {
+	Statuses []Status
+	IDs      []string
+}
+
+// ParseStreamFilter 쉼표로 구분된 status와 ids 파싱 / Parse comma-separated status and ids
+func ParseStreamFilter(status, ids string) (*StreamFilter, error) {
+	filter := &StreamFilter{}
+	for _, value := range splitList(status) {
+		s := Status(value)
+		if !s.IsValid() {
+			return nil, &ValidationError{Message: statusValidationMessage, Err: ErrInvalidStatus}
+		}
+		filter.Statuses = append(filter.Statuses, s)
+	}
+	for _, value := range splitList(ids) {
+		if _, err := strconv.ParseUint(value, 10, 32); err != nil {
+			return nil, &ValidationError{Message: fmt.Sprintf("Invalid user ID %q in ids", value)}
+		}
+		filter.IDs = append(filter.IDs, value)
+	}
+	return filter, nil
+}
+
+// Matches 이벤트가 필터를 통과하는지 / Report whether the event passes the filter
+// 상태 필터는 생성/변경 후 상태, 상태 전이의 이전 또는 이후 상태와 비교하며 삭제는 항상 통과
+// The status filter checks the status after a create or update and either side of a transition; deletes always pass
+func (f *StreamFilter) Matches(envelope outbox.Envelope) bool {
+	if envelope.AggregateType != AuditEntityType {
+		return false
+	}
+	if len(f.IDs) > 0 && !slices.Contains(f.IDs, envelope.AggregateID) {
+		return false
+	}
+	if len(f.Statuses) == 0 || envelope.EventType == EventUserDeleted {
+		return true
+	}
+
+	var payload struct {
+		User *struct {
+			Status Status `json:"status"`
+		} `json:"user"`
+		From Status `json:"from"`
+		To   Status `json:"to"`
+	}
+	if err := json.Unmarshal(envelope.Payload, &payload); err != nil {
+		return false
+	}
+	if payload.User != nil {
+		return slices.Contains(f.Statuses, payload.User.Status)
+	}
+	return slices.Contains(f.Statuses, payload.From) || slices.Contains(f.Statuses, payload.To)
+}
+
+// splitList 쉼표 구분 값 분리 (빈 값 제외) / Split comma-separated values, skipping empty ones
+func splitList(value string) []string {
+	var values []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+// ParseLastEventID Last-Event-ID 파싱, 비어 있으면 재개하지 않음 / Parse Last-Event-ID; empty means no resume
+func ParseLastEventID(value string) (id uint, resume bool, err error) {
+	if value == "" {
+		return 0, false, nil
+	}
+	parsed, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, false, &ValidationError{Message: "Last-Event-ID must be a change sequence number"}
+	}
+	return uint(parsed), true, nil
+}
+
+// EventWriter SSE 프레임 작성기 / SSE frame writer
+type EventWriter struct {
+	w       *bufio.Writer
+	conn    net.Conn
+	timeout time.Duration
+}
+
+// NewEventWriter 새 SSE 작성기 생성, conn이 있으면 쓰기마다 마감 시각 설정
+// Create new SSE writer; with a conn, every write gets a deadline
+func NewEventWriter(w *bufio.Writer, conn net.Conn, timeout time.Duration) *EventWriter {
+	return &EventWriter{w: w, conn: conn, timeout: timeout}
+}
+
+// Retry 재연결 대기 시간 전송 / Send the reconnect delay
+func (e *EventWriter) Retry(millis int) error {
+	return e.write("retry: " + strconv.Itoa(millis) + "\n\n")
+}
+
+// Event 변경 이벤트 전송 (id는 변경 순번) / Send a change event, with the change sequence as its id
+func (e *EventWriter) Event(envelope outbox.Envelope) error {
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to encode stream event: %w", err)
+	}
+	return e.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", envelope.ID, envelope.EventType, data))
+}
+
+// Comment 주석 전송 (하트비트 등) / Send a comment, such as a heartbeat
+func (e *EventWriter) Comment(text string) error {
+	return e.write(": " + text + "\n\n")
+}
+
+// write 프레임 쓰고 바로 전송 / Write a frame and send it right away
+func (e *EventWriter) write(frame string) error {
+	if err := extendWriteDeadline(e.conn, e.timeout); err != nil {
+		return err
+	}
+	if _, err := e.w.WriteString(frame); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+// extendWriteDeadline 스트리밍 응답의 다음 쓰기 마감 시각 설정 / Set the deadline for the next write of a streamed response
+// 서버 WriteTimeout은 응답 전체에 한 번만 걸리므로 쓰기마다 늘려야 함 / The server WriteTimeout covers the whole response once, so each write extends it
+func extendWriteDeadline(conn net.Conn, timeout time.Duration) error {
+	if conn == nil || timeout <= 0 {
+		return nil
+	}
+	return conn.SetWriteDeadline(time.Now().Add(timeout))
+}
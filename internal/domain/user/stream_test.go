@@ -0,0 +1,166 @@
+package user
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+)
+
+func envelope(t *testing.T, id uint, eventType, aggregateID string, payload interface{}) outbox.Envelope {
+	t.Helper()
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return outbox.Envelope{ID: id, AggregateType: AuditEntityType, AggregateID: aggregateID, EventType: eventType, Payload: data}
+}
+
+func TestStreamFilter_Matches(t *testing.T) {
+	created := envelope(t, 1, EventUserCreated, "1", UserCreated{User: &User{ID: 1, Status: StatusActive}})
+	suspended := envelope(t, 2, EventUserStatusChanged, "2", UserStatusChanged{UserID: 2, From: StatusActive, To: StatusSuspended})
+	deleted := envelope(t, 3, EventUserDeleted, "3", UserDeleted{UserID: 3})
+	other := outbox.Envelope{ID: 4, AggregateType: "order", AggregateID: "1", EventType: "OrderPlaced", Payload: []byte(`{}`)}
+
+	testCases := []struct {
+		name   string
+		status string
+		ids    string
+		want   []uint
+	}{
+		{name: "no filter", want: []uint{1, 2, 3}},
+		{name: "status", status: "suspended", want: []uint{2, 3}},
+		{name: "status leaving", status: "active", want: []uint{1, 2, 3}},
+		{name: "ids", ids: "1, 3", want: []uint{1, 3}},
+		{name: "status and ids", status: "inactive", ids: "1,2", want: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := ParseStreamFilter(tc.status, tc.ids)
+			require.NoError(t, err)
+
+			var got []uint
+			for _, e := range []outbox.Envelope{created, suspended, deleted, other} {
+				if filter.Matches(e) {
+					got = append(got, e.ID)
+				}
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := ParseStreamFilter("pending", "")
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+	_, err = ParseStreamFilter("", "1,abc")
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestParseLastEventID(t *testing.T) {
+	id, resume, err := ParseLastEventID("")
+	require.NoError(t, err)
+	assert.False(t, resume)
+	assert.Zero(t, id)
+
+	id, resume, err = ParseLastEventID("0")
+	require.NoError(t, err)
+	assert.True(t, resume)
+	assert.Zero(t, id)
+
+	id, _, err = ParseLastEventID("42")
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, id)
+
+	_, _, err = ParseLastEventID("-1")
+	assert.Error(t, err)
+}
+
+func TestEventWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewEventWriter(bufio.NewWriter(&buf), nil, 0)
+
+	require.NoError(t, w.Retry(3000))
+	require.NoError(t, w.Event(outbox.Envelope{ID: 7, AggregateType: "user", AggregateID: "1", EventType: EventUserDeleted, Payload: []byte(`{"user_id":1}`)}))
+	require.NoError(t, w.Comment("heartbeat"))
+
+	assert.Equal(t, "retry: 3000\n\n"+
+		"id: 7\nevent: UserDeleted\ndata: "+`{"id":7,"aggregate_type":"user","aggregate_id":"1","event_type":"UserDeleted","payload":{"user_id":1},"created_at":"0001-01-01T00:00:00Z"}`+"\n\n"+
+		": heartbeat\n\n", buf.String())
+}
+
+// readEvents SSE 응답에서 n개의 이벤트 id 읽기 / Read n event ids from an SSE response
+func readEvents(t *testing.T, scanner *bufio.Scanner, n int) []string {
+	t.Helper()
+
+	var ids []string
+	for len(ids) < n && scanner.Scan() {
+		if id, ok := strings.CutPrefix(scanner.Text(), "id: "); ok {
+			ids = append(ids, id)
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return ids
+}
+
+func TestHandler_StreamResumesAndFollowsChanges(t *testing.T) {
+	database := setupTestDB(t)
+	// 피드 고루틴도 같은 메모리 DB를 보도록 연결 하나만 사용 / One connection so the feed goroutine sees the same in-memory database
+	sqlDB, err := database.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	repo := NewRepository(database)
+	service := NewService(repo, db.NewTxManager(database, db.TxConfig{}), testHasher)
+
+	first, err := service.Create(t.Context(), &CreateUserRequest{Name: "First User", Email: "first@example.com"})
+	require.NoError(t, err)
+	_, err = service.Create(t.Context(), &CreateUserRequest{Name: "Second User", Email: "second@example.com"})
+	require.NoError(t, err)
+
+	handler := NewHandler(service, &Stream{
+		Feed:         outbox.NewFeed(repo.Outbox(t.Context()), outbox.FeedConfig{PollInterval: 20 * time.Millisecond, BatchSize: 10, BufferSize: 10, MaxSubscribers: 1}),
+		Heartbeat:    100 * time.Millisecond,
+		WriteTimeout: time.Second,
+	})
+	// 서버 쓰기 타임아웃보다 오래 열려 있어야 함 / The stream must outlive the server write timeout
+	app := fiber.New(fiber.Config{WriteTimeout: time.Second, DisableStartupMessage: true})
+	app.Get("/stream", handler.Stream)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(listener) }()
+	t.Cleanup(func() { _ = app.Shutdown() })
+
+	req, err := http.NewRequest(http.MethodGet, "http://"+listener.Addr().String()+"/stream?ids=1", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "0")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, StreamContentType, res.Header.Get(fiber.HeaderContentType))
+
+	// 재생: 첫 사용자 생성만 필터 통과 / Replay: only the first user's create passes the filter
+	scanner := bufio.NewScanner(res.Body)
+	assert.Equal(t, []string{"1"}, readEvents(t, scanner, 1))
+
+	time.Sleep(1500 * time.Millisecond)
+	_, err = service.Update(t.Context(), first.ID, &UpdateUserRequest{Name: ptr("Renamed User")}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3"}, readEvents(t, scanner, 1))
+
+	// 동시 연결 한도 / Concurrent connection limit
+	busy, err := http.Get("http://" + listener.Addr().String() + "/stream")
+	require.NoError(t, err)
+	defer busy.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, busy.StatusCode)
+}
@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+// blockedPrefixes 특수 용도 판별 함수가 다루지 않는 차단 대역 / Blocked ranges the special-purpose helpers do not cover
+// 0.0.0.0/8은 현재 네트워크, 100.64.0.0/10은 통신사 NAT(일부 클라우드 메타데이터 포함) / 0.0.0.0/8 is "this network", 100.64.0.0/10 is carrier-grade NAT (some cloud metadata included)
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+var (
+	// nat64Prefix IPv4 주소를 마지막 32비트에 담는 NAT64 대역 / NAT64 range carrying an IPv4 address in the last 32 bits
+	nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+	// sixToFourPrefix IPv4 주소를 16번째 비트부터 담는 6to4 대역 / 6to4 range carrying an IPv4 address from bit 16
+	sixToFourPrefix = netip.MustParsePrefix("2002::/16")
+)
+
+// blockedAddress 내부망을 가리켜 전송하지 않는 주소인지 확인 / Report whether an address points inside the network and is not delivered to
+// 루프백, 링크 로컬(클라우드 메타데이터 포함), 사설, 미지정 주소와 blockedPrefixes
+// Loopback, link-local (cloud metadata included), private, and unspecified addresses, plus blockedPrefixes
+// IPv4 매핑, NAT64, 6to4 주소는 담긴 IPv4 주소로 확인 / IPv4-mapped, NAT64, and 6to4 addresses are checked by the IPv4 address they carry
+func blockedAddress(addr netip.Addr) bool {
+	addr = embeddedIPv4(addr.Unmap())
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return addr.IsLoopback() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsPrivate() ||
+		addr.IsUnspecified()
+}
+
+// embeddedIPv4 NAT64, 6to4 주소에 담긴 IPv4 주소, 아니면 그대로 / The IPv4 address inside a NAT64 or 6to4 address, otherwise addr itself
+func embeddedIPv4(addr netip.Addr) netip.Addr {
+	b := addr.As16()
+	switch {
+	case nat64Prefix.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[12:16]))
+	case sixToFourPrefix.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[2:6]))
+	default:
+		return addr
+	}
+}
+
+// blockedHost URL 호스트가 차단된 주소 리터럴이나 localhost인지 확인 / Report whether a URL host is a blocked address literal or localhost
+// 이름은 전송 시 연결 단계에서 다시 확인 / Names are checked again when the delivery connects
+func blockedHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && blockedAddress(addr)
+}
+
+// dialControl 이름 해석 후 실제로 연결하는 주소 확인 / Check the address actually dialed, after name resolution
+// 검증 뒤 DNS 응답을 바꾸는 리바인딩도 막음 / Also stops DNS rebinding that changes the answer after validation
+func dialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid dial address %q: %w", address, err)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("invalid dial address %q: %w", address, err)
+	}
+	if blockedAddress(addr) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, addr)
+	}
+	return nil
+}
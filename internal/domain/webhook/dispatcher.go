@@ -0,0 +1,256 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+)
+
+// maxAttemptErrorLength 저장하는 오류 메시지 최대 길이 / Maximum stored error message length
+const maxAttemptErrorLength = 1000
+
+// claimMargin 선점 기간에 요청 제한 시간 외로 더하는 여유 / Slack added to the request timeout for a claim
+// 전송 중 프로세스가 죽으면 선점 기간이 지난 뒤 다시 시도 / A delivery whose sender died is retried once the claim lapses
+const claimMargin = time.Minute
+
+// Fanout 아웃박스 메시지를 구독별 전송으로 바꾸는 발행기 / Publisher that turns outbox messages into per-subscription deliveries
+// 같은 메시지가 다시 발행되어도 전송은 한 번만 생성 / A message published again creates its deliveries only once
+type Fanout struct {
+	repo Repository
+}
+
+// NewFanout 새 팬아웃 발행기 생성 / Create new fanout publisher
+func NewFanout(repo Repository) *Fanout {
+	return &Fanout{repo: repo}
+}
+
+// Publish 구독한 활성 구독마다 전송 생성 / Create a delivery for each active subscription wanting the event
+func (f *Fanout) Publish(_ context.Context, message *outbox.Message) error {
+	subscriptions, err := f.repo.ListActive()
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(message.Envelope())
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	now := time.Now()
+	var deliveries []*Delivery
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(message.EventType) {
+			continue
+		}
+		deliveries = append(deliveries, &Delivery{
+			SubscriptionID: subscription.ID,
+			MessageID:      message.ID,
+			EventType:      message.EventType,
+			Payload:        string(payload),
+			Status:         DeliveryPending,
+			NextAttemptAt:  now,
+		})
+	}
+	return f.repo.CreateDeliveries(deliveries)
+}
+
+// DispatcherConfig 전송 설정 / Dispatcher configuration
+type DispatcherConfig struct {
+	BatchSize   int
+	Workers     int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Dispatcher 대기 중인 전송을 수신자에게 POST / POSTs pending deliveries to their receivers
+type Dispatcher struct {
+	repo   Repository
+	client *http.Client
+	cfg    DispatcherConfig
+}
+
+// NewDispatcher 새 전송기 생성 / Create new dispatcher
+func NewDispatcher(repo Repository, client *http.Client, cfg DispatcherConfig) *Dispatcher {
+	return &Dispatcher{repo: repo, client: client, cfg: cfg}
+}
+
+// NewHTTPClient 리다이렉트를 따르지 않는 전송용 클라이언트 / Delivery client that does not follow redirects
+// 리다이렉트 응답은 실패한 시도로 기록됨 / A redirect response is recorded as a failed attempt
+// allowPrivate가 false면 내부망 주소로 연결하지 않고, 프록시는 연결 주소를 가리므로 쓰지 않음
+// Unless allowPrivate is set, it refuses to connect to internal addresses and skips proxies, which would hide the dialed address
+func NewHTTPClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivate {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: dialControl}
+		transport.DialContext = dialer.DialContext
+		transport.Proxy = nil
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// RunOnce 발송할 전송 한 배치를 작업자들이 나눠 보냄 / Send one batch of due deliveries across the workers
+func (d *Dispatcher) RunOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.repo.ListDueDeliveries(time.Now(), d.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	subscriptions := make(map[uint]*Subscription)
+	for _, delivery := range deliveries {
+		if _, ok := subscriptions[delivery.SubscriptionID]; ok {
+			continue
+		}
+		subscription, err := d.repo.GetByID(delivery.SubscriptionID)
+		if err != nil {
+			return 0, err
+		}
+		subscriptions[delivery.SubscriptionID] = subscription
+	}
+
+	queue := make(chan *Delivery)
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		firstErr  error
+	)
+	for range max(d.cfg.Workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range queue {
+				ok, err := d.deliver(ctx, subscriptions[delivery.SubscriptionID], delivery)
+				mu.Lock()
+				if ok {
+					succeeded++
+				}
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+		queue <- delivery
+	}
+	close(queue)
+	wg.Wait()
+
+	return succeeded, errors.Join(firstErr, ctx.Err())
+}
+
+// deliver 전송 한 건 시도 후 결과 저장 / Attempt one delivery and save the outcome
+func (d *Dispatcher) deliver(ctx context.Context, subscription *Subscription, delivery *Delivery) (bool, error) {
+	logger := zap.L().With(
+		zap.String("method", "webhook.Dispatcher.deliver"),
+		zap.Uint("subscription_id", subscription.ID),
+		zap.Uint("delivery_id", delivery.ID))
+
+	started := time.Now()
+	claimed, err := d.repo.ClaimDelivery(delivery.ID, started, started.Add(d.client.Timeout+claimMargin))
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		logger.Debug("Webhook delivery claimed by another dispatcher")
+		return false, nil
+	}
+
+	statusCode, sendErr := d.send(ctx, subscription, delivery, started)
+	attempt := &Attempt{
+		DeliveryID: delivery.ID,
+		StatusCode: statusCode,
+		DurationMS: time.Since(started).Milliseconds(),
+	}
+
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	if sendErr == nil {
+		now := time.Now()
+		delivery.Status = DeliverySucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	} else {
+		attempt.Error = truncate(sendErr.Error())
+		delivery.LastError = attempt.Error
+		delivery.NextAttemptAt = time.Now().Add(outbox.Backoff(d.cfg.BaseBackoff, d.cfg.MaxBackoff, delivery.Attempts))
+		if delivery.Attempts >= d.cfg.MaxAttempts {
+			delivery.Status = DeliveryFailed
+		}
+	}
+
+	if err := d.repo.RecordAttempt(delivery, attempt); err != nil {
+		return false, err
+	}
+
+	switch {
+	case sendErr == nil:
+		logger.Debug("Webhook delivered", zap.Int("status_code", statusCode))
+	case delivery.Status == DeliveryFailed:
+		logger.Error("Webhook delivery failed permanently", zap.Int("attempts", delivery.Attempts), zap.Error(sendErr))
+	default:
+		logger.Warn("Webhook delivery attempt failed", zap.Int("attempts", delivery.Attempts), zap.Error(sendErr))
+	}
+	return sendErr == nil, nil
+}
+
+// send 서명된 POST 요청 전송 / Send the signed POST request
+func (d *Dispatcher) send(ctx context.Context, subscription *Subscription, delivery *Delivery, now time.Time) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "spindle-webhooks")
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("receiver responded with status %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// truncate 오류 메시지 길이 제한 / Limit the error message length
+func truncate(message string) string {
+	if len(message) > maxAttemptErrorLength {
+		return message[:maxAttemptErrorLength]
+	}
+	return message
+}
@@ -0,0 +1,294 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// receiver 서명을 검증하고 요청을 세는 테스트 수신자 / Test receiver that verifies signatures and counts requests
+type receiver struct {
+	server   *httptest.Server
+	requests atomic.Int32
+	failures atomic.Int32
+	verified atomic.Int32
+	body     atomic.Value
+}
+
+func newReceiver(t *testing.T, failures int32) *receiver {
+	t.Helper()
+
+	r := &receiver{}
+	r.failures.Store(failures)
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.requests.Add(1)
+		body, _ := io.ReadAll(req.Body)
+		r.body.Store(body)
+		if Verify(testSecret, req.Header.Get(HeaderTimestamp), req.Header.Get(HeaderSignature), body, time.Minute, time.Now()) {
+			r.verified.Add(1)
+		}
+		if r.failures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func setupDispatcher(t *testing.T, url string, maxAttempts int) (*gorm.DB, Repository, *Dispatcher, *Subscription) {
+	t.Helper()
+
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	subscription := &Subscription{URL: url, EventTypes: EventTypes{"UserCreated"}, Secret: testSecret, Active: true}
+	require.NoError(t, repo.Create(subscription))
+
+	dispatcher := NewDispatcher(repo, NewHTTPClient(time.Second, true), DispatcherConfig{
+		BatchSize:   10,
+		Workers:     2,
+		MaxAttempts: maxAttempts,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  time.Hour,
+	})
+	return database, repo, dispatcher, subscription
+}
+
+func publish(t *testing.T, repo Repository, id uint, eventType string) {
+	t.Helper()
+
+	message, err := outbox.NewMessage("user", "7", eventType, map[string]string{"name": "Test User"})
+	require.NoError(t, err)
+	message.ID = id
+	require.NoError(t, NewFanout(repo).Publish(context.Background(), message))
+}
+
+// makeDue 재시도 대기 시간이 지난 것으로 처리 / Treat every retry delay as elapsed
+func makeDue(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	require.NoError(t, db.Model(&Delivery{}).Where("1 = 1").Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+}
+
+func TestFanout_Publish(t *testing.T) {
+	_, repo, _, subscription := setupDispatcher(t, "https://example.com/hook", 3)
+	require.NoError(t, repo.Create(&Subscription{URL: "https://example.com/other", EventTypes: EventTypes{"UserDeleted"}, Secret: testSecret, Active: true}))
+
+	publish(t, repo, 1, "UserCreated")
+	publish(t, repo, 1, "UserCreated") // 중계기의 재발행 / Republished by the relay
+	publish(t, repo, 2, "UserUpdated")
+
+	deliveries, total, err := repo.ListDeliveries(subscription.ID, 0, 10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+	require.Len(t, deliveries, 1)
+	assert.EqualValues(t, 1, deliveries[0].MessageID)
+
+	var envelope outbox.Envelope
+	require.NoError(t, json.Unmarshal([]byte(deliveries[0].Payload), &envelope))
+	assert.Equal(t, "UserCreated", envelope.EventType)
+	assert.JSONEq(t, `{"name":"Test User"}`, string(envelope.Payload))
+}
+
+func TestDispatcher_RetriesUntilDelivered(t *testing.T) {
+	target := newReceiver(t, 1)
+	database, repo, dispatcher, subscription := setupDispatcher(t, target.server.URL, 3)
+	publish(t, repo, 1, "UserCreated")
+
+	delivered, err := dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+
+	deliveries, _, err := repo.ListDeliveries(subscription.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, DeliveryPending, deliveries[0].Status)
+	assert.Equal(t, http.StatusServiceUnavailable, deliveries[0].LastStatusCode)
+	assert.True(t, deliveries[0].NextAttemptAt.After(time.Now()))
+
+	// 백오프 중에는 다시 보내지 않음 / Not sent again during the backoff
+	delivered, err = dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+	assert.EqualValues(t, 1, target.requests.Load())
+
+	makeDue(t, database)
+	delivered, err = dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	delivery, err := repo.GetDelivery(subscription.ID, deliveries[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, DeliverySucceeded, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.NotNil(t, delivery.DeliveredAt)
+	require.Len(t, delivery.AttemptLog, 2)
+	assert.Equal(t, http.StatusServiceUnavailable, delivery.AttemptLog[0].StatusCode)
+	assert.Equal(t, http.StatusNoContent, delivery.AttemptLog[1].StatusCode)
+
+	assert.EqualValues(t, 2, target.verified.Load())
+	assert.Equal(t, delivery.Payload, string(target.body.Load().([]byte)))
+}
+
+func TestDispatcher_FailsAfterMaxAttemptsAndRedelivers(t *testing.T) {
+	target := newReceiver(t, 2)
+	database, repo, dispatcher, subscription := setupDispatcher(t, target.server.URL, 2)
+	publish(t, repo, 1, "UserCreated")
+
+	for range 2 {
+		makeDue(t, database)
+		_, err := dispatcher.RunOnce(context.Background())
+		require.NoError(t, err)
+	}
+
+	deliveries, _, err := repo.ListDeliveries(subscription.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, DeliveryFailed, deliveries[0].Status)
+	assert.Equal(t, "receiver responded with status 503", deliveries[0].LastError)
+
+	// 실패한 전송은 재전송 요청 전까지 보내지 않음 / A failed delivery waits for a redelivery request
+	makeDue(t, database)
+	delivered, err := dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+
+	_, err = NewService(repo, testEventTypes, false).Redeliver(subscription.ID, deliveries[0].ID)
+	require.NoError(t, err)
+	delivered, err = dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.EqualValues(t, 3, target.requests.Load())
+}
+
+func TestDispatcher_SkipsInactiveSubscriptions(t *testing.T) {
+	target := newReceiver(t, 0)
+	_, repo, dispatcher, subscription := setupDispatcher(t, target.server.URL, 3)
+	publish(t, repo, 1, "UserCreated")
+
+	subscription.Active = false
+	require.NoError(t, repo.Update(subscription))
+
+	delivered, err := dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+	assert.Zero(t, target.requests.Load())
+}
+
+// claimingRepository 조회 직후 다른 인스턴스가 전송을 선점한 것처럼 동작 / Behaves as if another instance claimed the deliveries right after listing
+type claimingRepository struct {
+	Repository
+}
+
+func (r claimingRepository) ListDueDeliveries(now time.Time, limit int) ([]*Delivery, error) {
+	deliveries, err := r.Repository.ListDueDeliveries(now, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, delivery := range deliveries {
+		if _, err := r.ClaimDelivery(delivery.ID, now, now.Add(time.Minute)); err != nil {
+			return nil, err
+		}
+	}
+	return deliveries, nil
+}
+
+func TestDispatcher_SkipsClaimedDeliveries(t *testing.T) {
+	target := newReceiver(t, 0)
+	_, repo, dispatcher, subscription := setupDispatcher(t, target.server.URL, 3)
+	dispatcher.repo = claimingRepository{Repository: repo}
+	publish(t, repo, 1, "UserCreated")
+
+	delivered, err := dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+	assert.Zero(t, target.requests.Load())
+
+	deliveries, _, err := repo.ListDeliveries(subscription.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Zero(t, deliveries[0].Attempts)
+	assert.Equal(t, DeliveryPending, deliveries[0].Status)
+}
+
+func TestRepository_ClaimDelivery(t *testing.T) {
+	_, repo, _, subscription := setupDispatcher(t, "https://example.com/hook", 3)
+	publish(t, repo, 1, "UserCreated")
+	deliveries, _, err := repo.ListDeliveries(subscription.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	now := time.Now()
+	claimed, err := repo.ClaimDelivery(deliveries[0].ID, now, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// 선점 기간 동안은 다시 선점하거나 조회되지 않음 / Neither claimed again nor listed while the claim lasts
+	claimed, err = repo.ClaimDelivery(deliveries[0].ID, now, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, claimed)
+	due, err := repo.ListDueDeliveries(now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+}
+
+func TestDispatcher_RefusesPrivateAddresses(t *testing.T) {
+	target := newReceiver(t, 0)
+	_, repo, dispatcher, subscription := setupDispatcher(t, target.server.URL, 3)
+	dispatcher.client = NewHTTPClient(time.Second, false)
+	publish(t, repo, 1, "UserCreated")
+
+	// 저장된 URL이 내부망으로 해석되어도 연결 시 거부 / Refused at dial time even when a stored URL resolves inside the network
+	delivered, err := dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+	assert.Zero(t, target.requests.Load())
+
+	deliveries, _, err := repo.ListDeliveries(subscription.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Contains(t, deliveries[0].LastError, ErrBlockedAddress.Error())
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"id":1}`)
+	signature := Sign(testSecret, now.Unix(), body)
+	timestamp := func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) }
+
+	testCases := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      []byte
+		want      bool
+	}{
+		{name: "valid", secret: testSecret, timestamp: timestamp(now), signature: signature, body: body, want: true},
+		{name: "wrong secret", secret: "another-secret-value", timestamp: timestamp(now), signature: signature, body: body},
+		{name: "tampered body", secret: testSecret, timestamp: timestamp(now), signature: signature, body: []byte(`{"id":2}`)},
+		{name: "stale timestamp", secret: testSecret, timestamp: timestamp(now.Add(-time.Hour)), signature: Sign(testSecret, now.Add(-time.Hour).Unix(), body), body: body},
+		{name: "malformed timestamp", secret: testSecret, timestamp: "yesterday", signature: signature, body: body},
+		{name: "missing prefix", secret: testSecret, timestamp: timestamp(now), signature: signature[len("sha256="):], body: body},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Verify(tc.secret, tc.timestamp, tc.signature, tc.body, 5*time.Minute, now))
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package webhook
+
+import "errors"
+
+var (
+	// ErrSubscriptionNotFound is returned when a subscription lookup cannot find a matching row.
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+
+	// ErrDeliveryNotFound is returned when a delivery does not exist for the subscription.
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+
+	// ErrBlockedAddress is returned when a delivery would connect to a loopback, link-local, private, or unspecified address.
+	ErrBlockedAddress = errors.New("webhook address is not allowed")
+)
+
+// ValidationError 요청 필드 검증 오류 / Request field validation error
+// Message는 클라이언트에 그대로 노출됨 / Message is returned to clients as-is
+type ValidationError struct {
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,283 @@
+// Package webhook provides outbound webhook subscriptions, signed deliveries and their handlers
+package webhook
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/resp"
+)
+
+// Handler 웹훅 HTTP 핸들러 / Webhook HTTP handler
+type Handler struct {
+	service Service
+}
+
+// NewHandler 새 웹훅 핸들러 생성 / Create new webhook handler
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// Create 구독 생성 / Create subscription
+// @Summary Create webhook subscription
+// @Description Subscribe a URL to user events. The signing secret is only returned here.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param subscription body CreateSubscriptionRequest true "Subscription creation request"
+// @Success 201 {object} resp.SuccessResponse{data=CreateSubscriptionResponse}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/webhooks [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req CreateSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return resp.BadRequest(c, "Invalid request body", err.Error())
+	}
+
+	subscription, err := h.service.Create(&req)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			return resp.BadRequest(c, validationErr.Message)
+		}
+		zap.L().Error("Failed to create webhook subscription", zap.Error(err))
+		return resp.InternalServerError(c, "Failed to create webhook subscription")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp.SuccessResponse{
+		Data: CreateSubscriptionResponse{Subscription: subscription, Secret: subscription.Secret},
+	})
+}
+
+// List 구독 목록 조회 / List subscriptions
+// @Summary List webhook subscriptions
+// @Tags webhooks
+// @Produce json
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination" default(20)
+// @Success 200 {object} resp.PaginatedResponse{data=[]Subscription}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/webhooks [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	var query ListQuery
+	if err := c.QueryParser(&query); err != nil {
+		return resp.BadRequest(c, "Invalid query parameters", err.Error())
+	}
+
+	subscriptions, total, err := h.service.List(&query)
+	if err != nil {
+		zap.L().Error("Failed to list webhook subscriptions", zap.Error(err))
+		return resp.InternalServerError(c, "Failed to list webhook subscriptions")
+	}
+
+	return resp.SuccessWithPagination(c, subscriptions, query.Offset, query.Limit, total)
+}
+
+// GetByID ID로 구독 조회 / Get subscription by ID
+// @Summary Get webhook subscription
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} resp.SuccessResponse{data=Subscription}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/webhooks/{id} [get]
+func (h *Handler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return resp.BadRequest(c, "Invalid subscription ID")
+	}
+
+	subscription, err := h.service.GetByID(uint(id))
+	if err != nil {
+		if errors.Is(err, ErrSubscriptionNotFound) {
+			return resp.NotFound(c, "Webhook subscription not found")
+		}
+		zap.L().Error("Failed to get webhook subscription", zap.Error(err), zap.Uint64("subscription_id", id))
+		return resp.InternalServerError(c, "Failed to get webhook subscription")
+	}
+
+	return resp.Success(c, subscription)
+}
+
+// Update 구독 수정 / Update subscription
+// @Summary Update webhook subscription
+// @Description Update the URL, event types, secret or active flag; omitted fields are kept
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Param subscription body UpdateSubscriptionRequest true "Subscription update request"
+// @Success 200 {object} resp.SuccessResponse{data=Subscription}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/webhooks/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return resp.BadRequest(c, "Invalid subscription ID")
+	}
+
+	var req UpdateSubscriptionRequest
+	if parseErr := c.BodyParser(&req); parseErr != nil {
+		return resp.BadRequest(c, "Invalid request body", parseErr.Error())
+	}
+
+	subscription, err := h.service.Update(uint(id), &req)
+	if err != nil {
+		var validationErr *ValidationError
+		switch {
+		case errors.Is(err, ErrSubscriptionNotFound):
+			return resp.NotFound(c, "Webhook subscription not found")
+		case errors.As(err, &validationErr):
+			return resp.BadRequest(c, validationErr.Message)
+		}
+		zap.L().Error("Failed to update webhook subscription", zap.Error(err), zap.Uint64("subscription_id", id))
+		return resp.InternalServerError(c, "Failed to update webhook subscription")
+	}
+
+	return resp.Success(c, subscription)
+}
+
+// Delete 구독 삭제 / Delete subscription
+// @Summary Delete webhook subscription
+// @Description Delete the subscription together with its deliveries and attempts
+// @Tags webhooks
+// @Param id path int true "Subscription ID"
+// @Success 204
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/webhooks/{id} [delete]
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return resp.BadRequest(c, "Invalid subscription ID")
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		if errors.Is(err, ErrSubscriptionNotFound) {
+			return resp.NotFound(c, "Webhook subscription not found")
+		}
+		zap.L().Error("Failed to delete webhook subscription", zap.Error(err), zap.Uint64("subscription_id", id))
+		return resp.InternalServerError(c, "Failed to delete webhook subscription")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListDeliveries 전송 목록 조회 / List deliveries
+// @Summary List webhook deliveries
+// @Description List a subscription's deliveries, newest first
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination" default(20)
+// @Success 200 {object} resp.PaginatedResponse{data=[]Delivery}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/webhooks/{id}/deliveries [get]
+func (h *Handler) ListDeliveries(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return resp.BadRequest(c, "Invalid subscription ID")
+	}
+
+	var query ListQuery
+	if err := c.QueryParser(&query); err != nil {
+		return resp.BadRequest(c, "Invalid query parameters", err.Error())
+	}
+
+	deliveries, total, err := h.service.ListDeliveries(uint(id), &query)
+	if err != nil {
+		if errors.Is(err, ErrSubscriptionNotFound) {
+			return resp.NotFound(c, "Webhook subscription not found")
+		}
+		zap.L().Error("Failed to list webhook deliveries", zap.Error(err), zap.Uint64("subscription_id", id))
+		return resp.InternalServerError(c, "Failed to list webhook deliveries")
+	}
+
+	return resp.SuccessWithPagination(c, deliveries, query.Offset, query.Limit, total)
+}
+
+// GetDelivery 전송 조회 / Get delivery
+// @Summary Get webhook delivery
+// @Description Get a delivery with its attempt log
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Param deliveryId path int true "Delivery ID"
+// @Success 200 {object} resp.SuccessResponse{data=Delivery}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/webhooks/{id}/deliveries/{deliveryId} [get]
+func (h *Handler) GetDelivery(c *fiber.Ctx) error {
+	id, deliveryID, err := deliveryParams(c)
+	if err != nil {
+		return resp.BadRequest(c, err.Error())
+	}
+
+	delivery, err := h.service.GetDelivery(id, deliveryID)
+	if err != nil {
+		if errors.Is(err, ErrDeliveryNotFound) {
+			return resp.NotFound(c, "Webhook delivery not found")
+		}
+		zap.L().Error("Failed to get webhook delivery", zap.Error(err), zap.Uint("delivery_id", deliveryID))
+		return resp.InternalServerError(c, "Failed to get webhook delivery")
+	}
+
+	return resp.Success(c, delivery)
+}
+
+// Redeliver 재전송 / Redeliver
+// @Summary Redeliver webhook
+// @Description Queue the delivery to be sent again with a fresh retry budget
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Param deliveryId path int true "Delivery ID"
+// @Success 202 {object} resp.SuccessResponse{data=Delivery}
+// @Failure 400 {object} resp.ErrorResponse
+// @Failure 404 {object} resp.ErrorResponse
+// @Failure 500 {object} resp.ErrorResponse
+// @Router /v1/webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *Handler) Redeliver(c *fiber.Ctx) error {
+	id, deliveryID, err := deliveryParams(c)
+	if err != nil {
+		return resp.BadRequest(c, err.Error())
+	}
+
+	delivery, err := h.service.Redeliver(id, deliveryID)
+	if err != nil {
+		if errors.Is(err, ErrDeliveryNotFound) {
+			return resp.NotFound(c, "Webhook delivery not found")
+		}
+		zap.L().Error("Failed to redeliver webhook", zap.Error(err), zap.Uint("delivery_id", deliveryID))
+		return resp.InternalServerError(c, "Failed to redeliver webhook")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(resp.SuccessResponse{Data: delivery})
+}
+
+// deliveryParams 구독 ID와 전송 ID 파싱 / Parse the subscription and delivery IDs
+func deliveryParams(c *fiber.Ctx) (uint, uint, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return 0, 0, &ValidationError{Message: "Invalid subscription ID"}
+	}
+	deliveryID, err := strconv.ParseUint(c.Params("deliveryId"), 10, 32)
+	if err != nil {
+		return 0, 0, &ValidationError{Message: "Invalid delivery ID"}
+	}
+	return uint(id), uint(deliveryID), nil
+}
@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// EventTypes 구독한 이벤트 종류 목록 (JSON 텍스트로 저장) / Subscribed event types, stored as JSON text
+type EventTypes []string
+
+// Value 데이터베이스 저장용 JSON 인코딩 / Encode as JSON for storage
+func (e EventTypes) Value() (driver.Value, error) {
+	data, err := json.Marshal([]string(e))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event types: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan 데이터베이스 값 디코딩 / Decode a database value
+func (e *EventTypes) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	case nil:
+		*e = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported event types type %T", value)
+	}
+	return json.Unmarshal(data, (*[]string)(e))
+}
+
+// Subscription 웹훅 구독 모델 / Webhook subscription model
+type Subscription struct {
+	ID         uint       `json:"id" gorm:"primarykey"`
+	URL        string     `json:"url" gorm:"not null;size:2048"`
+	EventTypes EventTypes `json:"event_types" gorm:"type:text;not null"`
+	// Secret 서명 키, 생성 응답에서만 노출 / Signing key, only exposed in the create response
+	Secret    string    `json:"-" gorm:"not null;size:255"`
+	Active    bool      `json:"active" gorm:"not null;default:true"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName 테이블 이름 지정 / Specify table name
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Matches 이벤트 종류 구독 여부 / Report whether the subscription wants the event type
+func (s *Subscription) Matches(eventType string) bool {
+	return s.Active && slices.Contains(s.EventTypes, eventType)
+}
+
+// DeliveryStatus 전송 상태 / Delivery status
+type DeliveryStatus string
+
+const (
+	// DeliveryPending means the delivery is waiting for its first attempt or a retry.
+	DeliveryPending DeliveryStatus = "pending"
+	// DeliverySucceeded means the receiver answered with a 2xx status.
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed means every attempt failed; only a redelivery sends it again.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// Delivery 구독자 한 명에 대한 이벤트 전송 / Delivery of one event to one subscription
+type Delivery struct {
+	ID             uint           `json:"id" gorm:"primarykey"`
+	SubscriptionID uint           `json:"subscription_id" gorm:"not null;uniqueIndex:idx_webhook_deliveries_message,priority:1"`
+	MessageID      uint           `json:"message_id" gorm:"not null;uniqueIndex:idx_webhook_deliveries_message,priority:2"`
+	EventType      string         `json:"event_type" gorm:"not null;size:100"`
+	Payload        string         `json:"-" gorm:"type:text;not null"`
+	Status         DeliveryStatus `json:"status" gorm:"not null;size:20;default:'pending';index"`
+	Attempts       int            `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	LastStatusCode int            `json:"last_status_code,omitempty"`
+	LastError      string         `json:"last_error,omitempty" gorm:"size:1000"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+
+	// AttemptLog 시도 기록, 단건 조회에서만 채움 / Attempt records, only loaded for a single delivery
+	AttemptLog []Attempt `json:"attempt_log,omitempty" gorm:"foreignKey:DeliveryID"`
+}
+
+// TableName 테이블 이름 지정 / Specify table name
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// Attempt 전송 시도 기록 / Record of one delivery attempt
+type Attempt struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	DeliveryID uint      `json:"delivery_id" gorm:"not null;index"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty" gorm:"size:1000"`
+	DurationMS int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName 테이블 이름 지정 / Specify table name
+func (Attempt) TableName() string {
+	return "webhook_attempts"
+}
+
+// CreateSubscriptionRequest 구독 생성 요청 / Subscription creation request
+type CreateSubscriptionRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	// Secret 비우면 서버가 생성 / Generated by the server when empty
+	Secret string `json:"secret,omitempty"`
+	Active *bool  `json:"active,omitempty"`
+}
+
+// UpdateSubscriptionRequest 구독 수정 요청 (생략한 필드는 유지) / Subscription update request; omitted fields are kept
+type UpdateSubscriptionRequest struct {
+	URL        *string  `json:"url,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	Secret     *string  `json:"secret,omitempty"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
+// CreateSubscriptionResponse 서명 키를 포함한 생성 응답 / Create response including the signing secret
+type CreateSubscriptionResponse struct {
+	*Subscription
+	Secret string `json:"secret"`
+}
+
+// ListQuery 목록 조회 쿼리 / List query
+type ListQuery struct {
+	Offset int `query:"offset"`
+	Limit  int `query:"limit"`
+}
+
+// Validate 쿼리 파라미터 검증 및 기본값 설정 / Validate query parameters and set defaults
+func (q *ListQuery) Validate() {
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 20
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/middleware"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/module"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/scheduler"
+)
+
+func init() {
+	module.Register(module.Registration{Name: "webhook", New: NewModule})
+}
+
+// Module 웹훅 도메인 모듈 / Webhook domain module
+type Module struct {
+	module.Base
+	deps       module.Deps
+	repo       Repository
+	handler    *Handler
+	dispatcher *Dispatcher
+}
+
+// NewModule 새 웹훅 모듈 생성 / Create new webhook module
+// 구독 가능한 이벤트는 등록된 모든 모듈의 이벤트 유형 / Subscribable events are the event types of every registered module
+func NewModule(deps module.Deps) module.Module {
+	cfg := deps.Config
+	repo := NewRepository(deps.DB)
+	return &Module{
+		deps:    deps,
+		repo:    repo,
+		handler: NewHandler(NewService(repo, deps.EventTypes, cfg.WebhookAllowPrivate)),
+		dispatcher: NewDispatcher(repo, NewHTTPClient(cfg.WebhookTimeout, cfg.WebhookAllowPrivate), DispatcherConfig{
+			BatchSize:   cfg.WebhookBatchSize,
+			Workers:     cfg.WebhookWorkers,
+			MaxAttempts: cfg.WebhookMaxAttempts,
+			BaseBackoff: cfg.WebhookBaseBackoff,
+			MaxBackoff:  cfg.WebhookMaxBackoff,
+		}),
+	}
+}
+
+// Name 모듈 이름 / Module name
+func (m *Module) Name() string { return "webhook" }
+
+// Models 구독, 전송, 시도 모델 / Subscription, delivery, and attempt models
+func (m *Module) Models() []any {
+	return []any{&Subscription{}, &Delivery{}, &Attempt{}}
+}
+
+// RegisterRoutes 웹훅 라우트 등록 / Register webhook routes
+func (m *Module) RegisterRoutes(v1 fiber.Router) {
+	h := m.handler
+
+	read := middleware.RequireScopes("webhooks:read")
+	write := middleware.RequireScopes("webhooks:write")
+
+	webhooks := v1.Group("/webhooks")
+	webhooks.Get("/", read, h.List)                                            // GET /v1/webhooks
+	webhooks.Post("/", write, h.Create)                                        // POST /v1/webhooks
+	webhooks.Get("/:id", read, h.GetByID)                                      // GET /v1/webhooks/:id
+	webhooks.Put("/:id", write, h.Update)                                      // PUT /v1/webhooks/:id
+	webhooks.Delete("/:id", write, h.Delete)                                   // DELETE /v1/webhooks/:id
+	webhooks.Get("/:id/deliveries", read, h.ListDeliveries)                    // GET /v1/webhooks/:id/deliveries
+	webhooks.Get("/:id/deliveries/:deliveryId", read, h.GetDelivery)           // GET /v1/webhooks/:id/deliveries/:deliveryId
+	webhooks.Post("/:id/deliveries/:deliveryId/redeliver", write, h.Redeliver) // POST /v1/webhooks/:id/deliveries/:deliveryId/redeliver
+}
+
+// Jobs 전송 작업 / Delivery job
+func (m *Module) Jobs() []scheduler.Job {
+	return []scheduler.Job{{
+		Name:     "webhook.deliver",
+		Interval: m.deps.Config.WebhookDeliveryInterval,
+		Run: func(ctx context.Context) error {
+			_, err := m.dispatcher.RunOnce(ctx)
+			return err
+		},
+	}}
+}
+
+// Subscriber 전송이 켜져 있으면 아웃박스 메시지를 구독별 전송으로 팬아웃 / With delivery on, fan outbox messages out into deliveries
+func (m *Module) Subscriber() outbox.Publisher {
+	if m.deps.Config.WebhookDeliveryInterval <= 0 {
+		return nil
+	}
+	return NewFanout(m.repo)
+}
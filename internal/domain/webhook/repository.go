@@ -0,0 +1,207 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository 웹훅 저장소 인터페이스 / Webhook repository interface
+type Repository interface {
+	Create(subscription *Subscription) error
+	GetByID(id uint) (*Subscription, error)
+	List(offset, limit int) ([]*Subscription, int64, error)
+	// ListActive 활성 구독 전체 조회 / List every active subscription
+	ListActive() ([]*Subscription, error)
+	Update(subscription *Subscription) error
+	// Delete 구독과 전송 기록 삭제 / Delete the subscription and its deliveries
+	Delete(id uint) error
+
+	// CreateDeliveries 전송 생성, 같은 메시지의 중복은 무시 / Create deliveries, ignoring duplicates of the same message
+	CreateDeliveries(deliveries []*Delivery) error
+	// ListDueDeliveries 활성 구독의 재시도 시각이 지난 전송 조회 / List due pending deliveries of active subscriptions
+	ListDueDeliveries(now time.Time, limit int) ([]*Delivery, error)
+	// ClaimDelivery 아직 발송 대상이면 다음 시도 시각을 until로 미뤄 선점 / Claim a still-due delivery by pushing its next attempt to until
+	// 다른 인스턴스가 먼저 선점했으면 false / Reports false when another instance claimed it first
+	ClaimDelivery(id uint, now, until time.Time) (bool, error)
+	ListDeliveries(subscriptionID uint, offset, limit int) ([]*Delivery, int64, error)
+	// GetDelivery 시도 기록과 함께 조회 / Get a delivery with its attempt log
+	GetDelivery(subscriptionID, deliveryID uint) (*Delivery, error)
+	UpdateDelivery(delivery *Delivery) error
+	// RecordAttempt 시도 기록과 전송 상태를 함께 저장 / Save the attempt and the delivery state together
+	RecordAttempt(delivery *Delivery, attempt *Attempt) error
+}
+
+// repository 웹훅 저장소 구현체 / Webhook repository implementation
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository 새 웹훅 저장소 생성 / Create new webhook repository
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Create 구독 생성 / Create subscription
+func (r *repository) Create(subscription *Subscription) error {
+	if err := r.db.Create(subscription).Error; err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// GetByID ID로 구독 조회 / Get subscription by ID
+func (r *repository) GetByID(id uint) (*Subscription, error) {
+	var subscription Subscription
+	if err := r.db.First(&subscription, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	return &subscription, nil
+}
+
+// List 구독 목록 조회 / List subscriptions
+func (r *repository) List(offset, limit int) ([]*Subscription, int64, error) {
+	var total int64
+	if err := r.db.Model(&Subscription{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook subscriptions: %w", err)
+	}
+
+	var subscriptions []*Subscription
+	if err := r.db.Order("id").Offset(offset).Limit(limit).Find(&subscriptions).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	return subscriptions, total, nil
+}
+
+// ListActive 활성 구독 조회 / List active subscriptions
+func (r *repository) ListActive() ([]*Subscription, error) {
+	var subscriptions []*Subscription
+	if err := r.db.Where("active = ?", true).Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list active webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// Update 구독 수정 / Update subscription
+func (r *repository) Update(subscription *Subscription) error {
+	if err := r.db.Save(subscription).Error; err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// Delete 구독 삭제 / Delete subscription
+func (r *repository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		deliveries := tx.Model(&Delivery{}).Select("id").Where("subscription_id = ?", id)
+		if err := tx.Where("delivery_id IN (?)", deliveries).Delete(&Attempt{}).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook attempts: %w", err)
+		}
+		if err := tx.Where("subscription_id = ?", id).Delete(&Delivery{}).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+		}
+
+		result := tx.Delete(&Subscription{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete webhook subscription: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrSubscriptionNotFound
+		}
+		return nil
+	})
+}
+
+// CreateDeliveries 전송 생성 / Create deliveries
+func (r *repository) CreateDeliveries(deliveries []*Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(deliveries).Error; err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// ListDueDeliveries 발송할 전송 조회 / List deliveries due for sending
+func (r *repository) ListDueDeliveries(now time.Time, limit int) ([]*Delivery, error) {
+	var deliveries []*Delivery
+	if err := r.db.
+		Joins("JOIN webhook_subscriptions ON webhook_subscriptions.id = webhook_deliveries.subscription_id").
+		Where("webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ?", DeliveryPending, now).
+		Where("webhook_subscriptions.active = ?", true).
+		Order("webhook_deliveries.next_attempt_at, webhook_deliveries.id").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list due webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// ClaimDelivery 조건부 갱신으로 전송 선점 / Claim a delivery with a conditional update
+func (r *repository) ClaimDelivery(id uint, now, until time.Time) (bool, error) {
+	result := r.db.Model(&Delivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, DeliveryPending, now).
+		Update("next_attempt_at", until)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim webhook delivery: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ListDeliveries 구독의 전송 목록 조회 (최신순) / List a subscription's deliveries, newest first
+func (r *repository) ListDeliveries(subscriptionID uint, offset, limit int) ([]*Delivery, int64, error) {
+	query := r.db.Model(&Delivery{}).Where("subscription_id = ?", subscriptionID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	var deliveries []*Delivery
+	if err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+// GetDelivery 전송 조회 / Get delivery
+func (r *repository) GetDelivery(subscriptionID, deliveryID uint) (*Delivery, error) {
+	var delivery Delivery
+	if err := r.db.Preload("AttemptLog", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Where("subscription_id = ?", subscriptionID).First(&delivery, deliveryID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+// UpdateDelivery 전송 상태 저장 / Save delivery state
+func (r *repository) UpdateDelivery(delivery *Delivery) error {
+	if err := r.db.Omit("AttemptLog").Save(delivery).Error; err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// RecordAttempt 시도 기록 저장 / Save an attempt
+func (r *repository) RecordAttempt(delivery *Delivery, attempt *Attempt) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attempt).Error; err != nil {
+			return fmt.Errorf("failed to record webhook attempt: %w", err)
+		}
+		if err := tx.Omit("AttemptLog").Save(delivery).Error; err != nil {
+			return fmt.Errorf("failed to update webhook delivery: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,219 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// minSecretLength 클라이언트가 지정하는 서명 키 최소 길이 / Minimum length of a client-supplied secret
+	minSecretLength = 16
+	// generatedSecretBytes 서버가 생성하는 서명 키 바이트 수 / Bytes in a server-generated secret
+	generatedSecretBytes = 32
+	maxURLLength         = 2048
+)
+
+// Service 웹훅 서비스 인터페이스 / Webhook service interface
+type Service interface {
+	Create(req *CreateSubscriptionRequest) (*Subscription, error)
+	GetByID(id uint) (*Subscription, error)
+	List(query *ListQuery) ([]*Subscription, int64, error)
+	Update(id uint, req *UpdateSubscriptionRequest) (*Subscription, error)
+	Delete(id uint) error
+	ListDeliveries(id uint, query *ListQuery) ([]*Delivery, int64, error)
+	GetDelivery(id, deliveryID uint) (*Delivery, error)
+	// Redeliver 전송을 즉시 다시 보내도록 예약 (시도 횟수 초기화) / Schedule the delivery to be sent again now, resetting its attempts
+	Redeliver(id, deliveryID uint) (*Delivery, error)
+}
+
+// service 웹훅 서비스 구현체 / Webhook service implementation
+type service struct {
+	repo         Repository
+	eventTypes   []string
+	allowPrivate bool
+}
+
+// NewService 새 웹훅 서비스 생성 / Create new webhook service
+// eventTypes는 구독할 수 있는 이벤트 종류, allowPrivate는 내부망 URL 허용 여부
+// eventTypes lists the event types that can be subscribed to; allowPrivate accepts URLs pointing inside the network
+func NewService(repo Repository, eventTypes []string, allowPrivate bool) Service {
+	return &service{repo: repo, eventTypes: eventTypes, allowPrivate: allowPrivate}
+}
+
+// Create 구독 생성 / Create subscription
+func (s *service) Create(req *CreateSubscriptionRequest) (*Subscription, error) {
+	if err := s.validateURL(req.URL); err != nil {
+		return nil, err
+	}
+	if err := s.validateEventTypes(req.EventTypes); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	} else if err := validateSecret(secret); err != nil {
+		return nil, err
+	}
+
+	subscription := &Subscription{
+		URL:        req.URL,
+		EventTypes: slices.Compact(slices.Sorted(slices.Values(req.EventTypes))),
+		Secret:     secret,
+		Active:     req.Active == nil || *req.Active,
+	}
+	if err := s.repo.Create(subscription); err != nil {
+		return nil, err
+	}
+
+	zap.L().Info("Webhook subscription created",
+		zap.String("method", "webhook.Service.Create"),
+		zap.Uint("subscription_id", subscription.ID),
+		zap.String("url", subscription.URL))
+	return subscription, nil
+}
+
+// GetByID ID로 구독 조회 / Get subscription by ID
+func (s *service) GetByID(id uint) (*Subscription, error) {
+	return s.repo.GetByID(id)
+}
+
+// List 구독 목록 조회 / List subscriptions
+func (s *service) List(query *ListQuery) ([]*Subscription, int64, error) {
+	query.Validate()
+	return s.repo.List(query.Offset, query.Limit)
+}
+
+// Update 구독 수정 / Update subscription
+func (s *service) Update(id uint, req *UpdateSubscriptionRequest) (*Subscription, error) {
+	subscription, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := s.validateURL(*req.URL); err != nil {
+			return nil, err
+		}
+		subscription.URL = *req.URL
+	}
+	if req.EventTypes != nil {
+		if err := s.validateEventTypes(req.EventTypes); err != nil {
+			return nil, err
+		}
+		subscription.EventTypes = slices.Compact(slices.Sorted(slices.Values(req.EventTypes)))
+	}
+	if req.Secret != nil {
+		if err := validateSecret(*req.Secret); err != nil {
+			return nil, err
+		}
+		subscription.Secret = *req.Secret
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+
+	if err := s.repo.Update(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// Delete 구독 삭제 / Delete subscription
+func (s *service) Delete(id uint) error {
+	return s.repo.Delete(id)
+}
+
+// ListDeliveries 구독의 전송 목록 조회 / List a subscription's deliveries
+func (s *service) ListDeliveries(id uint, query *ListQuery) ([]*Delivery, int64, error) {
+	query.Validate()
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListDeliveries(id, query.Offset, query.Limit)
+}
+
+// GetDelivery 전송 조회 / Get delivery
+func (s *service) GetDelivery(id, deliveryID uint) (*Delivery, error) {
+	return s.repo.GetDelivery(id, deliveryID)
+}
+
+// Redeliver 재전송 예약 / Schedule a redelivery
+// 성공한 전송도 다시 보낼 수 있음 / Succeeded deliveries can be sent again too
+func (s *service) Redeliver(id, deliveryID uint) (*Delivery, error) {
+	delivery, err := s.repo.GetDelivery(id, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Status = DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	if err := s.repo.UpdateDelivery(delivery); err != nil {
+		return nil, err
+	}
+
+	zap.L().Info("Webhook redelivery scheduled",
+		zap.String("method", "webhook.Service.Redeliver"),
+		zap.Uint("subscription_id", id),
+		zap.Uint("delivery_id", deliveryID))
+	return delivery, nil
+}
+
+// validateEventTypes 구독 가능한 이벤트 종류인지 확인 / Check the event types can be subscribed to
+func (s *service) validateEventTypes(eventTypes []string) error {
+	if len(eventTypes) == 0 {
+		return &ValidationError{Message: "At least one event type is required"}
+	}
+	for _, eventType := range eventTypes {
+		if !slices.Contains(s.eventTypes, eventType) {
+			return &ValidationError{Message: fmt.Sprintf("Unsupported event type %q", eventType)}
+		}
+	}
+	return nil
+}
+
+// validateURL 내부망을 가리키지 않는 절대 http(s) URL인지 확인 / Check the URL is an absolute http(s) URL that does not point inside the network
+func (s *service) validateURL(raw string) error {
+	if raw == "" {
+		return &ValidationError{Message: "URL is required"}
+	}
+	if len(raw) > maxURLLength {
+		return &ValidationError{Message: fmt.Sprintf("URL must be at most %d characters", maxURLLength)}
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return &ValidationError{Message: "URL must be an absolute http or https URL"}
+	}
+	if !s.allowPrivate && blockedHost(parsed.Hostname()) {
+		return &ValidationError{Message: "URL must not point to a loopback, link-local, private, or unspecified address"}
+	}
+	return nil
+}
+
+// validateSecret 서명 키 길이 확인 / Check the secret length
+func validateSecret(secret string) error {
+	if len(secret) < minSecretLength {
+		return &ValidationError{Message: fmt.Sprintf("Secret must be at least %d characters", minSecretLength)}
+	}
+	return nil
+}
+
+// generateSecret 임의의 서명 키 생성 / Generate a random secret
+func generateSecret() (string, error) {
+	buf := make([]byte, generatedSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
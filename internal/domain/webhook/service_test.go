@@ -0,0 +1,144 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var testEventTypes = []string{"UserCreated", "UserUpdated", "UserDeleted"}
+
+const blockedURLMessage = "URL must not point to a loopback, link-local, private, or unspecified address"
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	database, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, database.AutoMigrate(&Subscription{}, &Delivery{}, &Attempt{}))
+	return database
+}
+
+func TestService_Create(t *testing.T) {
+	service := NewService(NewRepository(setupTestDB(t)), testEventTypes, false)
+
+	testCases := []struct {
+		name    string
+		request CreateSubscriptionRequest
+		wantErr string
+	}{
+		{name: "generated secret", request: CreateSubscriptionRequest{URL: "https://example.com/hook", EventTypes: []string{"UserCreated"}}},
+		{name: "own secret", request: CreateSubscriptionRequest{URL: "http://example.com/hook", EventTypes: []string{"UserDeleted"}, Secret: "0123456789abcdef"}},
+		{name: "missing url", request: CreateSubscriptionRequest{EventTypes: []string{"UserCreated"}}, wantErr: "URL is required"},
+		{name: "relative url", request: CreateSubscriptionRequest{URL: "/hook", EventTypes: []string{"UserCreated"}}, wantErr: "URL must be an absolute http or https URL"},
+		{name: "unsupported scheme", request: CreateSubscriptionRequest{URL: "ftp://example.com", EventTypes: []string{"UserCreated"}}, wantErr: "URL must be an absolute http or https URL"},
+		{name: "loopback address", request: CreateSubscriptionRequest{URL: "http://127.0.0.1:8080/hook", EventTypes: []string{"UserCreated"}}, wantErr: blockedURLMessage},
+		{name: "loopback ipv6", request: CreateSubscriptionRequest{URL: "http://[::1]/hook", EventTypes: []string{"UserCreated"}}, wantErr: blockedURLMessage},
+		{name: "localhost", request: CreateSubscriptionRequest{URL: "http://localhost/hook", EventTypes: []string{"UserCreated"}}, wantErr: blockedURLMessage},
+		{name: "metadata address", request: CreateSubscriptionRequest{URL: "http://169.254.169.254/latest/meta-data", EventTypes: []string{"UserCreated"}}, wantErr: blockedURLMessage},
+		{name: "private address", request: CreateSubscriptionRequest{URL: "https://10.0.0.5/hook", EventTypes: []string{"UserCreated"}}, wantErr: blockedURLMessage},
+		{name: "mapped private address", request: CreateSubscriptionRequest{URL: "https://[::ffff:192.168.1.1]/hook", EventTypes: []string{"UserCreated"}}, wantErr: blockedURLMessage},
+		{name: "unspecified address", request: CreateSubscriptionRequest{URL: "http://0.0.0.0/hook", EventTypes: []string{"UserCreated"}}, wantErr: blockedURLMessage},
+		{name: "this network address", request: CreateSubscriptionRequest{URL: "http://0.1.2.3/hook", EventTypes: []string{"UserCreated"}}, wantErr: blockedURLMessage},
+		{name: "carrier-grade nat metadata", request: CreateSubscriptionRequest{URL: "http://100.100.100.200/latest/meta-data", EventTypes: []string{"UserCreated"}}, wantErr: blockedURLMessage},
+		{name: "nat64 metadata address", request: CreateSubscriptionRequest{URL: "http://[64:ff9b::a9fe:a9fe]/latest/meta-data", EventTypes: []string{"UserCreated"}}, wantErr: blockedURLMessage},
+		{name: "6to4 loopback address", request: CreateSubscriptionRequest{URL: "http://[2002:7f00:1::]/hook", EventTypes: []string{"UserCreated"}}, wantErr: blockedURLMessage},
+		{name: "nat64 public address", request: CreateSubscriptionRequest{URL: "https://[64:ff9b::808:808]/hook", EventTypes: []string{"UserCreated"}}},
+		{name: "no event types", request: CreateSubscriptionRequest{URL: "https://example.com"}, wantErr: "At least one event type is required"},
+		{name: "unknown event type", request: CreateSubscriptionRequest{URL: "https://example.com", EventTypes: []string{"OrderPlaced"}}, wantErr: `Unsupported event type "OrderPlaced"`},
+		{name: "short secret", request: CreateSubscriptionRequest{URL: "https://example.com", EventTypes: []string{"UserCreated"}, Secret: "short"}, wantErr: "Secret must be at least 16 characters"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subscription, err := service.Create(&tc.request)
+			if tc.wantErr != "" {
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, tc.wantErr, validationErr.Message)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotZero(t, subscription.ID)
+			assert.True(t, subscription.Active)
+			assert.GreaterOrEqual(t, len(subscription.Secret), minSecretLength)
+			if tc.request.Secret != "" {
+				assert.Equal(t, tc.request.Secret, subscription.Secret)
+			}
+		})
+	}
+}
+
+func TestService_CreateAllowPrivate(t *testing.T) {
+	service := NewService(NewRepository(setupTestDB(t)), testEventTypes, true)
+
+	subscription, err := service.Create(&CreateSubscriptionRequest{URL: "http://localhost:9000/hook", EventTypes: []string{"UserCreated"}})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:9000/hook", subscription.URL)
+}
+
+func TestService_Update(t *testing.T) {
+	service := NewService(NewRepository(setupTestDB(t)), testEventTypes, false)
+
+	created, err := service.Create(&CreateSubscriptionRequest{URL: "https://example.com/hook", EventTypes: []string{"UserCreated"}})
+	require.NoError(t, err)
+
+	inactive := false
+	updated, err := service.Update(created.ID, &UpdateSubscriptionRequest{
+		EventTypes: []string{"UserUpdated", "UserCreated", "UserUpdated"},
+		Active:     &inactive,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, EventTypes{"UserCreated", "UserUpdated"}, updated.EventTypes)
+	assert.False(t, updated.Active)
+	assert.Equal(t, "https://example.com/hook", updated.URL)
+
+	stored, err := service.GetByID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, updated.EventTypes, stored.EventTypes)
+	assert.Equal(t, created.Secret, stored.Secret)
+
+	_, err = service.Update(created.ID, &UpdateSubscriptionRequest{URL: ptr("not a url")})
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+
+	_, err = service.Update(created.ID+1, &UpdateSubscriptionRequest{})
+	assert.ErrorIs(t, err, ErrSubscriptionNotFound)
+
+	require.NoError(t, service.Delete(created.ID))
+	assert.ErrorIs(t, service.Delete(created.ID), ErrSubscriptionNotFound)
+}
+
+func TestService_Redeliver(t *testing.T) {
+	database := setupTestDB(t)
+	repo := NewRepository(database)
+	service := NewService(repo, testEventTypes, false)
+
+	subscription, err := service.Create(&CreateSubscriptionRequest{URL: "https://example.com/hook", EventTypes: []string{"UserCreated"}})
+	require.NoError(t, err)
+	delivery := &Delivery{SubscriptionID: subscription.ID, MessageID: 1, EventType: "UserCreated", Payload: "{}", Status: DeliveryFailed, Attempts: 5}
+	require.NoError(t, repo.CreateDeliveries([]*Delivery{delivery}))
+
+	redelivered, err := service.Redeliver(subscription.ID, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, DeliveryPending, redelivered.Status)
+	assert.Zero(t, redelivered.Attempts)
+
+	// 다른 구독의 전송은 찾을 수 없음 / A delivery of another subscription is not found
+	_, err = service.Redeliver(subscription.ID+1, delivery.ID)
+	assert.ErrorIs(t, err, ErrDeliveryNotFound)
+
+	deliveries, total, err := service.ListDeliveries(subscription.ID, &ListQuery{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, DeliveryPending, deliveries[0].Status)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
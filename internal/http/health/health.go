@@ -4,6 +4,8 @@ package health
 // Health and readiness handlers
 
 import (
+	"context"
+
 	fiber "github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 
@@ -11,11 +13,22 @@ import (
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/resp"
 )
 
+// Check 준비 상태 검사 항목 / Readiness check
+type Check struct {
+	// Name 응답의 checks 키 / Key in the response checks
+	Name string
+	Run  func(ctx context.Context) error
+}
+
 // Handler 헬스 체크 핸들러 / Health check handler
-type Handler struct{ db *gorm.DB }
+type Handler struct {
+	db     *gorm.DB
+	checks []Check
+}
 
 // New 새로운 헬스 체크 핸들러 생성 / Create new health check handler
-func New(db *gorm.DB) *Handler { return &Handler{db: db} }
+// checks는 데이터베이스 확인 뒤 /ready에서 실행 / checks run in /ready after the database check
+func New(db *gorm.DB, checks ...Check) *Handler { return &Handler{db: db, checks: checks} }
 
 // Response 헬스 체크 응답 구조체 / Health check response structure
 type Response struct {
@@ -41,7 +54,7 @@ func (h *Handler) Health(c *fiber.Ctx) error {
 	})
 }
 
-// Ready checks DB ping.
+// Ready checks DB ping and module checks.
 // @Summary Readiness check
 // @Description Get service readiness status including dependencies
 // @Tags health
@@ -54,12 +67,26 @@ func (h *Handler) Ready(c *fiber.Ctx) error {
 	checks := make(map[string]string)
 
 	// 데이터베이스 연결 상태 확인 / Check database connection status
-	if err := db.HealthCheck(h.db); err != nil {
+	if err := db.HealthCheck(c.UserContext(), h.db); err != nil {
 		checks["database"] = "fail"
 		return resp.Error(c, fiber.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Service not ready", checks)
 	}
 	checks["database"] = "ok"
 
+	// 모듈 검사, 하나라도 실패하면 준비되지 않음 / Module checks; any failure means not ready
+	ready := true
+	for _, check := range h.checks {
+		if err := check.Run(c.UserContext()); err != nil {
+			checks[check.Name] = "fail"
+			ready = false
+			continue
+		}
+		checks[check.Name] = "ok"
+	}
+	if !ready {
+		return resp.Error(c, fiber.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Service not ready", checks)
+	}
+
 	return resp.Success(c, Response{
 		Status:  "ready",
 		Service: "fiber-gorm-starter",
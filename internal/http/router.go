@@ -9,11 +9,15 @@ import (
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/apikey"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/config"
-	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/domain/user"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/http/health"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/idempotency"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/jwt"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/metrics"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/middleware"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/module"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/resp"
 )
 
@@ -25,14 +29,17 @@ const (
 
 // Router HTTP 라우터 설정 / HTTP router configuration
 type Router struct {
-	app   *fiber.App
-	cfg   *config.Config
-	db    *gorm.DB
-	userH *user.Handler
+	app     *fiber.App
+	cfg     *config.Config
+	db      *gorm.DB
+	modules []module.Module
+	keys    apikey.Service
+	jwks    *jwt.JWKS
 }
 
 // NewRouter 새 라우터 생성 / Create new router
-func NewRouter(cfg *config.Config, db *gorm.DB) *Router {
+// 도메인 라우트, 준비 상태 검사, 메트릭은 modules가 제공 / Domain routes, readiness checks, and metrics come from modules
+func NewRouter(cfg *config.Config, database *gorm.DB, modules []module.Module) *Router {
 	// Fiber 앱 설정 / Fiber app configuration
 	app := fiber.New(fiber.Config{
 		AppName:      "spindle API", // 브랜딩 이름 사용 / Use branding name
@@ -40,26 +47,41 @@ func NewRouter(cfg *config.Config, db *gorm.DB) *Router {
 		WriteTimeout: writeTimeoutSeconds * time.Second,
 		IdleTimeout:  idleTimeoutSeconds * time.Second,
 		ServerHeader: "spindle",
+		// 클라이언트 IP는 middleware.ClientIP가 결정 / The client IP is resolved by middleware.ClientIP
+		EnableIPValidation: true,
 		// JSON 엔코더 최적화 옵션 (필요시 주석 해제) / JSON encoder optimization option (uncomment if needed)
 		// JSONEncoder: json.Marshal,   // 기본 encoding/json 사용 / Use default encoding/json
 		// JSONDecoder: json.Unmarshal, // goccy/go-json으로 교체 가능 / Can be replaced with goccy/go-json
 	})
 
-	// User 도메인 초기화 / Initialize User domain
-	userRepo := user.NewRepository(db)
-	userService := user.NewService(userRepo)
-	userHandler := user.NewHandler(userService)
+	var jwks *jwt.JWKS
+	if cfg.JWTJWKSFile != "" || cfg.JWTJWKSURL != "" {
+		jwks = jwt.NewJWKS(jwt.JWKSConfig{
+			File:    cfg.JWTJWKSFile,
+			URL:     cfg.JWTJWKSURL,
+			Refresh: cfg.JWTJWKSRefresh,
+		})
+	}
 
 	return &Router{
-		app:   app,
-		cfg:   cfg,
-		db:    db,
-		userH: userHandler,
+		app:     app,
+		cfg:     cfg,
+		db:      database,
+		modules: modules,
+		keys: apikey.NewService(apikey.NewStore(database), db.NewTxManager(database, db.TxConfig{
+			MaxAttempts: cfg.DBTxMaxAttempts,
+			Backoff:     cfg.DBTxRetryBackoff,
+		}), apikey.ServiceConfig{
+			CacheTTL:        cfg.APIKeyCacheTTL,
+			RotationOverlap: cfg.APIKeyRotationOverlap,
+		}),
+		jwks: jwks,
 	}
 }
 
 // Setup 라우터 설정 / Setup router
-func (r *Router) Setup() {
+// 범위를 선언하지 않은 /v1 라우트가 있으면 오류 / Fails when a /v1 route declares no scope
+func (r *Router) Setup() error {
 	// 패닉 복구 미들웨어 / Panic recovery middleware
 	r.app.Use(middleware.Recover())
 
@@ -69,22 +91,45 @@ func (r *Router) Setup() {
 	// 요청 ID 미들웨어 / Request ID middleware
 	r.app.Use(middleware.RequestID())
 
+	// 클라이언트 IP 미들웨어 (신뢰하는 프록시 뒤) / Client IP middleware (behind trusted proxies)
+	r.app.Use(middleware.ClientIP(r.cfg.TrustedProxies))
+
 	// 로깅 미들웨어 / Logging middleware
 	r.app.Use(middleware.RequestLogger())
 
 	// CORS 미들웨어 / CORS middleware
 	r.app.Use(middleware.CORS(r.cfg))
 
-	// API 키 미들웨어 (설정된 경우) / API key middleware (if configured)
-	if r.cfg.APIKey != "" {
-		r.app.Use(middleware.APIKey(r.cfg))
-	}
-
-	// 메트릭 미들웨어 (활성화된 경우) / Metrics middleware (if enabled)
+	// 메트릭 미들웨어 (활성화된 경우), 공개 라우트와 인증 실패도 집계 / Metrics middleware (if enabled); public routes and failed authentication are counted too
+	var prometheus *metrics.Prometheus
 	if r.cfg.MetricsEnabled {
-		prometheus := metrics.NewPrometheus()
+		prometheus = metrics.NewPrometheus()
 		r.app.Use(prometheus.Middleware())
+	}
+
+	// 요청 마감 시간 미들웨어 (스트리밍 라우트는 NoDeadline으로 제외) / Request deadline middleware (streaming routes opt out with NoDeadline)
+	r.app.Use(middleware.Deadline(r.cfg.RequestTimeout))
+
+	// 공개 라우트 (인증 미들웨어보다 먼저 등록) / Public routes (registered before the authentication middleware)
+	r.setupPublicRoutes()
+
+	// 인증 미들웨어 (설정된 경우) / Authentication middleware (if configured)
+	if r.cfg.AuthEnabled() {
+		var keys apikey.Verifier
+		if r.cfg.APIKeysEnabled {
+			keys = r.keys
+		}
+		var tokens jwt.Verifier
+		if r.cfg.JWTEnabled() {
+			tokens = r.newTokenVerifier()
+		}
+		r.app.Use(middleware.Authenticate(r.cfg, keys, tokens))
+	}
+
+	// 메트릭 엔드포인트 (인증 뒤에 등록) / Metrics endpoint (registered behind authentication)
+	if prometheus != nil {
 		prometheus.RegisterAt(r.app, "/metrics")
+		r.registerModuleMetrics()
 	}
 
 	// Health 체크 라우트 / Health check routes
@@ -105,11 +150,54 @@ func (r *Router) Setup() {
 
 	// 404 핸들러 / 404 handler
 	r.setup404Handler()
+
+	return middleware.CheckScopes(r.app, "/v1")
+}
+
+// newTokenVerifier JWT 검증기 생성 / Create JWT verifier
+func (r *Router) newTokenVerifier() jwt.Verifier {
+	cfg := jwt.Config{
+		Issuer:   r.cfg.JWTIssuer,
+		Audience: r.cfg.JWTAudience,
+		Leeway:   r.cfg.JWTLeeway,
+		JWKS:     r.jwks,
+	}
+	if r.cfg.JWTHMACSecret != "" {
+		cfg.Secret = []byte(r.cfg.JWTHMACSecret)
+	}
+
+	// 모듈이 확인기를 제공하면 폐기된 세션의 토큰 거부 / Reject tokens of revoked sessions when a module provides a checker
+	verifier := jwt.NewVerifier(cfg)
+	for _, m := range r.modules {
+		if s, ok := m.(module.SessionChecker); ok {
+			if sessions := s.SessionChecker(); sessions != nil {
+				verifier = jwt.NewSessionVerifier(verifier, sessions, r.cfg.AuthSessionCacheTTL)
+			}
+		}
+	}
+	return verifier
+}
+
+// setupPublicRoutes 모듈의 공개 라우트 설정 / Setup module public routes
+func (r *Router) setupPublicRoutes() {
+	v1 := r.app.Group("/v1")
+	for _, m := range r.modules {
+		if p, ok := m.(module.PublicRouter); ok {
+			p.RegisterPublicRoutes(v1)
+		}
+	}
 }
 
 // setupHealthRoutes 헬스 체크 라우트 설정 / Setup health check routes
 func (r *Router) setupHealthRoutes() {
-	healthHandler := health.New(r.db)
+	var checks []health.Check
+	for _, m := range r.modules {
+		checks = append(checks, m.HealthChecks()...)
+	}
+	if r.jwks != nil {
+		checks = append(checks, health.Check{Name: "jwks", Run: r.jwks.Check})
+	}
+	healthHandler := health.New(r.db, checks...)
 	r.app.Get("/health", healthHandler.Health)
 	r.app.Get("/ready", healthHandler.Ready)
 }
@@ -118,23 +206,42 @@ func (r *Router) setupHealthRoutes() {
 func (r *Router) setupV1Routes() {
 	v1 := r.app.Group("/v1")
 
-	// User 라우트 / User routes
-	users := v1.Group("/users")
-	users.Get("/", r.userH.List)         // GET /v1/users
-	users.Get("/:id", r.userH.GetByID)   // GET /v1/users/:id
-	users.Post("/", r.userH.Create)      // POST /v1/users
-	users.Put("/:id", r.userH.Update)    // PUT /v1/users/:id
-	users.Delete("/:id", r.userH.Delete) // DELETE /v1/users/:id
-
-	// 향후 확장 가능한 라우트들 / Future extensible routes
-	// auth := v1.Group("/auth")
-	// auth.Post("/login", authHandler.Login)
-	// auth.Post("/logout", authHandler.Logout)
-	// auth.Post("/refresh", authHandler.Refresh)
-
-	// protected := v1.Group("/protected")
-	// protected.Use(middleware.APIKey(r.cfg)) // API 키 인증 필요 / Requires API key authentication
-	// protected.Get("/admin", adminHandler.Dashboard)
+	// POST 요청 멱등성 키 처리 / Idempotency-Key handling for POST requests
+	v1.Use(middleware.Idempotency(idempotency.NewStore(r.db), r.cfg.IdempotencyTTL, r.cfg.IdempotencyMaxBody))
+
+	// 도메인 모듈 라우트 / Domain module routes
+	for _, m := range r.modules {
+		m.RegisterRoutes(v1)
+	}
+
+	// 관리자 라우트 (데이터베이스 API 키 사용 시) / Admin routes (when database API keys are on)
+	if r.cfg.APIKeysEnabled {
+		r.setupAdminRoutes(v1)
+	}
+}
+
+// setupAdminRoutes 관리자 라우트 설정 / Setup admin routes
+func (r *Router) setupAdminRoutes(v1 fiber.Router) {
+	keyHandler := apikey.NewHandler(r.keys, middleware.GrantedScopes)
+
+	read := middleware.RequireScopes("api-keys:read")
+	write := middleware.RequireScopes("api-keys:write")
+
+	keys := v1.Group("/admin/api-keys")
+	keys.Get("/", read, keyHandler.List)               // GET /v1/admin/api-keys
+	keys.Post("/", write, keyHandler.Create)           // POST /v1/admin/api-keys
+	keys.Get("/:id", read, keyHandler.GetByID)         // GET /v1/admin/api-keys/:id
+	keys.Post("/:id/revoke", write, keyHandler.Revoke) // POST /v1/admin/api-keys/:id/revoke
+	keys.Post("/:id/rotate", write, keyHandler.Rotate) // POST /v1/admin/api-keys/:id/rotate
+}
+
+// registerModuleMetrics 모듈 수집기 등록 / Register module collectors
+func (r *Router) registerModuleMetrics() {
+	for _, m := range r.modules {
+		if err := metrics.Register(m.Collectors()...); err != nil {
+			zap.L().Error("Failed to register module metrics", zap.String("module", m.Name()), zap.Error(err))
+		}
+	}
 }
 
 // setupPProfRoutes 프로파일링 라우트 설정 / Setup profiling routes
@@ -1,21 +1,42 @@
 package http
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/apikey"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/config"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/domain/user"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/http/health"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/jwt"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/middleware"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/module"
 )
 
 func TestRouter_PProfRoutesEnabledInDevelopment(t *testing.T) {
 	router := NewRouter(&config.Config{
 		Env:          "local",
 		PProfEnabled: true,
-	}, nil)
-	router.Setup()
+	}, nil, nil)
+	require.NoError(t, router.Setup())
 
 	resp, err := router.GetApp().Test(httptest.NewRequest("GET", "/debug/pprof/", nil), 5000)
 
@@ -27,11 +48,363 @@ func TestRouter_PProfRoutesDisabledOutsideDevelopment(t *testing.T) {
 	router := NewRouter(&config.Config{
 		Env:          "prod",
 		PProfEnabled: true,
-	}, nil)
-	router.Setup()
+	}, nil, nil)
+	require.NoError(t, router.Setup())
 
 	resp, err := router.GetApp().Test(httptest.NewRequest("GET", "/debug/pprof/", nil), 5000)
 
 	require.NoError(t, err)
 	assert.Equal(t, 404, resp.StatusCode)
 }
+
+func TestRouter_BatchRoutesMatchColonPaths(t *testing.T) {
+	cfg := &config.Config{Env: "prod"}
+	router := NewRouter(cfg, nil, []module.Module{user.NewModule(module.Deps{Config: cfg})})
+	require.NoError(t, router.Setup())
+
+	testCases := []struct {
+		path           string
+		expectedStatus int
+	}{
+		{path: "/v1/users:batch", expectedStatus: 400},
+		{path: "/v1/users:batchUpdate", expectedStatus: 400},
+		{path: "/v1/users:batchDelete", expectedStatus: 400},
+		{path: "/v1/users:unknown", expectedStatus: 404},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tc.path, strings.NewReader(`{"mode":"bogus","items":[]}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := router.GetApp().Test(req, 5000)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+type fakeModule struct {
+	module.Base
+	checkErr error
+}
+
+func (fakeModule) Name() string { return "fake" }
+
+func (fakeModule) RegisterRoutes(v1 fiber.Router) {
+	v1.Get("/fake", middleware.RequireScopes("fake:read"), func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+}
+
+func (m fakeModule) HealthChecks() []health.Check {
+	return []health.Check{{Name: "fake", Run: func(context.Context) error { return m.checkErr }}}
+}
+
+func TestRouter_Modules(t *testing.T) {
+	database, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		checkErr       error
+		path           string
+		expectedStatus int
+		expectedChecks map[string]interface{}
+	}{
+		{name: "module route", path: "/v1/fake", expectedStatus: 204},
+		{name: "module check passes", path: "/ready", expectedStatus: 200, expectedChecks: map[string]interface{}{"database": "ok", "fake": "ok"}},
+		{name: "module check fails", checkErr: errors.New("down"), path: "/ready", expectedStatus: 503, expectedChecks: map[string]interface{}{"database": "ok", "fake": "fail"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := NewRouter(&config.Config{Env: "prod"}, database, []module.Module{fakeModule{checkErr: tc.checkErr}})
+			require.NoError(t, router.Setup())
+
+			resp, err := router.GetApp().Test(httptest.NewRequest("GET", tc.path, nil), 5000)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+
+			if tc.expectedChecks != nil {
+				var payload struct {
+					Data struct {
+						Checks map[string]interface{} `json:"checks"`
+					} `json:"data"`
+					Error struct {
+						Details map[string]interface{} `json:"details"`
+					} `json:"error"`
+				}
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&payload))
+				checks := payload.Data.Checks
+				if checks == nil {
+					checks = payload.Error.Details
+				}
+				assert.Equal(t, tc.expectedChecks, checks)
+			}
+		})
+	}
+}
+
+func TestRouter_APIKeys(t *testing.T) {
+	database, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, database.AutoMigrate(&apikey.Key{}))
+
+	router := NewRouter(&config.Config{Env: "prod", APIKey: "bootstrap", APIKeysEnabled: true}, database, []module.Module{fakeModule{}})
+	require.NoError(t, router.Setup())
+
+	call := func(method, path, token, body string) (int, apikey.KeyWithToken) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := router.GetApp().Test(req, 5000)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var payload struct {
+			Data apikey.KeyWithToken `json:"data"`
+		}
+		if resp.StatusCode != fiber.StatusNoContent {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&payload))
+		}
+		return resp.StatusCode, payload.Data
+	}
+
+	status, created := call("POST", "/v1/admin/api-keys", "bootstrap", `{"name":"ci","scopes":["fake:read"]}`)
+	require.Equal(t, fiber.StatusCreated, status)
+	require.NotEmpty(t, created.Token)
+
+	status, _ = call("GET", "/v1/fake", created.Token, "")
+	assert.Equal(t, fiber.StatusNoContent, status)
+
+	status, rotated := call("POST", "/v1/admin/api-keys/"+strconv.FormatUint(uint64(created.ID), 10)+"/rotate", "bootstrap", `{"overlap":"1h"}`)
+	require.Equal(t, fiber.StatusCreated, status)
+
+	status, _ = call("GET", "/v1/fake", created.Token, "")
+	assert.Equal(t, fiber.StatusNoContent, status)
+	status, _ = call("GET", "/v1/fake", rotated.Token, "")
+	assert.Equal(t, fiber.StatusNoContent, status)
+
+	status, _ = call("POST", "/v1/admin/api-keys/"+strconv.FormatUint(uint64(created.ID), 10)+"/revoke", "bootstrap", "")
+	require.Equal(t, fiber.StatusOK, status)
+
+	status, _ = call("GET", "/v1/fake", created.Token, "")
+	assert.Equal(t, fiber.StatusUnauthorized, status)
+	status, _ = call("GET", "/v1/fake", rotated.Token, "")
+	assert.Equal(t, fiber.StatusNoContent, status)
+
+	// 키 관리 범위만 가진 키는 자신보다 넓은 키를 만들거나 교체할 수 없음 / A key holding only the admin scope cannot create or rotate a wider key
+	status, admin := call("POST", "/v1/admin/api-keys", "bootstrap", `{"name":"admin","scopes":["api-keys:write"]}`)
+	require.Equal(t, fiber.StatusCreated, status)
+	status, _ = call("POST", "/v1/admin/api-keys", admin.Token, `{"name":"root","scopes":["*"]}`)
+	assert.Equal(t, fiber.StatusForbidden, status)
+	status, _ = call("POST", "/v1/admin/api-keys/"+strconv.FormatUint(uint64(rotated.ID), 10)+"/rotate", admin.Token, "")
+	assert.Equal(t, fiber.StatusForbidden, status)
+	status, _ = call("POST", "/v1/admin/api-keys", admin.Token, `{"name":"writer","scopes":["api-keys:write"]}`)
+	assert.Equal(t, fiber.StatusCreated, status)
+}
+
+type unscopedModule struct{ module.Base }
+
+func (unscopedModule) Name() string { return "unscoped" }
+
+func (unscopedModule) RegisterRoutes(v1 fiber.Router) {
+	v1.Get("/open", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+}
+
+func TestRouter_Scopes(t *testing.T) {
+	database, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, database.AutoMigrate(&apikey.Key{}))
+
+	unscoped := NewRouter(&config.Config{Env: "prod"}, database, []module.Module{fakeModule{}, unscopedModule{}})
+	assert.EqualError(t, unscoped.Setup(), "routes without a declared scope: GET /v1/open")
+
+	cfg := &config.Config{Env: "prod", APIKeysEnabled: true}
+	router := NewRouter(cfg, database, []module.Module{fakeModule{}})
+	require.NoError(t, router.Setup())
+
+	_, token, err := apikey.NewService(apikey.NewStore(database), db.NewTxManager(database, db.TxConfig{}), apikey.ServiceConfig{}).
+		Create(t.Context(), &apikey.CreateKeyRequest{Name: "reader", Scopes: []string{"users:*"}}, nil)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/v1/fake", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := router.GetApp().Test(req, 5000)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	var payload struct {
+		Error struct {
+			Details struct {
+				RequiredScopes []string `json:"required_scopes"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&payload))
+	assert.Equal(t, []string{"fake:read"}, payload.Error.Details.RequiredScopes)
+}
+
+// hs256Token HS256 서명 토큰 / HS256-signed token
+func hs256Token(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestRouter_JWT(t *testing.T) {
+	database, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	const secret = "0123456789abcdef0123456789abcdef"
+	cfg := &config.Config{
+		Env:           "prod",
+		JWTIssuer:     "https://id.example.com/",
+		JWTAudience:   "spindle",
+		JWTHMACSecret: secret,
+		JWTJWKSFile:   t.TempDir() + "/missing.json",
+	}
+	router := NewRouter(cfg, database, []module.Module{fakeModule{}})
+	require.NoError(t, router.Setup())
+
+	claims := func(scope string) map[string]any {
+		return map[string]any{
+			"iss": cfg.JWTIssuer, "aud": cfg.JWTAudience, "sub": "user-42", "scope": scope,
+			"exp": time.Now().Add(time.Minute).Unix(),
+		}
+	}
+	call := func(path, token string) int {
+		req := httptest.NewRequest("GET", path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := router.GetApp().Test(req, 5000)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusNoContent, call("/v1/fake", hs256Token(t, secret, claims("fake:read"))))
+	assert.Equal(t, fiber.StatusForbidden, call("/v1/fake", hs256Token(t, secret, claims("users:read"))))
+	assert.Equal(t, fiber.StatusUnauthorized, call("/v1/fake", hs256Token(t, "another-secret-another-secret-xx", claims("fake:read"))))
+	assert.Equal(t, fiber.StatusUnauthorized, call("/v1/fake", ""))
+
+	// 키 집합을 읽을 수 없으면 준비되지 않음 / Not ready while the key set cannot be loaded
+	assert.Equal(t, fiber.StatusServiceUnavailable, call("/ready", hs256Token(t, secret, claims("fake:read"))))
+}
+
+// revokedSessions 폐기된 세션 목록 / Set of revoked sessions
+type revokedSessions map[string]bool
+
+func (r revokedSessions) SessionRevoked(_ context.Context, sessionID string) (bool, error) {
+	return r[sessionID], nil
+}
+
+type sessionModule struct {
+	fakeModule
+	sessions revokedSessions
+}
+
+func (m sessionModule) SessionChecker() jwt.SessionChecker { return m.sessions }
+
+func TestRouter_JWTSessions(t *testing.T) {
+	database, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	const secret = "0123456789abcdef0123456789abcdef"
+	cfg := &config.Config{Env: "local", JWTHMACSecret: secret}
+	router := NewRouter(cfg, database, []module.Module{sessionModule{sessions: revokedSessions{"logged-out": true}}})
+	require.NoError(t, router.Setup())
+
+	testCases := []struct {
+		name           string
+		sessionID      string
+		expectedStatus int
+	}{
+		{name: "active session", sessionID: "active", expectedStatus: fiber.StatusNoContent},
+		{name: "revoked session", sessionID: "logged-out", expectedStatus: fiber.StatusUnauthorized},
+		{name: "no session claim", expectedStatus: fiber.StatusNoContent},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token := hs256Token(t, secret, map[string]any{
+				"sub": "7", "scope": "fake:read", "sid": tc.sessionID,
+				"exp": time.Now().Add(time.Minute).Unix(),
+			})
+			req := httptest.NewRequest("GET", "/v1/fake", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			resp, err := router.GetApp().Test(req, 5000)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+type publicModule struct {
+	module.Base
+}
+
+func (publicModule) Name() string { return "public" }
+
+func (publicModule) RegisterPublicRoutes(v1 fiber.Router) {
+	v1.Post("/login", middleware.Public(), func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+	v1.Get("/ip", middleware.Public(), func(c *fiber.Ctx) error { return c.SendString(middleware.GetClientIP(c)) })
+}
+
+func TestRouter_PublicRoutes(t *testing.T) {
+	database, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	router := NewRouter(&config.Config{Env: "prod", APIKey: "bootstrap"}, database, []module.Module{fakeModule{}, publicModule{}})
+	require.NoError(t, router.Setup())
+
+	call := func(method, path string) int {
+		resp, err := router.GetApp().Test(httptest.NewRequest(method, path, nil), 5000)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+
+	// 공개 라우트는 인증 없이 호출, 나머지 /v1 라우트는 그대로 보호 / Public routes need no credentials while the rest of /v1 stays protected
+	assert.Equal(t, fiber.StatusNoContent, call("POST", "/v1/login"))
+	assert.Equal(t, fiber.StatusUnauthorized, call("GET", "/v1/fake"))
+}
+
+func TestRouter_TrustedProxies(t *testing.T) {
+	testCases := []struct {
+		name           string
+		trustedProxies []string
+		wantIP         string
+	}{
+		{name: "no trusted proxies", wantIP: "0.0.0.0"},
+		{name: "trusted proxy", trustedProxies: []string{"0.0.0.0"}, wantIP: "10.0.0.1"},
+		{name: "trusted proxy chain", trustedProxies: []string{"0.0.0.0", "10.0.0.0/8"}, wantIP: "203.0.113.7"},
+		{name: "untrusted proxy", trustedProxies: []string{"10.0.0.1"}, wantIP: "0.0.0.0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := NewRouter(&config.Config{Env: "local", TrustedProxies: tc.trustedProxies}, nil, []module.Module{publicModule{}})
+			require.NoError(t, router.Setup())
+
+			// 가장 왼쪽 항목은 클라이언트가 위조 가능 / The leftmost entry can be forged by the client
+			req := httptest.NewRequest("GET", "/v1/ip", nil)
+			req.Header.Set(fiber.HeaderXForwardedFor, "198.51.100.9, 203.0.113.7, 10.0.0.1")
+			resp, err := router.GetApp().Test(req, 5000)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantIP, string(body))
+		})
+	}
+}
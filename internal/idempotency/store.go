@@ -0,0 +1,107 @@
+// Package idempotency persists Idempotency-Key records so retried requests can be replayed
+package idempotency
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Record 멱등성 키 레코드 / Idempotency key record
+// StatusCode가 0이면 아직 처리 중 / A zero StatusCode means the request is still in progress
+type Record struct {
+	ID uint `gorm:"primarykey"`
+	// Actor 키를 사용한 요청 주체, 키는 주체마다 따로 존재 / Actor that used the key; keys are namespaced per actor
+	Actor       string `gorm:"not null;size:100;default:'';uniqueIndex:idx_idempotency_keys_actor_key,priority:1"`
+	Key         string `gorm:"column:idempotency_key;not null;size:255;uniqueIndex:idx_idempotency_keys_actor_key,priority:2"`
+	Fingerprint string `gorm:"not null;size:64"`
+	StatusCode  int    `gorm:"not null;default:0"`
+	ContentType string `gorm:"size:255"`
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time `gorm:"index;not null"`
+}
+
+// TableName 테이블 이름 지정 / Specify table name
+func (Record) TableName() string {
+	return "idempotency_keys"
+}
+
+// InProgress 처리 중 여부 / Report whether the original request has not completed yet
+func (r *Record) InProgress() bool {
+	return r.StatusCode == 0
+}
+
+// Store 멱등성 키 저장소 인터페이스 / Idempotency key store interface
+// 키는 요청 주체별로 구분되어 다른 주체의 응답을 재전송하지 않음 / Keys are scoped to an actor so one caller never replays another's response
+type Store interface {
+	// Begin 키 선점 시도, 이미 있으면 기존 레코드 반환 / Claim a key or return the existing record
+	Begin(actor, key, fingerprint string, expiresAt time.Time) (*Record, bool, error)
+	Complete(actor, key string, statusCode int, contentType string, body []byte) error
+	Release(actor, key string) error
+	PurgeExpired(now time.Time) (int64, error)
+}
+
+// store GORM 기반 저장소 구현체 / GORM-backed store implementation
+type store struct {
+	db *gorm.DB
+}
+
+// NewStore 새 멱등성 키 저장소 생성 / Create new idempotency key store
+func NewStore(db *gorm.DB) Store {
+	return &store{db: db}
+}
+
+// Begin 키 선점 시도 / Try to claim a key
+// 유니크 인덱스 충돌 시 INSERT를 건너뛰어 동시 요청 중 하나만 선점 / Only one concurrent request wins via the unique index
+func (s *store) Begin(actor, key, fingerprint string, expiresAt time.Time) (*Record, bool, error) {
+	record := &Record{Actor: actor, Key: key, Fingerprint: fingerprint, ExpiresAt: expiresAt}
+
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return nil, false, fmt.Errorf("failed to claim idempotency key: %w", result.Error)
+	}
+	if result.RowsAffected == 1 {
+		return record, true, nil
+	}
+
+	var existing Record
+	if err := s.db.Where("actor = ? AND idempotency_key = ?", actor, key).First(&existing).Error; err != nil {
+		return nil, false, fmt.Errorf("failed to load idempotency key: %w", err)
+	}
+	return &existing, false, nil
+}
+
+// Complete 응답 저장 / Store the response for replay
+func (s *store) Complete(actor, key string, statusCode int, contentType string, body []byte) error {
+	err := s.db.Model(&Record{}).
+		Where("actor = ? AND idempotency_key = ?", actor, key).
+		Updates(map[string]interface{}{
+			"status_code":  statusCode,
+			"content_type": contentType,
+			"body":         body,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release 키 해제 (재시도 허용) / Release a key so the request can be retried
+func (s *store) Release(actor, key string) error {
+	if err := s.db.Where("actor = ? AND idempotency_key = ?", actor, key).Delete(&Record{}).Error; err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// PurgeExpired 만료된 키 삭제 / Delete expired keys
+func (s *store) PurgeExpired(now time.Time) (int64, error) {
+	result := s.db.Where("expires_at < ?", now).Delete(&Record{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge idempotency keys: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,106 @@
+// Package jwt verifies RS256, ES256 and HS256 bearer tokens against static secrets and cached JWKS key sets, and signs HS256 tokens
+package jwt
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// NumericDate Unix 초 단위 시각 / Time in Unix seconds
+type NumericDate struct {
+	time.Time
+}
+
+// NewNumericDate 초 단위로 자른 시각 / Time truncated to seconds
+func NewNumericDate(t time.Time) *NumericDate {
+	return &NumericDate{Time: t.Truncate(time.Second)}
+}
+
+// MarshalJSON Unix 초로 인코딩 / Encode as Unix seconds
+func (d NumericDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Unix())
+}
+
+// UnmarshalJSON 정수 또는 소수 Unix 초 디코딩 / Decode integer or fractional Unix seconds
+func (d *NumericDate) UnmarshalJSON(data []byte) error {
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return errors.New("numeric date must be a number")
+	}
+	whole, frac := math.Modf(seconds)
+	d.Time = time.Unix(int64(whole), int64(frac*float64(time.Second)))
+	return nil
+}
+
+// Audience 문자열 하나 또는 배열인 aud 클레임 / aud claim, either a single string or an array
+type Audience []string
+
+// UnmarshalJSON 문자열과 배열 모두 허용 / Accept both a string and an array
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return errors.New("aud must be a string or an array of strings")
+	}
+	*a = many
+	return nil
+}
+
+// Claims 등록된 클레임과 원본 클레임 / Registered claims plus the raw claim set
+type Claims struct {
+	Issuer    string       `json:"iss,omitempty"`
+	Subject   string       `json:"sub,omitempty"`
+	Audience  Audience     `json:"aud,omitempty"`
+	ExpiresAt *NumericDate `json:"exp,omitempty"`
+	NotBefore *NumericDate `json:"nbf,omitempty"`
+	IssuedAt  *NumericDate `json:"iat,omitempty"`
+	ID        string       `json:"jti,omitempty"`
+	// Scope 공백으로 구분한 범위 (RFC 8693) / Space-separated scopes (RFC 8693)
+	Scope string `json:"scope,omitempty"`
+	// SessionID 토큰을 발급한 세션 / Session the token was issued for
+	SessionID string `json:"sid,omitempty"`
+
+	// Raw 사용자 정의 클레임을 포함한 전체 클레임 / Every claim, including custom ones
+	Raw map[string]any `json:"-"`
+}
+
+// Scopes scope 클레임과 scp 배열을 합친 범위 / Scopes from the scope claim and the scp array
+func (c *Claims) Scopes() []string {
+	scopes := strings.Fields(c.Scope)
+	if scp, ok := c.Raw["scp"].([]any); ok {
+		for _, v := range scp {
+			if s, ok := v.(string); ok && s != "" {
+				scopes = append(scopes, s)
+			}
+		}
+	}
+	return slices.Compact(slices.Sorted(slices.Values(scopes)))
+}
+
+// validate 시각, 발급자, 대상 확인 / Check the time window, issuer and audience
+func (c *Claims) validate(now time.Time, cfg *Config) error {
+	if c.ExpiresAt == nil {
+		return invalid("token has no expiry")
+	}
+	if !now.Before(c.ExpiresAt.Add(cfg.Leeway)) {
+		return invalid("token is expired")
+	}
+	if c.NotBefore != nil && now.Add(cfg.Leeway).Before(c.NotBefore.Time) {
+		return invalid("token is not valid yet")
+	}
+	if cfg.Issuer != "" && c.Issuer != cfg.Issuer {
+		return invalid("token issuer is not accepted")
+	}
+	if cfg.Audience != "" && !slices.Contains(c.Audience, cfg.Audience) {
+		return invalid("token audience is not accepted")
+	}
+	return nil
+}
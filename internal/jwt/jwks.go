@@ -0,0 +1,336 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// minRSABits 허용하는 최소 RSA 키 길이 / Smallest accepted RSA modulus
+	minRSABits = 2048
+	// maxJWKSBytes JWKS 응답 최대 크기 / Largest accepted JWKS document
+	maxJWKSBytes = 1 << 20
+	// missRefreshInterval 모르는 kid로 인한 재조회 최소 간격 / Minimum interval between refreshes caused by unknown kids
+	missRefreshInterval = 30 * time.Second
+	// defaultRefresh 설정이 없을 때 재조회 주기 / Reload interval when none is configured
+	defaultRefresh = 10 * time.Minute
+	// fetchTimeout 한 번의 JWKS 조회 최대 시간 / Longest a single JWKS fetch may take
+	fetchTimeout = 10 * time.Second
+)
+
+// Key 서명 검증 키 / Signature verification key
+type Key struct {
+	ID string
+	// Algorithm JWK의 alg, 비어 있으면 키 유형에 맞는 알고리즘 모두 허용 / JWK alg; when empty, any algorithm matching the key type
+	Algorithm string
+	// Public *rsa.PublicKey, *ecdsa.PublicKey 또는 HMAC 비밀 []byte / *rsa.PublicKey, *ecdsa.PublicKey, or an HMAC secret []byte
+	Public any
+}
+
+// supports 알고리즘과 키 유형이 맞는지 확인 / Report whether the key can verify alg
+// 알고리즘 혼동 공격을 막기 위해 키 유형을 함께 확인 / The key type is checked too, to prevent algorithm confusion
+func (k *Key) supports(alg string) bool {
+	if k.Algorithm != "" && k.Algorithm != alg {
+		return false
+	}
+	switch k.Public.(type) {
+	case *rsa.PublicKey:
+		return alg == RS256
+	case *ecdsa.PublicKey:
+		return alg == ES256
+	case []byte:
+		return alg == HS256
+	default:
+		return false
+	}
+}
+
+// jwk JSON Web Key 필드 / JSON Web Key fields
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// ParseJWKS JWKS 문서에서 서명 키 추출, 지원하지 않는 키는 건너뜀 / Extract signing keys from a JWKS document, skipping unsupported keys
+func ParseJWKS(data []byte) ([]*Key, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to decode jwks: %w", err)
+	}
+
+	keys := make([]*Key, 0, len(set.Keys))
+	for _, raw := range set.Keys {
+		if raw.Use != "" && raw.Use != "sig" {
+			continue
+		}
+		key, err := raw.key()
+		if err != nil {
+			zap.L().Warn("Skipping JWKS key",
+				zap.String("method", "jwt.ParseJWKS"),
+				zap.String("kid", raw.Kid),
+				zap.Error(err))
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwks has no usable signing keys")
+	}
+	return keys, nil
+}
+
+// key JWK를 검증 키로 변환 / Convert the JWK into a verification key
+func (j *jwk) key() (*Key, error) {
+	key := &Key{ID: j.Kid, Algorithm: j.Alg}
+	switch j.Kty {
+	case "RSA":
+		n, err := decodeInt(j.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rsa modulus: %w", err)
+		}
+		e, err := decodeInt(j.E)
+		if err != nil || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid rsa exponent")
+		}
+		if n.BitLen() < minRSABits {
+			return nil, fmt.Errorf("rsa key must be at least %d bits", minRSABits)
+		}
+		key.Public = &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case "EC":
+		if j.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(j.X)
+		y, errY := base64.RawURLEncoding.DecodeString(j.Y)
+		if errX != nil || errY != nil || len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("invalid ec coordinates")
+		}
+		// ecdh가 점이 곡선 위에 있는지 확인 / ecdh checks the point is on the curve
+		if _, err := ecdh.P256().NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return nil, fmt.Errorf("invalid ec point: %w", err)
+		}
+		key.Public = &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(j.K)
+		if err != nil || len(secret) < MinSecretLength {
+			return nil, fmt.Errorf("hmac key must be at least %d bytes", MinSecretLength)
+		}
+		key.Public = secret
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", j.Kty)
+	}
+	return key, nil
+}
+
+func decodeInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid base64url integer")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// JWKSConfig 키 집합 설정, File과 URL 중 하나 / Key set settings; one of File or URL
+type JWKSConfig struct {
+	File string
+	URL  string
+	// Refresh 키 집합을 다시 읽는 주기 / How often the key set is reloaded
+	Refresh time.Duration
+	Client  *http.Client
+}
+
+// JWKS 파일 또는 URL에서 읽어 캐시하는 키 집합 / Key set loaded from a file or URL and cached
+// Refresh마다 다시 읽고, 모르는 kid가 오면 키 교체로 보고 즉시 다시 읽음 (30초에 한 번 이하)
+// Reloaded every Refresh, and at once when an unknown kid shows up, since that signals a key rotation (at most every 30s)
+// 다시 읽기에 실패하면 이전 키를 계속 사용 / A failed reload keeps serving the previous keys
+// 조회는 잠금 밖에서 한 번만 실행되고 동시 요청은 그 결과를 기다림 / A fetch runs once, outside the lock, and concurrent requests wait for it
+type JWKS struct {
+	cfg   JWKSConfig
+	group singleflight.Group
+
+	mu          sync.Mutex
+	keys        []*Key
+	loadedAt    time.Time
+	lastAttempt time.Time
+	lastErr     error
+	now         func() time.Time
+}
+
+// NewJWKS 새 키 집합 생성, 첫 사용 시 로드 / Create new key set; loaded on first use
+func NewJWKS(cfg JWKSConfig) *JWKS {
+	if cfg.Refresh <= 0 {
+		cfg.Refresh = defaultRefresh
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &JWKS{cfg: cfg, now: time.Now}
+}
+
+// Lookup kid와 알고리즘에 맞는 키 조회 / Look up keys matching kid and algorithm
+// kid가 비어 있으면 알고리즘이 맞는 모든 키 / With an empty kid, every key matching the algorithm
+// ctx가 끝나면 기다리기를 멈추지만 진행 중인 조회는 계속됨 / When ctx ends the wait stops, but a fetch in progress carries on
+func (s *JWKS) Lookup(ctx context.Context, kid, alg string) ([]*Key, error) {
+	keys, loadedAt, _ := s.snapshot()
+	reloaded := false
+	if keys == nil || s.now().Sub(loadedAt) >= s.cfg.Refresh {
+		if err := s.reload(ctx); err != nil {
+			return nil, err
+		}
+		reloaded = true
+	}
+
+	keys, _, lastErr := s.snapshot()
+	if keys == nil {
+		return nil, lastErr
+	}
+
+	matches := matchKeys(keys, kid, alg)
+	if len(matches) == 0 && kid != "" && !reloaded {
+		if err := s.reload(ctx); err != nil {
+			return nil, err
+		}
+		keys, _, _ = s.snapshot()
+		matches = matchKeys(keys, kid, alg)
+	}
+	return matches, nil
+}
+
+// snapshot 현재 키와 상태 / Current keys and state
+func (s *JWKS) snapshot() ([]*Key, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keys, s.loadedAt, s.lastErr
+}
+
+// Check 키 집합을 읽을 수 있는지 확인, 준비 상태 검사용 / Check the key set can be loaded; used by the readiness probe
+func (s *JWKS) Check(ctx context.Context) error {
+	_, err := s.Lookup(ctx, "", "")
+	return err
+}
+
+// reload 진행 중인 조회에 합류하거나 새로 시작해 끝날 때까지 대기 / Join the fetch in progress, or start one, and wait for it to finish
+func (s *JWKS) reload(ctx context.Context) error {
+	done := s.group.DoChan("jwks", func() (any, error) {
+		s.load()
+		return nil, nil
+	})
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("stopped waiting for jwks: %w", ctx.Err())
+	}
+}
+
+// load 키 집합 다시 읽기, 실패하면 이전 키 유지 / Reload the key set, keeping the previous keys on failure
+// 요청 ctx와 무관하게 자체 제한 시간으로 조회 / Fetches with its own timeout, independent of any request ctx
+func (s *JWKS) load() {
+	// 실패가 반복되어도 원본을 최소 간격보다 자주 읽지 않음 / Never read the source more often than the minimum interval, even while failing
+	s.mu.Lock()
+	now := s.now()
+	if !s.lastAttempt.IsZero() && now.Sub(s.lastAttempt) < missRefreshInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastAttempt = now
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	data, err := s.fetch(ctx)
+	var keys []*Key
+	if err == nil {
+		keys, err = ParseJWKS(data)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		s.keys = keys
+		s.loadedAt = now
+		s.lastErr = nil
+		return
+	}
+
+	s.lastErr = err
+	zap.L().Warn("Failed to load JWKS",
+		zap.String("method", "jwt.JWKS.load"),
+		zap.String("file", s.cfg.File),
+		zap.String("url", s.cfg.URL),
+		zap.Bool("serving_previous_keys", s.keys != nil),
+		zap.Error(err))
+}
+
+// fetch 파일 또는 URL에서 JWKS 문서 읽기 / Read the JWKS document from the file or URL
+func (s *JWKS) fetch(ctx context.Context) ([]byte, error) {
+	if s.cfg.File != "" {
+		data, err := os.ReadFile(s.cfg.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwks file: %w", err)
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.URL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build jwks request: %w", err)
+	}
+	res, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch jwks: unexpected status %d", res.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxJWKSBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks response: %w", err)
+	}
+	return data, nil
+}
+
+// matchKeys kid와 알고리즘이 맞는 키 / Keys matching kid and algorithm
+// 알고리즘이 비어 있으면 kid만 비교 / With an empty algorithm only kid is compared
+func matchKeys(keys []*Key, kid, alg string) []*Key {
+	var matches []*Key
+	for _, key := range keys {
+		if kid != "" && key.ID != kid {
+			continue
+		}
+		if alg != "" && !key.supports(alg) {
+			continue
+		}
+		matches = append(matches, key)
+	}
+	return matches
+}
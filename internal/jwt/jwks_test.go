@@ -0,0 +1,221 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jwkSet 공개 키로 JWKS 문서 생성, 키가 nil이면 제외 / Build a JWKS document from public keys, leaving out nil keys
+func jwkSet(t *testing.T, rsaKey *rsa.PrivateKey, rsaKid string, ecKey *ecdsa.PrivateKey, ecKid string) []byte {
+	t.Helper()
+
+	var keys []map[string]any
+	if rsaKey != nil {
+		keys = append(keys, map[string]any{
+			"kty": "RSA", "kid": rsaKid, "use": "sig",
+			"n": b64(rsaKey.N.Bytes()),
+			"e": b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+		})
+	}
+	if ecKey != nil {
+		keys = append(keys, map[string]any{
+			"kty": "EC", "kid": ecKid, "alg": ES256, "crv": "P-256",
+			"x": b64(ecKey.X.FillBytes(make([]byte, 32))),
+			"y": b64(ecKey.Y.FillBytes(make([]byte, 32))),
+		})
+	}
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	require.NoError(t, err)
+	return data
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// newTestJWKS 임시 파일에서 읽는 키 집합 / Key set read from a temporary file
+func newTestJWKS(t *testing.T, data []byte) *JWKS {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return NewJWKS(JWKSConfig{File: path})
+}
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		document string
+		wantKids []string
+		wantErr  bool
+	}{
+		{name: "rsa and ec", document: string(jwkSet(t, rsaKey, "rsa-1", ecKey, "ec-1")), wantKids: []string{"rsa-1", "ec-1"}},
+		{name: "weak rsa key", document: string(jwkSet(t, smallKey, "small", ecKey, "ec-1")), wantKids: []string{"ec-1"}},
+		{
+			name:     "encryption key and short secret",
+			document: `{"keys":[{"kty":"oct","kid":"enc","use":"enc","k":"` + b64(testSecret) + `"},{"kty":"oct","kid":"short","k":"c2hvcnQ"},{"kty":"oct","kid":"hs","k":"` + b64(testSecret) + `"}]}`,
+			wantKids: []string{"hs"},
+		},
+		{name: "point off curve", document: `{"keys":[{"kty":"EC","kid":"bad","crv":"P-256","x":"` + b64(make([]byte, 32)) + `","y":"` + b64(make([]byte, 32)) + `"}]}`, wantErr: true},
+		{name: "unsupported curve", document: `{"keys":[{"kty":"EC","kid":"p384","crv":"P-384","x":"AA","y":"AA"}]}`, wantErr: true},
+		{name: "not json", document: `keys`, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys, err := ParseJWKS([]byte(tc.document))
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var kids []string
+			for _, key := range keys {
+				kids = append(kids, key.ID)
+			}
+			assert.Equal(t, tc.wantKids, kids)
+		})
+	}
+}
+
+func TestJWKS_ReloadsOnUnknownKid(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var document atomic.Value
+	document.Store(jwkSet(t, oldKey, "2024-01", nil, ""))
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		_, _ = w.Write(document.Load().([]byte))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	jwks := NewJWKS(JWKSConfig{URL: server.URL, Refresh: time.Hour})
+	jwks.now = func() time.Time { return now }
+	v := NewVerifier(Config{JWKS: jwks})
+
+	_, err = v.Verify(t.Context(), sign(t, RS256, "2024-01", oldKey, validClaims(now)))
+	require.NoError(t, err)
+
+	// 발급자가 새 키로 교체 / The issuer rotates to a new key
+	document.Store(jwkSet(t, newKey, "2024-02", nil, ""))
+
+	// 첫 로드 직후에는 모르는 kid로 다시 읽지 않음 / No reload for an unknown kid right after the first load
+	_, err = v.Verify(t.Context(), sign(t, RS256, "2024-02", newKey, validClaims(now)))
+	require.ErrorIs(t, err, ErrInvalidToken)
+	assert.Equal(t, int32(1), fetches.Load())
+
+	now = now.Add(missRefreshInterval)
+	_, err = v.Verify(t.Context(), sign(t, RS256, "2024-02", newKey, validClaims(now)))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fetches.Load())
+
+	// 교체된 키는 더 이상 허용되지 않음 / The retired key is no longer accepted
+	_, err = v.Verify(t.Context(), sign(t, RS256, "2024-01", oldKey, validClaims(now)))
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestJWKS_KeepsKeysWhenReloadFails(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(jwkSet(t, rsaKey, "rsa-1", nil, ""))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	jwks := NewJWKS(JWKSConfig{URL: server.URL, Refresh: time.Minute})
+	jwks.now = func() time.Time { return now }
+	require.NoError(t, jwks.Check(t.Context()))
+
+	failing.Store(true)
+	now = now.Add(time.Hour)
+
+	keys, err := jwks.Lookup(t.Context(), "rsa-1", RS256)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func TestJWKS_ReportsFirstLoadFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := NewJWKS(JWKSConfig{URL: server.URL}).Check(t.Context())
+
+	assert.ErrorContains(t, err, "unexpected status 500")
+}
+
+func TestJWKS_FetchesOnceForConcurrentLookups(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		<-release
+		_, _ = w.Write(jwkSet(t, rsaKey, "rsa-1", nil, ""))
+	}))
+	defer server.Close()
+
+	jwks := NewJWKS(JWKSConfig{URL: server.URL})
+
+	// 끝난 요청은 기다리기를 멈추지만 조회는 계속됨 / A finished request stops waiting, but the fetch carries on
+	canceled, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = jwks.Lookup(canceled, "rsa-1", RS256)
+	require.ErrorIs(t, err, context.Canceled)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = jwks.Check(t.Context())
+		}()
+	}
+	close(release)
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), fetches.Load())
+}
@@ -0,0 +1,109 @@
+package jwt
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// sessionCacheMaxEntries 세션 확인 캐시 최대 항목 수 / Maximum entries in the session check cache
+const sessionCacheMaxEntries = 4096
+
+// SessionChecker 세션 폐기 여부 확인 인터페이스 / Interface reporting whether a session was revoked
+type SessionChecker interface {
+	// SessionRevoked sid의 세션이 폐기되었는지 확인, 모르는 세션은 false
+	// Report whether the session of a sid was revoked; unknown sessions report false
+	SessionRevoked(ctx context.Context, sessionID string) (bool, error)
+}
+
+// sessionVerifier 폐기된 세션의 토큰을 거부하는 검증기 / Verifier that rejects tokens of revoked sessions
+// 확인 결과는 ttl 동안 캐시되어 로그아웃은 최대 ttl 뒤에 반영 / Results are cached for ttl, so a logout takes effect within ttl
+type sessionVerifier struct {
+	next     Verifier
+	sessions SessionChecker
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]sessionEntry
+}
+
+type sessionEntry struct {
+	revoked   bool
+	expiresAt time.Time
+}
+
+// NewSessionVerifier sid 클레임의 세션이 폐기된 토큰을 거부하는 검증기 생성, ttl이 0이면 캐시하지 않음
+// Create a verifier that rejects tokens whose sid session was revoked; a zero ttl disables the cache
+func NewSessionVerifier(next Verifier, sessions SessionChecker, ttl time.Duration) Verifier {
+	return &sessionVerifier{
+		next:     next,
+		sessions: sessions,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[string]sessionEntry),
+	}
+}
+
+// Verify 토큰 검증 후 세션 확인 / Verify the token, then check its session
+func (v *sessionVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	claims, err := v.next.Verify(ctx, token)
+	if err != nil || claims.SessionID == "" {
+		return claims, err
+	}
+
+	revoked, err := v.revoked(ctx, claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, invalid("session revoked")
+	}
+	return claims, nil
+}
+
+// revoked 캐시 또는 저장소에서 폐기 여부 확인 / Check revocation from the cache or the store
+func (v *sessionVerifier) revoked(ctx context.Context, sessionID string) (bool, error) {
+	now := v.now()
+
+	v.mu.Lock()
+	entry, ok := v.entries[sessionID]
+	v.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.revoked, nil
+	}
+
+	revoked, err := v.sessions.SessionRevoked(ctx, sessionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check session: %w", err)
+	}
+	v.put(sessionID, revoked, now)
+	return revoked, nil
+}
+
+// put 결과 저장, 가득 차면 만료 항목부터 비우고 그래도 차 있으면 임의 항목 제거
+// Store a result; when full, drop expired entries first and then an arbitrary one
+func (v *sessionVerifier) put(sessionID string, revoked bool, now time.Time) {
+	if v.ttl <= 0 {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, ok := v.entries[sessionID]; !ok && len(v.entries) >= sessionCacheMaxEntries {
+		for id, entry := range v.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(v.entries, id)
+			}
+		}
+		for id := range v.entries {
+			if len(v.entries) < sessionCacheMaxEntries {
+				break
+			}
+			delete(v.entries, id)
+		}
+	}
+	v.entries[sessionID] = sessionEntry{revoked: revoked, expiresAt: now.Add(v.ttl)}
+}
@@ -0,0 +1,97 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSessions 폐기된 세션 목록과 조회 횟수 / Revoked sessions and a lookup counter
+type fakeSessions struct {
+	revoked map[string]bool
+	calls   int
+	err     error
+}
+
+func (f *fakeSessions) SessionRevoked(_ context.Context, sessionID string) (bool, error) {
+	f.calls++
+	return f.revoked[sessionID], f.err
+}
+
+func signSession(t *testing.T, sessionID string) string {
+	t.Helper()
+	token, err := SignHS256(&Claims{
+		Subject:   "7",
+		ExpiresAt: NewNumericDate(time.Now().Add(time.Minute)),
+		SessionID: sessionID,
+	}, testSecret)
+	require.NoError(t, err)
+	return token
+}
+
+func TestSessionVerifier(t *testing.T) {
+	testCases := []struct {
+		name      string
+		sessionID string
+		revoked   map[string]bool
+		wantErr   bool
+		wantCalls int
+	}{
+		{name: "active session", sessionID: "session-1", wantCalls: 1},
+		{name: "revoked session", sessionID: "session-1", revoked: map[string]bool{"session-1": true}, wantErr: true, wantCalls: 1},
+		{name: "no session claim", wantCalls: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sessions := &fakeSessions{revoked: tc.revoked}
+			v := NewSessionVerifier(NewVerifier(Config{Secret: testSecret}), sessions, time.Minute)
+
+			_, err := v.Verify(t.Context(), signSession(t, tc.sessionID))
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantCalls, sessions.calls)
+		})
+	}
+}
+
+func TestSessionVerifier_CachesResults(t *testing.T) {
+	sessions := &fakeSessions{revoked: map[string]bool{}}
+	v := NewSessionVerifier(NewVerifier(Config{Secret: testSecret}), sessions, time.Minute).(*sessionVerifier)
+	now := time.Now()
+	v.now = func() time.Time { return now }
+	token := signSession(t, "session-1")
+
+	for range 3 {
+		_, err := v.Verify(t.Context(), token)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, sessions.calls)
+
+	// TTL이 지나면 폐기가 반영 / Revocation takes effect once the TTL passes
+	sessions.revoked["session-1"] = true
+	_, err := v.Verify(t.Context(), token)
+	require.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	_, err = v.Verify(t.Context(), token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	assert.Equal(t, 2, sessions.calls)
+}
+
+func TestSessionVerifier_CheckError(t *testing.T) {
+	sessions := &fakeSessions{err: errors.New("database is down")}
+	v := NewSessionVerifier(NewVerifier(Config{Secret: testSecret}), sessions, time.Minute)
+
+	_, err := v.Verify(t.Context(), signSession(t, "session-1"))
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidToken)
+}
@@ -0,0 +1,28 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// hs256Header 인코딩된 HS256 헤더 / Encoded HS256 header
+var hs256Header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// SignHS256 클레임을 HS256으로 서명, Raw는 포함되지 않음 / Sign claims with HS256; Raw is not included
+func SignHS256(claims *Claims, secret []byte) (string, error) {
+	if len(secret) < MinSecretLength {
+		return "", fmt.Errorf("hmac secret must be at least %d bytes", MinSecretLength)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode claims: %w", err)
+	}
+
+	signed := hs256Header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,179 @@
+package jwt
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// 지원하는 서명 알고리즘 / Supported signing algorithms
+const (
+	RS256 = "RS256"
+	ES256 = "ES256"
+	HS256 = "HS256"
+)
+
+// MinSecretLength HMAC 비밀 최소 바이트 수 / Minimum HMAC secret length in bytes
+const MinSecretLength = 32
+
+// ErrInvalidToken is returned, wrapped with the reason, when a token is malformed, badly signed, expired, or for another issuer or audience.
+var ErrInvalidToken = errors.New("invalid token")
+
+func invalid(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidToken, reason)
+}
+
+// Config 검증 설정 / Verification settings
+type Config struct {
+	// Issuer 비어 있지 않으면 iss가 같아야 함 / When set, iss must equal it
+	Issuer string
+	// Audience 비어 있지 않으면 aud에 포함되어야 함 / When set, aud must contain it
+	Audience string
+	// Leeway 시계 오차 허용 범위 / Allowed clock skew
+	Leeway time.Duration
+	// Secret HS256 공유 비밀 (선택) / HS256 shared secret (optional)
+	Secret []byte
+	// JWKS RS256, ES256, HS256 키 집합 (선택) / RS256, ES256 and HS256 key set (optional)
+	JWKS *JWKS
+}
+
+// Verifier 토큰 검증 인터페이스 / Token verification interface
+type Verifier interface {
+	// Verify 서명과 클레임을 확인해 반환, 거부된 토큰은 ErrInvalidToken을 감쌈
+	// Check the signature and claims and return them; rejected tokens wrap ErrInvalidToken
+	Verify(ctx context.Context, token string) (*Claims, error)
+}
+
+// verifier 토큰 검증 구현체 / Token verifier implementation
+type verifier struct {
+	cfg Config
+	now func() time.Time
+}
+
+// NewVerifier 새 토큰 검증기 생성 / Create new token verifier
+func NewVerifier(cfg Config) Verifier {
+	return &verifier{cfg: cfg, now: time.Now}
+}
+
+// header JOSE 헤더 / JOSE header
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+// IsToken JWS compact 형식인지 확인 / Report whether the value has the JWS compact form
+func IsToken(token string) bool {
+	return strings.Count(token, ".") == 2 && strings.HasPrefix(token, "eyJ")
+}
+
+// Verify 토큰 검증 / Verify token
+func (v *verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalid("token must have three segments")
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, invalid("malformed header")
+	}
+	if h.Alg != RS256 && h.Alg != ES256 && h.Alg != HS256 {
+		return nil, invalid(fmt.Sprintf("unsupported algorithm %q", h.Alg))
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalid("malformed signature")
+	}
+
+	keys, err := v.keys(ctx, h.Kid, h.Alg)
+	if err != nil {
+		return nil, err
+	}
+	signed := []byte(parts[0] + "." + parts[1])
+	if !verifyAny(keys, h.Alg, signed, signature) {
+		return nil, invalid("signature does not match any key")
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, invalid("malformed claims")
+	}
+	if err := decodeSegment(parts[1], &claims.Raw); err != nil {
+		return nil, invalid("malformed claims")
+	}
+	if err := claims.validate(v.now(), &v.cfg); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+// keys 후보 키, 공유 비밀은 kid와 무관하게 HS256 후보 / Candidate keys; the shared secret is an HS256 candidate whatever the kid
+func (v *verifier) keys(ctx context.Context, kid, alg string) ([]*Key, error) {
+	var keys []*Key
+	if alg == HS256 && len(v.cfg.Secret) > 0 {
+		keys = append(keys, &Key{Algorithm: HS256, Public: v.cfg.Secret})
+	}
+	if v.cfg.JWKS != nil {
+		found, err := v.cfg.JWKS.Lookup(ctx, kid, alg)
+		if err != nil && len(keys) == 0 {
+			return nil, fmt.Errorf("failed to load signing keys: %w", err)
+		}
+		keys = append(keys, found...)
+	}
+	return keys, nil
+}
+
+// verifyAny 후보 키 중 하나라도 서명을 검증하는지 확인 / Report whether any candidate key verifies the signature
+func verifyAny(keys []*Key, alg string, signed, signature []byte) bool {
+	digest := sha256.Sum256(signed)
+	for _, key := range keys {
+		if !key.supports(alg) {
+			continue
+		}
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(public, crypto.SHA256, digest[:], signature) == nil {
+				return true
+			}
+		case *ecdsa.PublicKey:
+			// JWS ES256 서명은 r||s 각 32바이트 / A JWS ES256 signature is r||s, 32 bytes each
+			if len(signature) == 64 {
+				r := new(big.Int).SetBytes(signature[:32])
+				s := new(big.Int).SetBytes(signature[32:])
+				if ecdsa.Verify(public, digest[:], r, s) {
+					return true
+				}
+			}
+		case []byte:
+			mac := hmac.New(sha256.New, public)
+			mac.Write(signed)
+			if hmac.Equal(mac.Sum(nil), signature) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// decodeSegment base64url JSON 세그먼트 디코딩 / Decode a base64url JSON segment
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,196 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// sign 테스트용 토큰 서명 / Sign a token for tests
+func sign(t *testing.T, alg, kid string, key any, claims map[string]any) string {
+	t.Helper()
+
+	h := map[string]any{"alg": alg, "typ": "JWT"}
+	if kid != "" {
+		h["kid"] = kid
+	}
+	header, err := json.Marshal(h)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		require.NoError(t, err)
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case nil:
+	default:
+		t.Fatalf("unsupported key type %T", key)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// validClaims 기본 검증을 통과하는 클레임 / Claims passing the default checks
+func validClaims(now time.Time) map[string]any {
+	return map[string]any{
+		"iss":   "https://id.example.com/",
+		"sub":   "user-42",
+		"aud":   []string{"spindle", "other"},
+		"exp":   now.Add(time.Hour).Unix(),
+		"iat":   now.Unix(),
+		"scope": "users:read users:write",
+		"scp":   []string{"webhooks:read", "users:read"},
+		"org":   "acme",
+	}
+}
+
+func with(claims map[string]any, key string, value any) map[string]any {
+	claims[key] = value
+	return claims
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	now := time.Now()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwks := newTestJWKS(t, jwkSet(t, rsaKey, "rsa-1", ecKey, "ec-1"))
+
+	testCases := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "RS256", token: sign(t, RS256, "rsa-1", rsaKey, validClaims(now))},
+		{name: "ES256", token: sign(t, ES256, "ec-1", ecKey, validClaims(now))},
+		{name: "HS256 shared secret", token: sign(t, HS256, "", testSecret, validClaims(now))},
+		{name: "RS256 without kid", token: sign(t, RS256, "", rsaKey, validClaims(now))},
+		{name: "expired", token: sign(t, RS256, "rsa-1", rsaKey, with(validClaims(now), "exp", now.Add(-time.Minute).Unix())), wantErr: true},
+		{name: "expired within leeway", token: sign(t, RS256, "rsa-1", rsaKey, with(validClaims(now), "exp", now.Add(-10*time.Second).Unix()))},
+		{name: "no expiry", token: sign(t, RS256, "rsa-1", rsaKey, with(validClaims(now), "exp", nil)), wantErr: true},
+		{name: "not yet valid", token: sign(t, RS256, "rsa-1", rsaKey, with(validClaims(now), "nbf", now.Add(time.Hour).Unix())), wantErr: true},
+		{name: "wrong issuer", token: sign(t, RS256, "rsa-1", rsaKey, with(validClaims(now), "iss", "https://evil.example.com/")), wantErr: true},
+		{name: "wrong audience", token: sign(t, RS256, "rsa-1", rsaKey, with(validClaims(now), "aud", "other")), wantErr: true},
+		{name: "single audience string", token: sign(t, RS256, "rsa-1", rsaKey, with(validClaims(now), "aud", "spindle"))},
+		{name: "tampered payload", token: tamper(t, sign(t, RS256, "rsa-1", rsaKey, validClaims(now))), wantErr: true},
+		{name: "alg none", token: sign(t, "none", "", nil, validClaims(now)), wantErr: true},
+		{name: "unsupported alg", token: sign(t, "HS512", "", testSecret, validClaims(now)), wantErr: true},
+		{name: "wrong hmac secret", token: sign(t, HS256, "", []byte("another-secret-another-secret-xx"), validClaims(now)), wantErr: true},
+		{name: "ES256 key under RS256 kid", token: sign(t, ES256, "rsa-1", ecKey, validClaims(now)), wantErr: true},
+		{name: "malformed", token: "eyJhbGciOiJSUzI1NiJ9.not-json.sig", wantErr: true},
+	}
+
+	v := NewVerifier(Config{
+		Issuer:   "https://id.example.com/",
+		Audience: "spindle",
+		Leeway:   30 * time.Second,
+		Secret:   testSecret,
+		JWKS:     jwks,
+	})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, err := v.Verify(t.Context(), tc.token)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user-42", claims.Subject)
+			assert.Equal(t, "acme", claims.Raw["org"])
+			assert.Equal(t, []string{"users:read", "users:write", "webhooks:read"}, claims.Scopes())
+		})
+	}
+}
+
+// 공개 RSA 키를 HMAC 비밀로 쓰는 알고리즘 혼동 공격 / Algorithm confusion using the public RSA key as an HMAC secret
+func TestVerifier_RejectsAlgorithmConfusion(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	v := NewVerifier(Config{JWKS: newTestJWKS(t, jwkSet(t, rsaKey, "rsa-1", nil, ""))})
+
+	public := rsaKey.PublicKey.N.Bytes()
+	_, err = v.Verify(t.Context(), sign(t, HS256, "rsa-1", public, validClaims(time.Now())))
+
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestVerifier_ReportsUnavailableKeySet(t *testing.T) {
+	v := NewVerifier(Config{JWKS: NewJWKS(JWKSConfig{File: t.TempDir() + "/missing.json"})})
+
+	_, err := v.Verify(t.Context(), sign(t, HS256, "", testSecret, validClaims(time.Now())))
+
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrInvalidToken))
+}
+
+func TestIsToken(t *testing.T) {
+	assert.True(t, IsToken(sign(t, HS256, "", testSecret, validClaims(time.Now()))))
+	assert.False(t, IsToken("spk_0123456789ab_secret"))
+	assert.False(t, IsToken("plain-api-key"))
+}
+
+func tamper(t *testing.T, token string) string {
+	t.Helper()
+	payload, err := json.Marshal(with(validClaims(time.Now()), "sub", "admin"))
+	require.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	return parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+}
+
+func TestSignHS256(t *testing.T) {
+	now := time.Now()
+	token, err := SignHS256(&Claims{
+		Issuer:    "https://id.example.com/",
+		Subject:   "7",
+		Audience:  Audience{"spindle"},
+		ExpiresAt: NewNumericDate(now.Add(time.Minute)),
+		IssuedAt:  NewNumericDate(now),
+		Scope:     "users:read",
+		SessionID: "session-1",
+	}, testSecret)
+	require.NoError(t, err)
+
+	claims, err := NewVerifier(Config{Issuer: "https://id.example.com/", Audience: "spindle", Secret: testSecret}).Verify(t.Context(), token)
+	require.NoError(t, err)
+	assert.Equal(t, "7", claims.Subject)
+	assert.Equal(t, "session-1", claims.SessionID)
+	assert.Equal(t, []string{"users:read"}, claims.Scopes())
+
+	_, err = SignHS256(&Claims{}, []byte("short"))
+	assert.Error(t, err)
+}
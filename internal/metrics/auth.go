@@ -0,0 +1,11 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// LoginLockoutsTotal 로그인 잠금 횟수, kind는 account 또는 ip / Login lockouts; kind is account or ip
+var LoginLockoutsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "spindle",
+	Subsystem: "auth",
+	Name:      "lockouts_total",
+	Help:      "Total number of login lockouts by kind",
+}, []string{"kind"})
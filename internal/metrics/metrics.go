@@ -2,8 +2,11 @@
 package metrics
 
 import (
+	"errors"
+
 	"github.com/ansrivas/fiberprometheus/v2"
 	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Prometheus Prometheus 메트릭 래퍼 / Prometheus metrics wrapper
@@ -31,6 +34,21 @@ func (p *Prometheus) RegisterAt(app fiber.Router, url string, handlers ...fiber.
 	p.fiberPrometheus.RegisterAt(app, url, handlers...)
 }
 
+// Register 모듈 수집기를 기본 레지스트리에 등록 / Register module collectors with the default registry
+// 이미 등록된 수집기는 건너뜀 / Collectors that are already registered are skipped
+func Register(collectors ...prometheus.Collector) error {
+	for _, collector := range collectors {
+		if err := prometheus.Register(collector); err != nil {
+			var already prometheus.AlreadyRegisteredError
+			if errors.As(err, &already) {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
 // RegisterCustomMetrics 사용자 정의 메트릭 등록 / Register custom metrics
 // 향후 비즈니스 메트릭 추가 시 사용 / Use when adding business metrics in the future
 func (p *Prometheus) RegisterCustomMetrics() {
@@ -0,0 +1,28 @@
+package middleware
+
+import "github.com/gofiber/fiber/v2"
+
+// ActorContextKey 요청 주체 컨텍스트 키 / Request actor context key
+const ActorContextKey = "actor"
+
+// maxActorLength 감사 이벤트 actor 컬럼 길이 / Length of the audit event actor column
+const maxActorLength = 100
+
+const (
+	// APIKeyActor identifies requests authenticated with the bootstrap API key.
+	// Database keys are recorded as "api-key:<prefix>".
+	APIKeyActor = "api-key"
+	// JWTActor prefixes the subject of requests authenticated with a JWT, as in "jwt:<sub>".
+	JWTActor = "jwt"
+	// AnonymousActor identifies requests without an authenticated caller.
+	AnonymousActor = "anonymous"
+)
+
+// GetActor 컨텍스트에서 요청 주체 가져오기 / Get the request actor from context
+// 인증되지 않은 요청은 AnonymousActor 반환 / Returns AnonymousActor for unauthenticated requests
+func GetActor(c *fiber.Ctx) string {
+	if actor, ok := c.Locals(ActorContextKey).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
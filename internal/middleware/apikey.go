@@ -3,49 +3,63 @@ package middleware
 
 import (
 	"crypto/subtle"
+	"errors"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/apikey"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/config"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/jwt"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/resp"
 )
 
+// APIKeyContextKey 검증된 데이터베이스 키 컨텍스트 키 / Verified database key context key
+const APIKeyContextKey = "api_key"
+
 // APIKey API 키 인증 미들웨어 / API key authentication middleware
-func APIKey(cfg *config.Config) fiber.Handler {
+// cfg.APIKey는 모든 권한의 부트스트랩 키, 나머지 토큰은 keys로 검증 (nil이면 부트스트랩 키만)
+// cfg.APIKey is an all-access bootstrap key; other tokens are verified by keys (bootstrap key only when nil)
+func APIKey(cfg *config.Config, keys apikey.Verifier) fiber.Handler {
+	return Authenticate(cfg, keys, nil)
+}
+
+// Authenticate API 키 또는 JWT 인증 미들웨어 / API key or JWT authentication middleware
+// JWT 형식의 토큰은 tokens로, 나머지는 APIKey와 같이 검증 (nil이면 해당 방식 사용 안 함)
+// JWT-shaped tokens are verified by tokens and the rest as in APIKey (a nil verifier turns that method off)
+func Authenticate(cfg *config.Config, keys apikey.Verifier, tokens jwt.Verifier) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// API 키가 설정되지 않은 경우 스킵 / Skip if API key is not configured
-		if cfg.APIKey == "" {
+		// 인증이 설정되지 않은 경우 스킵 / Skip if authentication is not configured
+		if cfg.APIKey == "" && keys == nil && tokens == nil {
 			return c.Next()
 		}
 
-		// Authorization 헤더에서 API 키 추출 / Extract API key from Authorization header
+		// Authorization 헤더에서 토큰 추출 / Extract token from Authorization header
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
 			return resp.Unauthorized(c, "Missing authorization header")
 		}
 
 		// Bearer 토큰 형식 확인 / Check Bearer token format
-		apiKey, ok := bearerAPIKey(authHeader)
+		token, ok := bearerAPIKey(authHeader)
 		if !ok {
 			return resp.Unauthorized(c, "Invalid authorization header format")
 		}
 
-		// API 키 추출 및 검증 / Extract and validate API key
-		if !validAPIKey(apiKey, cfg.APIKey) {
-			return resp.Unauthorized(c, "Invalid API key")
+		if tokens != nil && jwt.IsToken(token) {
+			return authenticateJWT(c, tokens, token)
 		}
-
-		return c.Next()
+		return authenticate(c, cfg, keys, token)
 	}
 }
 
 // OptionalAPIKey 선택적 API 키 인증 미들웨어 / Optional API key authentication middleware
 // API 키가 제공되면 검증하지만 필수는 아님 / Validates API key if provided but not required
-func OptionalAPIKey(cfg *config.Config) fiber.Handler {
+func OptionalAPIKey(cfg *config.Config, keys apikey.Verifier) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// API 키가 설정되지 않은 경우 스킵 / Skip if API key is not configured
-		if cfg.APIKey == "" {
+		if cfg.APIKey == "" && keys == nil {
 			return c.Next()
 		}
 
@@ -61,15 +75,37 @@ func OptionalAPIKey(cfg *config.Config) fiber.Handler {
 			return resp.Unauthorized(c, "Invalid authorization header format")
 		}
 
-		// API 키 추출 및 검증 / Extract and validate API key
-		if !validAPIKey(apiKey, cfg.APIKey) {
-			return resp.Unauthorized(c, "Invalid API key")
-		}
-
 		// 인증된 사용자 표시 / Mark as authenticated user
 		c.Locals("authenticated", true)
+		return authenticate(c, cfg, keys, apiKey)
+	}
+}
+
+// authenticate 부트스트랩 키 또는 데이터베이스 키로 인증 후 다음 핸들러 호출 / Authenticate with the bootstrap key or a database key, then call the next handler
+func authenticate(c *fiber.Ctx, cfg *config.Config, keys apikey.Verifier, token string) error {
+	if validAPIKey(token, cfg.APIKey) {
+		c.Locals(ActorContextKey, APIKeyActor)
+		c.Locals(ScopesContextKey, []string{apikey.WildcardScope})
 		return c.Next()
 	}
+	if keys == nil || !apikey.IsToken(token) {
+		return resp.Unauthorized(c, "Invalid API key")
+	}
+
+	key, err := keys.Verify(c.UserContext(), token)
+	if err != nil {
+		if errors.Is(err, apikey.ErrInvalidKey) {
+			return resp.Unauthorized(c, "Invalid API key")
+		}
+		zap.L().Error("Failed to verify API key", zap.String("method", "middleware.APIKey"), zap.Error(err))
+		return resp.InternalServerError(c, "Failed to verify API key")
+	}
+
+	// 감사 기록에는 비밀이 아닌 접두사로 키를 식별 / The audit trail identifies the key by its non-secret prefix
+	c.Locals(ActorContextKey, APIKeyActor+":"+key.Prefix)
+	c.Locals(APIKeyContextKey, key)
+	c.Locals(ScopesContextKey, []string(key.Scopes))
+	return c.Next()
 }
 
 func bearerAPIKey(authHeader string) (string, bool) {
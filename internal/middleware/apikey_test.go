@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http/httptest"
 	"testing"
 
@@ -8,13 +10,29 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/apikey"
 	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/config"
 )
 
+// fakeVerifier 토큰별 키 또는 오류를 반환 / Return a key or error per token
+type fakeVerifier map[string]*apikey.Key
+
+func (f fakeVerifier) Verify(_ context.Context, token string) (*apikey.Key, error) {
+	key, ok := f[token]
+	if !ok {
+		return nil, apikey.ErrInvalidKey
+	}
+	if key == nil {
+		return nil, errors.New("database unavailable")
+	}
+	return key, nil
+}
+
 func TestAPIKeyAllowsValidBearerToken(t *testing.T) {
 	app := fiber.New()
-	app.Use(APIKey(&config.Config{APIKey: "expected-secret"}))
+	app.Use(APIKey(&config.Config{APIKey: "expected-secret"}, nil))
 	app.Get("/", func(c *fiber.Ctx) error {
+		assert.Equal(t, APIKeyActor, GetActor(c))
 		return c.SendStatus(fiber.StatusNoContent)
 	})
 
@@ -41,7 +59,7 @@ func TestAPIKeyRejectsMissingAndInvalidTokens(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			app := fiber.New()
-			app.Use(APIKey(&config.Config{APIKey: "expected-secret"}))
+			app.Use(APIKey(&config.Config{APIKey: "expected-secret"}, nil))
 			app.Get("/", func(c *fiber.Ctx) error {
 				return c.SendStatus(fiber.StatusNoContent)
 			})
@@ -61,7 +79,7 @@ func TestAPIKeyRejectsMissingAndInvalidTokens(t *testing.T) {
 
 func TestOptionalAPIKeyAllowsAnonymousAndRejectsInvalidToken(t *testing.T) {
 	app := fiber.New()
-	app.Use(OptionalAPIKey(&config.Config{APIKey: "expected-secret"}))
+	app.Use(OptionalAPIKey(&config.Config{APIKey: "expected-secret"}, nil))
 	app.Get("/", func(c *fiber.Ctx) error {
 		if c.Locals("authenticated") == true {
 			return c.SendStatus(fiber.StatusCreated)
@@ -88,3 +106,48 @@ func TestOptionalAPIKeyAllowsAnonymousAndRejectsInvalidToken(t *testing.T) {
 	require.NoError(t, invalidResp.Body.Close())
 	assert.Equal(t, fiber.StatusUnauthorized, invalidResp.StatusCode)
 }
+
+func TestAPIKeyVerifiesDatabaseKeys(t *testing.T) {
+	const (
+		valid  = "spk_0123456789ab_valid"
+		broken = "spk_0123456789ab_broken"
+	)
+	keys := fakeVerifier{
+		valid:  {ID: 7, Prefix: "0123456789ab", Scopes: apikey.Scopes{"users:read"}},
+		broken: nil,
+	}
+
+	testCases := []struct {
+		name       string
+		bootstrap  string
+		token      string
+		wantStatus int
+		wantActor  string
+	}{
+		{name: "database key", token: valid, wantStatus: fiber.StatusNoContent, wantActor: "api-key:0123456789ab"},
+		{name: "bootstrap key", bootstrap: "expected-secret", token: "expected-secret", wantStatus: fiber.StatusNoContent, wantActor: APIKeyActor},
+		{name: "database key alongside bootstrap", bootstrap: "expected-secret", token: valid, wantStatus: fiber.StatusNoContent, wantActor: "api-key:0123456789ab"},
+		{name: "unknown key", token: "spk_0123456789ab_unknown", wantStatus: fiber.StatusUnauthorized},
+		{name: "not a key", token: "wrong-secret", wantStatus: fiber.StatusUnauthorized},
+		{name: "verifier failure", token: broken, wantStatus: fiber.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(APIKey(&config.Config{APIKey: tc.bootstrap}, keys))
+			app.Get("/", func(c *fiber.Ctx) error {
+				assert.Equal(t, tc.wantActor, GetActor(c))
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			resp, err := app.Test(req)
+
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+		})
+	}
+}
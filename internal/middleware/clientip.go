@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// ClientIPContextKey 클라이언트 IP 컨텍스트 키 / Client IP context key
+const ClientIPContextKey = "client_ip"
+
+// ClientIP 클라이언트 IP 확인 미들웨어 / Client IP resolution middleware
+// 신뢰하는 프록시에서 온 요청만 X-Forwarded-For를 오른쪽부터 읽고, 신뢰하지 않는 첫 주소를 클라이언트로 봄.
+// 가장 왼쪽 항목은 클라이언트가 마음대로 쓸 수 있으므로 사용하지 않음.
+// X-Forwarded-For is read only from trusted proxies, right to left, and the first untrusted hop is the client.
+// The leftmost entries are written by the client and are never trusted.
+func ClientIP(trustedProxies []string) fiber.Handler {
+	trusted := parseTrustedProxies(trustedProxies)
+
+	return func(c *fiber.Ctx) error {
+		c.Locals(ClientIPContextKey, resolveClientIP(c, trusted))
+		return c.Next()
+	}
+}
+
+// GetClientIP 컨텍스트에서 클라이언트 IP 가져오기 / Get the client IP from context
+// ClientIP 미들웨어가 없으면 연결의 원격 주소 반환 / Returns the connection's remote address without the ClientIP middleware
+func GetClientIP(c *fiber.Ctx) string {
+	if ip, ok := c.Locals(ClientIPContextKey).(string); ok && ip != "" {
+		return ip
+	}
+	return c.Context().RemoteIP().String()
+}
+
+// resolveClientIP 전달 체인에서 신뢰하지 않는 가장 오른쪽 주소 / Rightmost untrusted address in the forwarding chain
+func resolveClientIP(c *fiber.Ctx, trusted []netip.Prefix) string {
+	remote := c.Context().RemoteIP().String()
+	if !isTrusted(trusted, remote) {
+		return remote
+	}
+
+	client := remote
+	hops := c.IPs()
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if _, err := netip.ParseAddr(hop); err != nil {
+			// 잘못된 항목 너머는 믿을 수 없음 / Nothing beyond a malformed entry can be trusted
+			break
+		}
+		client = hop
+		if !isTrusted(trusted, hop) {
+			break
+		}
+	}
+	return client
+}
+
+// isTrusted 신뢰하는 프록시 주소인지 확인 / Check whether an address belongs to a trusted proxy
+func isTrusted(trusted []netip.Prefix, ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies IP와 CIDR을 접두사로 변환 / Convert IPs and CIDRs to prefixes
+func parseTrustedProxies(trustedProxies []string) []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(trustedProxies))
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(proxy); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(proxy)
+		if err != nil {
+			zap.L().Warn("Ignoring invalid trusted proxy", zap.String("proxy", proxy))
+			continue
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes
+}
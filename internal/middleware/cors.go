@@ -10,8 +10,9 @@ import (
 // CORS CORS 미들웨어 설정 / CORS middleware configuration
 func CORS(cfg *config.Config) fiber.Handler {
 	corsConfig := cors.Config{
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization, X-Request-ID",
-		AllowMethods: "GET, POST, PUT, PATCH, DELETE, OPTIONS",
+		AllowHeaders:  "Origin, Content-Type, Accept, Authorization, X-Request-ID, If-Match, If-None-Match, Idempotency-Key",
+		AllowMethods:  "GET, POST, PUT, PATCH, DELETE, OPTIONS",
+		ExposeHeaders: "ETag, X-Request-ID, Idempotent-Replayed",
 	}
 
 	// 프로덕션 환경에서는 특정 도메인만 허용 / Allow only specific domains in production
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/resp"
+)
+
+// Deadline 요청별 마감 시간 미들웨어 / Per-request deadline middleware
+// c.UserContext()에 마감 시간을 걸고 서버 종료 시 취소하여 진행 중인 쿼리를 중단
+// Puts a deadline on c.UserContext() and cancels it on server shutdown so in-flight queries are aborted
+// 컨텍스트가 끝난 뒤의 서버 오류나 컨텍스트 오류는 마감 초과 시 504, 종료 시 503으로 변환
+// A server error or context error after the context ended becomes 504 on a missed deadline and 503 on shutdown
+// timeout이 0이면 종료 시 취소만 적용, 스트리밍 라우트는 NoDeadline으로 제외
+// A zero timeout only cancels on shutdown; streaming routes opt out with NoDeadline
+func Deadline(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var ctx context.Context
+		var cancel context.CancelFunc
+		if timeout > 0 {
+			ctx, cancel = context.WithTimeout(c.UserContext(), timeout)
+		} else {
+			ctx, cancel = context.WithCancel(c.UserContext())
+		}
+		defer cancel()
+
+		// RequestCtx의 Done은 서버 종료 시 닫힘 / RequestCtx's Done closes on server shutdown
+		shutdown := c.Context().Done()
+		go func() {
+			select {
+			case <-shutdown:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		c.SetUserContext(ctx)
+		err := c.Next()
+
+		// 드라이버마다 취소 오류가 달라 응답 상태와 컨텍스트로 판단 / Drivers report cancellation differently, so judge by status and context
+		if ctx.Err() == nil || !serverFailure(c, err) {
+			return err
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return resp.GatewayTimeout(c, "Request timed out")
+		}
+		return resp.ServiceUnavailable(c, "Server is shutting down")
+	}
+}
+
+// serverFailure 컨텍스트 오류이거나 최종 상태가 5xx인지 확인 / Report whether the result is a context error or ends with a 5xx status
+// 반환된 오류의 상태는 Fiber 기본 오류 처리기와 같이 계산 / The status of a returned error is worked out like Fiber's default error handler
+func serverFailure(c *fiber.Ctx, err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+	if err == nil {
+		return c.Response().StatusCode() >= fiber.StatusInternalServerError
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code >= fiber.StatusInternalServerError
+	}
+	return true
+}
+
+// NoDeadline 라우트에서 마감 시간과 종료 시 취소를 해제 / Lift the deadline and shutdown cancellation on a route
+// 핸들러 반환 후 스트리밍하는 응답(내보내기, SSE)이 Deadline의 취소에 끊기지 않도록 함
+// Keeps responses that stream after the handler returns (exports, SSE) from being cut off by Deadline's cancellation
+func NoDeadline() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.SetUserContext(context.WithoutCancel(c.UserContext()))
+		return c.Next()
+	}
+}
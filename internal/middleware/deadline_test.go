@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/resp"
+)
+
+func TestDeadline(t *testing.T) {
+	app := fiber.New()
+	app.Use(Deadline(50 * time.Millisecond))
+
+	// 쿼리처럼 컨텍스트가 끝날 때까지 기다린 뒤 실패 / Wait for the context to end like a query would, then fail
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return resp.InternalServerError(c, "Failed to get user")
+	})
+	app.Get("/slow-not-found", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return resp.NotFound(c, "User not found")
+	})
+	app.Get("/slow-bad-request", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return fiber.ErrBadRequest
+	})
+	app.Get("/slow-context-error", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return fmt.Errorf("failed to get user: %w", c.UserContext().Err())
+	})
+	app.Get("/fast", func(c *fiber.Ctx) error {
+		if _, ok := c.UserContext().Deadline(); !ok {
+			return resp.InternalServerError(c, "Missing deadline")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	app.Get("/broken", func(c *fiber.Ctx) error {
+		return resp.InternalServerError(c, "Failed to get user")
+	})
+	app.Get("/stream", NoDeadline(), func(c *fiber.Ctx) error {
+		if _, ok := c.UserContext().Deadline(); ok {
+			return resp.InternalServerError(c, "Unexpected deadline")
+		}
+		if c.UserContext().Done() != nil {
+			return resp.InternalServerError(c, "Unexpected cancellation")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	testCases := []struct {
+		name         string
+		path         string
+		expectedCode int
+		errorCode    string
+	}{
+		{name: "slow query times out", path: "/slow", expectedCode: fiber.StatusGatewayTimeout, errorCode: "GATEWAY_TIMEOUT"},
+		{name: "client error after deadline is kept", path: "/slow-not-found", expectedCode: fiber.StatusNotFound, errorCode: "NOT_FOUND"},
+		{name: "returned client error after deadline is kept", path: "/slow-bad-request", expectedCode: fiber.StatusBadRequest},
+		{name: "returned context error times out", path: "/slow-context-error", expectedCode: fiber.StatusGatewayTimeout, errorCode: "GATEWAY_TIMEOUT"},
+		{name: "fast request has a deadline", path: "/fast", expectedCode: fiber.StatusNoContent},
+		{name: "server error before deadline is kept", path: "/broken", expectedCode: fiber.StatusInternalServerError, errorCode: "INTERNAL_SERVER_ERROR"},
+		{name: "opted-out route has no deadline", path: "/stream", expectedCode: fiber.StatusNoContent},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := app.Test(httptest.NewRequest(fiber.MethodGet, tc.path, nil), -1)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedCode, res.StatusCode)
+			if tc.errorCode != "" {
+				assert.Equal(t, tc.errorCode, decodeErrorCode(t, res.Body))
+			}
+		})
+	}
+}
+
+func TestDeadline_CancelsOnShutdown(t *testing.T) {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(Deadline(0))
+
+	started := make(chan struct{})
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		close(started)
+		<-c.UserContext().Done()
+		return resp.InternalServerError(c, "Failed to get user")
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(ln) }()
+
+	type result struct {
+		res *http.Response
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		res, err := http.Get("http://" + ln.Addr().String() + "/slow")
+		done <- result{res: res, err: err}
+	}()
+
+	<-started
+	require.NoError(t, app.ShutdownWithTimeout(5*time.Second))
+
+	got := <-done
+	require.NoError(t, got.err)
+	defer got.res.Body.Close()
+	assert.Equal(t, fiber.StatusServiceUnavailable, got.res.StatusCode)
+	assert.Equal(t, "SERVICE_UNAVAILABLE", decodeErrorCode(t, got.res.Body))
+}
+
+func decodeErrorCode(t *testing.T, body io.Reader) string {
+	t.Helper()
+
+	var payload resp.ErrorResponse
+	require.NoError(t, json.NewDecoder(body).Decode(&payload))
+	return payload.Error.Code
+}
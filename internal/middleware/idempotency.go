@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/idempotency"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/resp"
+)
+
+const (
+	// IdempotencyKeyHeader 멱등성 키 요청 헤더 / Idempotency key request header
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader 저장된 응답 재전송 표시 헤더 / Header marking a replayed response
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// Idempotency POST 요청 멱등성 미들웨어 / Idempotency middleware for POST requests
+// 같은 키로 재시도하면 저장된 응답을 재전송하고, 다른 요청 본문이면 422 반환
+// Retries with the same key replay the stored response; a different request body gets 422
+// 키는 요청 주체별로 구분되므로 인증 미들웨어 뒤에 등록 / Keys are scoped to the request actor, so register this after authentication
+// maxBody보다 큰 응답 본문은 저장하지 않고 상태 코드만 재전송 / Response bodies over maxBody are not stored; only the status code is replayed
+func Idempotency(store idempotency.Store, ttl time.Duration, maxBody int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(IdempotencyKeyHeader)
+		if c.Method() != fiber.MethodPost || key == "" {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return resp.BadRequest(c, "Idempotency-Key must be at most 255 characters")
+		}
+
+		actor := GetActor(c)
+		fingerprint := requestFingerprint(c)
+		now := time.Now()
+
+		record, created, err := store.Begin(actor, key, fingerprint, now.Add(ttl))
+		if err == nil && !created && record.ExpiresAt.Before(now) {
+			// 만료된 키는 해제 후 다시 선점 / Release an expired key and claim it again
+			if err = store.Release(actor, key); err == nil {
+				record, created, err = store.Begin(actor, key, fingerprint, now.Add(ttl))
+			}
+		}
+		if err != nil {
+			zap.L().Error("Failed to claim idempotency key", zap.Error(err), zap.String("rid", GetRequestID(c)))
+			return resp.InternalServerError(c, "Failed to process Idempotency-Key")
+		}
+
+		if !created {
+			return replayIdempotentResponse(c, record, fingerprint)
+		}
+
+		err = c.Next()
+
+		// 서버 오류와 인가 전 거부는 저장하지 않고 키를 해제하여 재시도 허용
+		// Do not store server errors or responses refused before authorization; release the key for retries
+		status := c.Response().StatusCode()
+		if err != nil || !storableStatus(status) {
+			if releaseErr := store.Release(actor, key); releaseErr != nil {
+				zap.L().Error("Failed to release idempotency key", zap.Error(releaseErr))
+			}
+			return err
+		}
+
+		body := append([]byte(nil), c.Response().Body()...)
+		contentType := string(c.Response().Header.ContentType())
+		if len(body) > maxBody {
+			zap.L().Warn("Idempotent response body too large to store",
+				zap.Int("size", len(body)), zap.Int("max", maxBody), zap.String("rid", GetRequestID(c)))
+			body, contentType = nil, ""
+		}
+		if completeErr := store.Complete(actor, key, status, contentType, body); completeErr != nil {
+			zap.L().Error("Failed to store idempotent response", zap.Error(completeErr))
+		}
+
+		return nil
+	}
+}
+
+// storableStatus 재전송용으로 저장할 응답 상태인지 확인 / Report whether a response status is stored for replay
+// 401, 403은 라우트의 범위 검사가 핸들러 전에 거부한 응답 / 401 and 403 come from route scope checks refusing the request before the handler
+func storableStatus(status int) bool {
+	switch status {
+	case fiber.StatusUnauthorized, fiber.StatusForbidden:
+		return false
+	default:
+		return status < fiber.StatusInternalServerError
+	}
+}
+
+// replayIdempotentResponse 저장된 응답 재전송 / Replay a stored response
+func replayIdempotentResponse(c *fiber.Ctx, record *idempotency.Record, fingerprint string) error {
+	if record.Fingerprint != fingerprint {
+		return resp.UnprocessableEntity(c, "Idempotency-Key was already used with a different request")
+	}
+	if record.InProgress() {
+		return resp.Conflict(c, "A request with this Idempotency-Key is still in progress")
+	}
+
+	c.Set(IdempotentReplayedHeader, "true")
+	if record.ContentType != "" {
+		c.Set(fiber.HeaderContentType, record.ContentType)
+	}
+	return c.Status(record.StatusCode).Send(record.Body)
+}
+
+// requestFingerprint 메서드, 경로, 쿼리 문자열, 본문 기반 요청 지문 / Request fingerprint from method, path, query string, and body
+// dry_run처럼 쿼리로 동작이 바뀌므로 쿼리도 포함 / The query is included because parameters such as dry_run change the behavior
+func requestFingerprint(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method()))
+	hash.Write([]byte{0})
+	hash.Write([]byte(c.Path()))
+	hash.Write([]byte{0})
+	hash.Write(c.Request().URI().QueryString())
+	hash.Write([]byte{0})
+	hash.Write(c.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/idempotency"
+)
+
+func setupIdempotencyApp(t *testing.T, status int, calls *int) *fiber.App {
+	t.Helper()
+	return setupIdempotencyAppWithLimit(t, status, calls, 1<<20)
+}
+
+func setupIdempotencyAppWithLimit(t *testing.T, status int, calls *int, maxBody int) *fiber.App {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&idempotency.Record{}))
+
+	app := fiber.New()
+	// 테스트 헤더로 요청 주체 지정 / The test header sets the request actor
+	app.Use(func(c *fiber.Ctx) error {
+		if actor := c.Get("X-Test-Actor"); actor != "" {
+			c.Locals(ActorContextKey, actor)
+		}
+		return c.Next()
+	})
+	app.Use(Idempotency(idempotency.NewStore(db), time.Hour, maxBody))
+	app.Post("/users", func(c *fiber.Ctx) error {
+		*calls++
+		return c.Status(status).JSON(fiber.Map{"call": *calls})
+	})
+	return app
+}
+
+func postWithKey(t *testing.T, app *fiber.App, key, body string) (int, string, string) {
+	t.Helper()
+	return postPathWithKey(t, app, "/users", key, body)
+}
+
+func postPathWithKey(t *testing.T, app *fiber.App, path, key, body string) (int, string, string) {
+	t.Helper()
+	return postAsWithKey(t, app, "", path, key, body)
+}
+
+func postAsWithKey(t *testing.T, app *fiber.App, actor, path, key, body string) (int, string, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodPost, path, strings.NewReader(body))
+	if actor != "" {
+		req.Header.Set("X-Test-Actor", actor)
+	}
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(data), resp.Header.Get(IdempotentReplayedHeader)
+}
+
+func TestIdempotencyReplaysStoredResponse(t *testing.T) {
+	calls := 0
+	app := setupIdempotencyApp(t, fiber.StatusCreated, &calls)
+
+	status, body, replayed := postWithKey(t, app, "key-1", `{"name":"a"}`)
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Empty(t, replayed)
+
+	status, replayBody, replayed := postWithKey(t, app, "key-1", `{"name":"a"}`)
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Equal(t, body, replayBody)
+	assert.Equal(t, "true", replayed)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyRejectsKeyReuseWithDifferentBody(t *testing.T) {
+	calls := 0
+	app := setupIdempotencyApp(t, fiber.StatusCreated, &calls)
+
+	status, _, _ := postWithKey(t, app, "key-1", `{"name":"a"}`)
+	require.Equal(t, fiber.StatusCreated, status)
+
+	status, _, _ = postWithKey(t, app, "key-1", `{"name":"b"}`)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyRejectsKeyReuseWithDifferentQuery(t *testing.T) {
+	calls := 0
+	app := setupIdempotencyApp(t, fiber.StatusCreated, &calls)
+
+	status, _, _ := postPathWithKey(t, app, "/users?dry_run=true", "key-1", `{"name":"a"}`)
+	require.Equal(t, fiber.StatusCreated, status)
+
+	status, _, _ = postPathWithKey(t, app, "/users?dry_run=false", "key-1", `{"name":"a"}`)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyScopesKeysToActor(t *testing.T) {
+	calls := 0
+	app := setupIdempotencyApp(t, fiber.StatusCreated, &calls)
+
+	status, body, _ := postAsWithKey(t, app, "api-key:alice", "/users", "key-1", `{"name":"a"}`)
+	require.Equal(t, fiber.StatusCreated, status)
+
+	// 다른 주체는 같은 키로 저장된 응답을 받을 수 없음 / Another actor cannot obtain the stored response with the same key
+	status, otherBody, replayed := postAsWithKey(t, app, "api-key:bob", "/users", "key-1", `{"name":"a"}`)
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Empty(t, replayed)
+	assert.NotEqual(t, body, otherBody)
+	assert.Equal(t, 2, calls)
+
+	_, replayBody, replayed := postAsWithKey(t, app, "api-key:alice", "/users", "key-1", `{"name":"a"}`)
+	assert.Equal(t, "true", replayed)
+	assert.Equal(t, body, replayBody)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyReleasesKeyOnServerError(t *testing.T) {
+	calls := 0
+	app := setupIdempotencyApp(t, fiber.StatusInternalServerError, &calls)
+
+	postWithKey(t, app, "key-1", `{"name":"a"}`)
+	status, _, replayed := postWithKey(t, app, "key-1", `{"name":"a"}`)
+
+	assert.Equal(t, fiber.StatusInternalServerError, status)
+	assert.Empty(t, replayed)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyReleasesKeyOnAuthorizationFailure(t *testing.T) {
+	for _, code := range []int{fiber.StatusUnauthorized, fiber.StatusForbidden} {
+		calls := 0
+		app := setupIdempotencyApp(t, code, &calls)
+
+		postWithKey(t, app, "key-1", `{"name":"a"}`)
+		status, _, replayed := postWithKey(t, app, "key-1", `{"name":"a"}`)
+
+		assert.Equal(t, code, status)
+		assert.Empty(t, replayed)
+		assert.Equal(t, 2, calls)
+	}
+}
+
+func TestIdempotencyReplaysStatusOnlyForLargeBodies(t *testing.T) {
+	calls := 0
+	app := setupIdempotencyAppWithLimit(t, fiber.StatusCreated, &calls, 5)
+
+	status, body, _ := postWithKey(t, app, "key-1", `{"name":"a"}`)
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.NotEmpty(t, body)
+
+	status, replayBody, replayed := postWithKey(t, app, "key-1", `{"name":"a"}`)
+	assert.Equal(t, fiber.StatusCreated, status)
+	assert.Empty(t, replayBody)
+	assert.Equal(t, "true", replayed)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencySkipsRequestsWithoutKey(t *testing.T) {
+	calls := 0
+	app := setupIdempotencyApp(t, fiber.StatusCreated, &calls)
+
+	postWithKey(t, app, "", `{"name":"a"}`)
+	postWithKey(t, app, "", `{"name":"a"}`)
+	status, _, _ := postWithKey(t, app, strings.Repeat("k", maxIdempotencyKeyLength+1), `{}`)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, fiber.StatusBadRequest, status)
+}
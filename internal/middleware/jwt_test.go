@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/config"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/jwt"
+)
+
+// fakeTokenVerifier 토큰별 클레임 또는 오류를 반환 / Return claims or an error per token
+type fakeTokenVerifier map[string]*jwt.Claims
+
+func (f fakeTokenVerifier) Verify(_ context.Context, token string) (*jwt.Claims, error) {
+	claims, ok := f[token]
+	if !ok {
+		return nil, jwt.ErrInvalidToken
+	}
+	if claims == nil {
+		return nil, errors.New("jwks unavailable")
+	}
+	return claims, nil
+}
+
+func TestAuthenticateVerifiesJWTs(t *testing.T) {
+	const (
+		valid   = "eyJhbGciOiJSUzI1NiJ9.eyJzdWIiOiJ1c2VyLTQyIn0.valid"
+		long    = "eyJhbGciOiJSUzI1NiJ9.eyJzdWIiOiJsb25nIn0.long"
+		unknown = "eyJhbGciOiJSUzI1NiJ9.eyJzdWIiOiJ1c2VyLTQyIn0.unknown"
+		broken  = "eyJhbGciOiJSUzI1NiJ9.eyJzdWIiOiJ1c2VyLTQyIn0.broken"
+	)
+	tokens := fakeTokenVerifier{
+		valid:  {Subject: "user-42", Scope: "users:read", Raw: map[string]any{"sub": "user-42"}},
+		long:   {Subject: strings.Repeat("x", 200)},
+		broken: nil,
+	}
+
+	testCases := []struct {
+		name       string
+		token      string
+		wantStatus int
+		wantActor  string
+		wantScopes []string
+	}{
+		{name: "valid token", token: valid, wantStatus: fiber.StatusNoContent, wantActor: "jwt:user-42", wantScopes: []string{"users:read"}},
+		{name: "long subject", token: long, wantStatus: fiber.StatusNoContent, wantActor: "jwt:" + strings.Repeat("x", maxActorLength-4), wantScopes: []string(nil)},
+		{name: "bootstrap key still works", token: "expected-secret", wantStatus: fiber.StatusNoContent, wantActor: APIKeyActor, wantScopes: []string{"*"}},
+		{name: "rejected token", token: unknown, wantStatus: fiber.StatusUnauthorized},
+		{name: "verifier failure", token: broken, wantStatus: fiber.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(Authenticate(&config.Config{APIKey: "expected-secret"}, nil, tokens))
+			app.Get("/", func(c *fiber.Ctx) error {
+				assert.Equal(t, tc.wantActor, GetActor(c))
+				assert.Equal(t, tc.wantScopes, c.Locals(ScopesContextKey))
+				if claims := GetClaims(c); claims != nil {
+					assert.Equal(t, tokens[tc.token], claims)
+				}
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			resp, err := app.Test(req)
+
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+		})
+	}
+}
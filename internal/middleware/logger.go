@@ -28,7 +28,7 @@ func Logger() fiber.Handler {
 		fields := []zap.Field{
 			zap.String("method", c.Method()),
 			zap.String("path", c.Path()),
-			zap.String("ip", c.IP()),
+			zap.String("ip", GetClientIP(c)),
 			zap.String("user_agent", c.Get("User-Agent")),
 			zap.Int("status", c.Response().StatusCode()),
 			zap.Duration("latency", duration),
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/apikey"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/resp"
+)
+
+// ScopesContextKey 인증된 호출자에게 부여된 범위 컨텍스트 키 / Context key of the scopes granted to the authenticated caller
+const ScopesContextKey = "scopes"
+
+// scopeGuard 라우트에 선언된 필요 범위 / Scopes declared on a route
+type scopeGuard []string
+
+// guardPointer scopeGuard.handle 메서드 값의 코드 포인터, 라우트 검사에서 사용
+// Code pointer of the scopeGuard.handle method value, used by the route check
+var guardPointer = reflect.ValueOf(scopeGuard(nil).handle).Pointer()
+
+// RequireScopes 필요 범위를 모두 가진 호출자만 허용 / Allow only callers granted every required scope
+// "*"는 모든 범위, "users:*"는 users의 모든 동작을 허용 / "*" grants every scope and "users:*" every users action
+// 범위가 없는 요청은 인증이 꺼져 있을 때만 도달하므로 통과 / Requests without scopes only get here when authentication is off, so they pass
+func RequireScopes(scopes ...string) fiber.Handler {
+	if len(scopes) == 0 {
+		panic("middleware: RequireScopes needs at least one scope")
+	}
+	return scopeGuard(scopes).handle
+}
+
+func (g scopeGuard) handle(c *fiber.Ctx) error {
+	granted, ok := c.Locals(ScopesContextKey).([]string)
+	if !ok {
+		return c.Next()
+	}
+
+	for _, scope := range g {
+		if !HasScope(granted, scope) {
+			return resp.Forbidden(c, "Insufficient scope", fiber.Map{"required_scopes": []string(g)})
+		}
+	}
+	return c.Next()
+}
+
+// Public 자격 증명을 본문으로 받아 인증 미들웨어 앞에 등록되는 라우트 표시 / Mark a route registered ahead of the authentication middleware because it takes credentials in its body
+// CheckScopes는 이 표시를 범위 선언으로 인정 / CheckScopes accepts the mark in place of a scope
+func Public() fiber.Handler {
+	return public
+}
+
+func public(c *fiber.Ctx) error {
+	return c.Next()
+}
+
+// publicPointer public 함수의 코드 포인터 / Code pointer of the public function
+var publicPointer = reflect.ValueOf(public).Pointer()
+
+// HasScope 부여된 범위가 필요 범위를 포함하는지 확인 / Report whether the granted scopes cover the required scope
+func HasScope(granted []string, required string) bool {
+	return apikey.HasScope(granted, required)
+}
+
+// GrantedScopes 인증된 호출자의 범위, 인증이 꺼져 있으면 nil / Scopes of the authenticated caller; nil when authentication is off
+// 범위 없이 인증된 호출자는 빈 슬라이스 / A caller authenticated without scopes gets an empty slice
+func GrantedScopes(c *fiber.Ctx) []string {
+	granted, ok := c.Locals(ScopesContextKey).([]string)
+	if !ok {
+		return nil
+	}
+	if granted == nil {
+		return []string{}
+	}
+	return granted
+}
+
+// CheckScopes prefix 아래 모든 라우트가 RequireScopes 또는 Public을 선언했는지 확인 / Check every route under prefix declares RequireScopes or Public
+// 시작 시 호출해 범위 없이 노출된 라우트를 막음 / Called at startup so no route is exposed without a scope
+func CheckScopes(app *fiber.App, prefix string) error {
+	var missing []string
+	for _, route := range app.GetRoutes(true) {
+		if route.Method == fiber.MethodHead || !strings.HasPrefix(route.Path, prefix) {
+			continue
+		}
+		if !slices.ContainsFunc(route.Handlers, isScopeGuard) {
+			missing = append(missing, route.Method+" "+route.Path)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("routes without a declared scope: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func isScopeGuard(handler fiber.Handler) bool {
+	pointer := reflect.ValueOf(handler).Pointer()
+	return pointer == guardPointer || pointer == publicPointer
+}
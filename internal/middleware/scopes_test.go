@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireScopes(t *testing.T) {
+	testCases := []struct {
+		name       string
+		granted    []string
+		wantStatus int
+	}{
+		{name: "authentication off", wantStatus: fiber.StatusNoContent},
+		{name: "exact scopes", granted: []string{"users:read", "users:delete"}, wantStatus: fiber.StatusNoContent},
+		{name: "resource wildcard", granted: []string{"users:*"}, wantStatus: fiber.StatusNoContent},
+		{name: "global wildcard", granted: []string{"*"}, wantStatus: fiber.StatusNoContent},
+		{name: "missing one scope", granted: []string{"users:read"}, wantStatus: fiber.StatusForbidden},
+		{name: "other resource wildcard", granted: []string{"webhooks:*"}, wantStatus: fiber.StatusForbidden},
+		{name: "no scopes", granted: []string{}, wantStatus: fiber.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(c *fiber.Ctx) error {
+				if tc.granted != nil {
+					c.Locals(ScopesContextKey, tc.granted)
+				}
+				return c.Next()
+			})
+			app.Delete("/", RequireScopes("users:read", "users:delete"), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodDelete, "/", nil))
+
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestCheckScopes(t *testing.T) {
+	handler := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) }
+
+	app := fiber.New()
+	app.Get("/health", handler)
+	v1 := app.Group("/v1")
+	v1.Use(func(c *fiber.Ctx) error { return c.Next() })
+	v1.Get("/users", RequireScopes("users:read"), handler)
+	v1.Post("/users", NoDeadline(), RequireScopes("users:write"), handler)
+	v1.Post("/auth/login", Public(), handler)
+	require.NoError(t, CheckScopes(app, "/v1"))
+
+	v1.Delete("/users/:id", handler)
+	assert.EqualError(t, CheckScopes(app, "/v1"), "routes without a declared scope: DELETE /v1/users/:id")
+
+	assert.Panics(t, func() { RequireScopes() })
+}
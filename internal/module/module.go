@@ -0,0 +1,139 @@
+// Package module defines pluggable domain modules and the registry the router and main iterate over
+package module
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/config"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/db"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/http/health"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/jwt"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/outbox"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/internal/scheduler"
+	"github.com/kyungseok-lee/go-fiber-gorm-starter/pkg/password"
+)
+
+// Module 도메인 모듈 인터페이스 / Domain module interface
+// 중앙 배선을 고치지 않고 도메인을 추가할 수 있도록 라우터와 main이 등록된 모듈을 순회
+// The router and main iterate over registered modules so a domain can be added without touching central wiring
+type Module interface {
+	// Name 고유한 모듈 이름 / Unique module name
+	Name() string
+	// Models AutoMigrate 대상 모델 / Models to auto-migrate
+	Models() []any
+	// RegisterRoutes 멱등성 미들웨어가 걸린 /v1 그룹에 라우트 등록 / Register routes on the /v1 group, behind the idempotency middleware
+	// 모든 라우트는 middleware.RequireScopes로 범위를 선언해야 시작됨 / Every route must declare its scopes with middleware.RequireScopes or startup fails
+	RegisterRoutes(v1 fiber.Router)
+	// HealthChecks /ready에서 실행할 검사 / Checks run by /ready
+	HealthChecks() []health.Check
+	// Collectors 메트릭이 켜져 있을 때 등록할 Prometheus 수집기 / Prometheus collectors registered when metrics are enabled
+	Collectors() []prometheus.Collector
+	// Jobs 임대 기반 스케줄러에서 실행할 백그라운드 작업 / Background jobs run by the lease-based scheduler
+	Jobs() []scheduler.Job
+}
+
+// Subscriber 아웃박스 이벤트를 추가로 받는 모듈 (선택) / Module that also receives outbox events (optional)
+type Subscriber interface {
+	// Subscriber 릴레이가 함께 호출할 발행기, nil이면 구독하지 않음 / Publisher the relay also calls, nil to skip
+	Subscriber() outbox.Publisher
+}
+
+// PublicRouter 인증 없이 호출되는 라우트를 가진 모듈 (선택) / Module with routes called without authentication (optional)
+// 로그인처럼 자격 증명을 본문으로 받는 라우트용 / For routes that take credentials in their body, such as login
+type PublicRouter interface {
+	// RegisterPublicRoutes 인증 미들웨어 앞의 /v1 그룹에 라우트 등록, 각 라우트는 middleware.Public을 선언
+	// Register routes on a /v1 group ahead of the authentication middleware; each route declares middleware.Public
+	RegisterPublicRoutes(v1 fiber.Router)
+}
+
+// SessionChecker 액세스 토큰의 세션을 확인하는 모듈 (선택) / Module that checks the session of access tokens (optional)
+type SessionChecker interface {
+	// SessionChecker sid 클레임이 있는 JWT마다 호출할 확인기, nil이면 확인하지 않음 / Checker called for every JWT with a sid claim, nil to skip
+	SessionChecker() jwt.SessionChecker
+}
+
+// Base 모든 훅의 빈 구현, 모듈이 임베드해 필요한 것만 재정의 / Empty implementation of every hook; modules embed it and override what they need
+type Base struct{}
+
+// Models 모델 없음 / No models
+func (Base) Models() []any { return nil }
+
+// RegisterRoutes 라우트 없음 / No routes
+func (Base) RegisterRoutes(fiber.Router) {}
+
+// HealthChecks 검사 없음 / No checks
+func (Base) HealthChecks() []health.Check { return nil }
+
+// Collectors 수집기 없음 / No collectors
+func (Base) Collectors() []prometheus.Collector { return nil }
+
+// Jobs 작업 없음 / No jobs
+func (Base) Jobs() []scheduler.Job { return nil }
+
+// Deps 모듈 생성 시 주입되는 공유 의존성 / Shared dependencies injected when a module is built
+type Deps struct {
+	Config *config.Config
+	DB     *gorm.DB
+	Tx     db.TxManager
+	// Passwords 모든 모듈이 함께 쓰는 해시기, 동시 해시 제한을 프로세스 전체에 적용
+	// Hasher shared by every module so the concurrency limit applies to the whole process
+	Passwords password.Hasher
+	// EventTypes 등록된 모든 모듈이 발행하는 이벤트 유형 / Event types published by every registered module
+	EventTypes []string
+}
+
+// Registration 모듈 등록 정보 / Module registration
+type Registration struct {
+	Name string
+	// EventTypes 모듈이 아웃박스에 발행하는 이벤트 유형 / Event types the module publishes to the outbox
+	EventTypes []string
+	New        func(deps Deps) Module
+}
+
+var (
+	mu            sync.Mutex
+	registrations []Registration
+)
+
+// Register 모듈 등록, 도메인 패키지의 init에서 호출 / Register a module; called from the domain package's init
+// database/sql 드라이버처럼 cmd/server에서 빈 import로 활성화 / Enabled by a blank import in cmd/server, like database/sql drivers
+func Register(reg Registration) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if reg.New == nil {
+		panic("module: Register " + reg.Name + " without a constructor")
+	}
+	if slices.ContainsFunc(registrations, func(r Registration) bool { return r.Name == reg.Name }) {
+		panic(fmt.Sprintf("module: Register called twice for %q", reg.Name))
+	}
+	registrations = append(registrations, reg)
+}
+
+// Build 등록된 모든 모듈을 이름 순으로 생성 / Build every registered module in name order
+// 모든 모듈의 이벤트 유형을 먼저 모아 Deps로 전달 / Event types of every module are collected first and passed in Deps
+func Build(deps Deps) []Module {
+	mu.Lock()
+	regs := slices.Clone(registrations)
+	mu.Unlock()
+
+	slices.SortFunc(regs, func(a, b Registration) int { return cmp.Compare(a.Name, b.Name) })
+
+	deps.EventTypes = nil
+	for _, reg := range regs {
+		deps.EventTypes = append(deps.EventTypes, reg.EventTypes...)
+	}
+
+	modules := make([]Module, 0, len(regs))
+	for _, reg := range regs {
+		modules = append(modules, reg.New(deps))
+	}
+	return modules
+}
@@ -0,0 +1,43 @@
+package module
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testModule struct {
+	Base
+	name string
+	deps Deps
+}
+
+func (m *testModule) Name() string { return m.name }
+
+func register(name string, eventTypes ...string) {
+	Register(Registration{
+		Name:       name,
+		EventTypes: eventTypes,
+		New:        func(deps Deps) Module { return &testModule{name: name, deps: deps} },
+	})
+}
+
+func TestBuild(t *testing.T) {
+	register("orders", "order.created")
+	register("billing", "invoice.paid", "invoice.voided")
+	register("reports")
+
+	modules := Build(Deps{EventTypes: []string{"stale"}})
+
+	require.Len(t, modules, 3)
+	names := make([]string, len(modules))
+	for i, m := range modules {
+		names[i] = m.Name()
+		assert.Equal(t, []string{"invoice.paid", "invoice.voided", "order.created"}, m.(*testModule).deps.EventTypes)
+	}
+	assert.Equal(t, []string{"billing", "orders", "reports"}, names)
+
+	assert.Panics(t, func() { register("orders") })
+	assert.Panics(t, func() { Register(Registration{Name: "broken"}) })
+}
@@ -0,0 +1,203 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrFeedFull 구독자 수 한도 초과 / Subscriber limit reached
+var ErrFeedFull = errors.New("feed subscriber limit reached")
+
+// FeedConfig 변경 피드 설정 / Change feed configuration
+type FeedConfig struct {
+	PollInterval time.Duration
+	// GapGrace 앞선 ID가 커밋되길 기다리는 시간 / How long to wait for an earlier ID to commit
+	GapGrace       time.Duration
+	BatchSize      int
+	BufferSize     int
+	MaxSubscribers int
+}
+
+// Feed 아웃박스를 ID 순으로 읽어 구독자에게 전달하는 프로세스 내 변경 피드
+// In-process change feed that reads the outbox in ID order and hands messages to subscribers
+//
+// 메시지 ID가 변경 순번이며 구독자에게 오름차순으로만 전달됨. 빈 ID는 커밋 전이거나 롤백된
+// 트랜잭션이므로 GapGrace 동안 뒤 메시지를 보류한 뒤 건너뜀.
+// The message ID is the change sequence and reaches subscribers in ascending order only. A missing
+// ID belongs to an uncommitted or rolled-back transaction, so later messages wait up to GapGrace
+// before it is skipped.
+//
+// 폴링은 첫 구독자와 함께 시작하고 마지막 구독자가 떠나면 멈춤
+// Polling starts with the first subscriber and stops when the last one leaves
+type Feed struct {
+	store Store
+	cfg   FeedConfig
+
+	mu       sync.Mutex
+	subs     map[*FeedSubscription]struct{}
+	cursor   uint
+	gapSince time.Time
+	stop     context.CancelFunc
+}
+
+// NewFeed 새 변경 피드 생성 / Create new change feed
+func NewFeed(store Store, cfg FeedConfig) *Feed {
+	return &Feed{store: store, cfg: cfg, subs: make(map[*FeedSubscription]struct{})}
+}
+
+// FeedSubscription 피드 구독 / Feed subscription
+type FeedSubscription struct {
+	feed    *Feed
+	events  chan Envelope
+	dropped chan struct{}
+}
+
+// Events 전달된 메시지 / Delivered messages
+func (s *FeedSubscription) Events() <-chan Envelope {
+	return s.events
+}
+
+// Dropped 버퍼가 가득 차 구독이 끊기면 닫힘 / Closed when the subscription is cut off for a full buffer
+func (s *FeedSubscription) Dropped() <-chan struct{} {
+	return s.dropped
+}
+
+// Close 구독 해제 / Unsubscribe
+func (s *FeedSubscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	s.feed.removeLocked(s)
+}
+
+// Subscribe 구독 등록, 이후 전달될 메시지는 모두 반환된 커서보다 큼
+// Register a subscription; every message it receives is after the returned cursor
+func (f *Feed) Subscribe() (*FeedSubscription, uint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.cfg.MaxSubscribers > 0 && len(f.subs) >= f.cfg.MaxSubscribers {
+		return nil, 0, ErrFeedFull
+	}
+	if f.stop == nil {
+		last, err := f.store.LastID()
+		if err != nil {
+			return nil, 0, err
+		}
+		f.cursor = last
+		f.gapSince = time.Time{}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		f.stop = cancel
+		go f.run(ctx)
+	}
+
+	sub := &FeedSubscription{
+		feed:    f,
+		events:  make(chan Envelope, f.cfg.BufferSize),
+		dropped: make(chan struct{}),
+	}
+	f.subs[sub] = struct{}{}
+	return sub, f.cursor, nil
+}
+
+// Replay afterID 초과 untilID 이하의 메시지를 순서대로 fn에 전달 / Pass messages in (afterID, untilID] to fn in order
+func (f *Feed) Replay(afterID, untilID uint, fn func(Envelope) error) error {
+	for afterID < untilID {
+		messages, err := f.store.After(afterID, f.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+		for _, message := range messages {
+			if message.ID > untilID {
+				return nil
+			}
+			if err := fn(message.Envelope()); err != nil {
+				return err
+			}
+			afterID = message.ID
+		}
+	}
+	return nil
+}
+
+// run 폴링 루프 / Polling loop
+func (f *Feed) run(ctx context.Context) {
+	ticker := time.NewTicker(f.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := f.poll(ctx, time.Now()); err != nil {
+			zap.L().Warn("Failed to poll change feed", zap.String("method", "outbox.Feed.run"), zap.Error(err))
+		}
+	}
+}
+
+// poll 커서 이후 메시지를 읽어 순서대로 전달 / Read messages after the cursor and hand them out in order
+func (f *Feed) poll(ctx context.Context, now time.Time) error {
+	f.mu.Lock()
+	cursor := f.cursor
+	f.mu.Unlock()
+
+	messages, err := f.store.After(cursor, f.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// 읽는 동안 폴링이 멈췄거나 다시 시작됨 / Polling stopped or restarted while reading
+	if ctx.Err() != nil || f.cursor != cursor {
+		return nil
+	}
+
+	for _, message := range messages {
+		if message.ID != f.cursor+1 {
+			if f.gapSince.IsZero() {
+				f.gapSince = now
+			}
+			if now.Sub(f.gapSince) < f.cfg.GapGrace {
+				return nil
+			}
+		}
+		f.gapSince = time.Time{}
+		f.cursor = message.ID
+		f.broadcastLocked(message.Envelope())
+	}
+	return nil
+}
+
+// broadcastLocked 막히지 않고 전달, 버퍼가 가득 찬 구독자는 끊음 / Hand out without blocking, cutting off subscribers with a full buffer
+func (f *Feed) broadcastLocked(envelope Envelope) {
+	for sub := range f.subs {
+		select {
+		case sub.events <- envelope:
+		default:
+			f.removeLocked(sub)
+			close(sub.dropped)
+		}
+	}
+}
+
+// removeLocked 구독자 제거, 마지막이면 폴링 중지 / Remove a subscriber, stopping polling after the last one
+func (f *Feed) removeLocked(sub *FeedSubscription) {
+	if _, ok := f.subs[sub]; !ok {
+		return
+	}
+	delete(f.subs, sub)
+	if len(f.subs) == 0 && f.stop != nil {
+		f.stop()
+		f.stop = nil
+	}
+}
@@ -0,0 +1,119 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestFeed 폴링 주기가 길어 poll을 직접 호출하는 피드 / Feed with a long poll interval so tests call poll themselves
+func newTestFeed(store Store, bufferSize int) *Feed {
+	return NewFeed(store, FeedConfig{
+		PollInterval:   time.Hour,
+		GapGrace:       time.Second,
+		BatchSize:      2,
+		BufferSize:     bufferSize,
+		MaxSubscribers: 2,
+	})
+}
+
+func received(sub *FeedSubscription) []uint {
+	var ids []uint
+	for {
+		select {
+		case envelope := <-sub.Events():
+			ids = append(ids, envelope.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestFeed_DeliversInOrderAfterCursor(t *testing.T) {
+	_, store := setupStore(t)
+	addMessages(t, store, "1")
+
+	feed := newTestFeed(store, 10)
+	sub, cursor, err := feed.Subscribe()
+	require.NoError(t, err)
+	defer sub.Close()
+	assert.EqualValues(t, 1, cursor)
+
+	addMessages(t, store, "2", "1", "3")
+	now := time.Now()
+	require.NoError(t, feed.poll(context.Background(), now))
+	require.NoError(t, feed.poll(context.Background(), now))
+	assert.Equal(t, []uint{2, 3, 4}, received(sub))
+}
+
+func TestFeed_WaitsForGapsBeforeSkipping(t *testing.T) {
+	db, store := setupStore(t)
+	addMessages(t, store, "1")
+
+	feed := newTestFeed(store, 10)
+	sub, _, err := feed.Subscribe()
+	require.NoError(t, err)
+	defer sub.Close()
+
+	// ID 2가 아직 커밋되지 않은 상황 / ID 2 is not committed yet
+	gap, err := NewMessage("user", "1", "UserUpdated", map[string]string{})
+	require.NoError(t, err)
+	gap.ID = 3
+	require.NoError(t, db.Create(gap).Error)
+
+	now := time.Now()
+	require.NoError(t, feed.poll(context.Background(), now))
+	assert.Empty(t, received(sub))
+
+	require.NoError(t, feed.poll(context.Background(), now.Add(2*time.Second)))
+	assert.Equal(t, []uint{3}, received(sub))
+}
+
+func TestFeed_DropsSlowSubscribers(t *testing.T) {
+	_, store := setupStore(t)
+
+	feed := newTestFeed(store, 1)
+	slow, _, err := feed.Subscribe()
+	require.NoError(t, err)
+	fast, _, err := feed.Subscribe()
+	require.NoError(t, err)
+	defer fast.Close()
+
+	_, _, err = feed.Subscribe()
+	assert.ErrorIs(t, err, ErrFeedFull)
+
+	addMessages(t, store, "1")
+	require.NoError(t, feed.poll(context.Background(), time.Now()))
+	assert.Equal(t, []uint{1}, received(fast))
+
+	// 읽지 않는 구독자는 버퍼가 차면 끊김 / A subscriber that never reads is cut off once its buffer is full
+	addMessages(t, store, "2")
+	require.NoError(t, feed.poll(context.Background(), time.Now()))
+	select {
+	case <-slow.Dropped():
+	default:
+		t.Fatal("slow subscriber was not dropped")
+	}
+	assert.Equal(t, []uint{2}, received(fast))
+	slow.Close()
+
+	replacement, _, err := feed.Subscribe()
+	require.NoError(t, err)
+	replacement.Close()
+}
+
+func TestFeed_Replay(t *testing.T) {
+	_, store := setupStore(t)
+	addMessages(t, store, "1", "2", "3", "4", "5")
+	feed := newTestFeed(store, 10)
+
+	var ids []uint
+	require.NoError(t, feed.Replay(1, 4, func(envelope Envelope) error {
+		ids = append(ids, envelope.ID)
+		return nil
+	}))
+	assert.Equal(t, []uint{2, 3, 4}, ids)
+}
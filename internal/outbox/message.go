@@ -0,0 +1,88 @@
+// Package outbox stores domain events in the same transaction as the change and relays them to a publisher
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Status 메시지 전달 상태 / Message delivery status
+type Status string
+
+const (
+	// StatusPending means the message is waiting to be published or retried.
+	StatusPending Status = "pending"
+	// StatusPublished means the publisher accepted the message.
+	StatusPublished Status = "published"
+	// StatusDead means the message exhausted its attempts and was dead-lettered.
+	StatusDead Status = "dead"
+)
+
+// Message 아웃박스 메시지 레코드 / Outbox message record
+type Message struct {
+	ID            uint   `gorm:"primarykey"`
+	AggregateType string `gorm:"not null;size:50;index:idx_outbox_aggregate,priority:1"`
+	AggregateID   string `gorm:"not null;size:64;index:idx_outbox_aggregate,priority:2"`
+	EventType     string `gorm:"not null;size:100"`
+	Payload       string `gorm:"type:text;not null"`
+	Status        Status `gorm:"not null;size:20;default:'pending';index"`
+	Attempts      int    `gorm:"not null;default:0"`
+	// NextAttemptAt 다음 재시도 시각, 첫 실패 전에는 NULL / Time of the next retry; NULL until the first failure
+	NextAttemptAt *time.Time
+	LastError     string `gorm:"size:1000"`
+	CreatedAt     time.Time
+	PublishedAt   *time.Time
+}
+
+// TableName 테이블 이름 지정 / Specify table name
+func (Message) TableName() string {
+	return "outbox"
+}
+
+// NewMessage 페이로드를 JSON으로 인코딩한 메시지 생성 / Create a message with a JSON-encoded payload
+func NewMessage(aggregateType, aggregateID, eventType string, payload interface{}) (*Message, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s payload: %w", eventType, err)
+	}
+	return &Message{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       string(data),
+		Status:        StatusPending,
+	}, nil
+}
+
+// Due now에 발행할 수 있는지 확인 / Report whether the message can be published at now
+func (m *Message) Due(now time.Time) bool {
+	return m.NextAttemptAt == nil || !m.NextAttemptAt.After(now)
+}
+
+// AggregateKey 순서 보장 단위 / Unit of ordering
+func (m *Message) AggregateKey() string {
+	return m.AggregateType + ":" + m.AggregateID
+}
+
+// Envelope 발행되는 메시지 형식 / Published message format
+type Envelope struct {
+	ID            uint            `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// Envelope 메시지를 발행 형식으로 변환 / Convert the message to its published format
+func (m *Message) Envelope() Envelope {
+	return Envelope{
+		ID:            m.ID,
+		AggregateType: m.AggregateType,
+		AggregateID:   m.AggregateID,
+		EventType:     m.EventType,
+		Payload:       json.RawMessage(m.Payload),
+		CreatedAt:     m.CreatedAt,
+	}
+}
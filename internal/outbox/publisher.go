@@ -0,0 +1,127 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Publisher 메시지 발행 인터페이스 / Message publisher interface
+// 발행은 최소 한 번 보장이므로 소비자는 Envelope.ID로 중복을 제거해야 함
+// Delivery is at-least-once, so consumers should deduplicate on Envelope.ID
+type Publisher interface {
+	Publish(ctx context.Context, message *Message) error
+}
+
+// MultiPublisher 여러 발행기에 차례로 발행 / Publishes to several publishers in turn
+// 하나라도 실패하면 메시지 전체가 재시도되므로 앞선 발행기는 같은 메시지를 다시 받을 수 있음
+// Any failure retries the whole message, so earlier publishers may receive it again
+type MultiPublisher struct {
+	publishers []Publisher
+}
+
+// NewMultiPublisher 새 다중 발행기 생성 / Create new multi publisher
+func NewMultiPublisher(publishers ...Publisher) *MultiPublisher {
+	return &MultiPublisher{publishers: publishers}
+}
+
+// Publish 모든 발행기에 발행 / Publish to every publisher
+func (p *MultiPublisher) Publish(ctx context.Context, message *Message) error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LogPublisher 메시지를 로그로 남기는 발행기 / Publisher that logs messages
+type LogPublisher struct{}
+
+// NewLogPublisher 새 로그 발행기 생성 / Create new log publisher
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+// Publish 메시지 로그 기록 / Log the message
+func (p *LogPublisher) Publish(_ context.Context, message *Message) error {
+	zap.L().Info("Outbox message published",
+		zap.String("method", "outbox.LogPublisher.Publish"),
+		zap.Uint("message_id", message.ID),
+		zap.String("event_type", message.EventType),
+		zap.String("aggregate", message.AggregateKey()),
+		zap.String("payload", message.Payload))
+	return nil
+}
+
+// FilePublisher 메시지를 NDJSON 파일에 추가하는 발행기 / Publisher that appends messages to an NDJSON file
+type FilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFilePublisher 새 파일 발행기 생성 / Create new file publisher
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox file: %w", err)
+	}
+	return &FilePublisher{file: file}, nil
+}
+
+// Publish 메시지를 한 줄로 추가 / Append the message as one line
+func (p *FilePublisher) Publish(_ context.Context, message *Message) error {
+	data, err := json.Marshal(message.Envelope())
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox message: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write outbox message: %w", err)
+	}
+	return nil
+}
+
+// Close 파일 닫기 / Close the file
+func (p *FilePublisher) Close() error {
+	return p.file.Close()
+}
+
+// MemoryPublisher 메모리에 메시지를 모으는 발행기 (테스트용) / Publisher that collects messages in memory, for tests
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []Envelope
+	// Fail 오류를 반환하면 발행 실패로 처리 / A returned error fails the publish
+	Fail func(message *Message) error
+}
+
+// NewMemoryPublisher 새 메모리 발행기 생성 / Create new memory publisher
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// Publish 메시지 저장 / Store the message
+func (p *MemoryPublisher) Publish(_ context.Context, message *Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.Fail != nil {
+		if err := p.Fail(message); err != nil {
+			return err
+		}
+	}
+	p.messages = append(p.messages, message.Envelope())
+	return nil
+}
+
+// Messages 발행된 메시지 복사본 / Copy of the published messages
+func (p *MemoryPublisher) Messages() []Envelope {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Envelope(nil), p.messages...)
+}
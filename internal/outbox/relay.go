@@ -0,0 +1,99 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RelayConfig 중계 설정 / Relay configuration
+type RelayConfig struct {
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Relay 대기 메시지를 발행기로 전달 / Forwards pending messages to a publisher
+type Relay struct {
+	store     Store
+	publisher Publisher
+	cfg       RelayConfig
+}
+
+// NewRelay 새 중계기 생성 / Create new relay
+func NewRelay(store Store, publisher Publisher, cfg RelayConfig) *Relay {
+	return &Relay{store: store, publisher: publisher, cfg: cfg}
+}
+
+// RunOnce 대기 메시지 한 배치 발행 / Publish one batch of pending messages
+// 집합체별로 ID 순서를 지키며, 앞선 메시지가 재시도 대기 중이면 뒤 메시지는 다음 실행으로 미룸
+// Keeps ID order per aggregate: while an earlier message waits for a retry, later ones wait for a later run
+// 배달 불능 처리된 메시지는 더 이상 뒤 메시지를 막지 않음 / A dead-lettered message no longer blocks later ones
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	logger := zap.L().With(zap.String("method", "outbox.Relay.RunOnce"))
+
+	messages, err := r.store.Pending(time.Now(), r.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	blocked := make(map[string]bool)
+	for _, message := range messages {
+		if ctx.Err() != nil {
+			return published, ctx.Err()
+		}
+
+		key := message.AggregateKey()
+		now := time.Now()
+		if blocked[key] || !message.Due(now) {
+			blocked[key] = true
+			continue
+		}
+
+		if err := r.publisher.Publish(ctx, message); err != nil {
+			attempts := message.Attempts + 1
+			dead := attempts >= r.cfg.MaxAttempts
+			if markErr := r.store.MarkFailed(message.ID, attempts, now.Add(Backoff(r.cfg.BaseBackoff, r.cfg.MaxBackoff, attempts)), err.Error(), dead); markErr != nil {
+				return published, markErr
+			}
+			if dead {
+				logger.Error("Outbox message dead-lettered",
+					zap.Uint("message_id", message.ID),
+					zap.String("event_type", message.EventType),
+					zap.Int("attempts", attempts),
+					zap.Error(err))
+				continue
+			}
+			logger.Warn("Failed to publish outbox message",
+				zap.Uint("message_id", message.ID),
+				zap.Int("attempts", attempts),
+				zap.Error(err))
+			blocked[key] = true
+			continue
+		}
+
+		if err := r.store.MarkPublished(message.ID, now); err != nil {
+			return published, fmt.Errorf("failed to record publish of message %d: %w", message.ID, err)
+		}
+		published++
+	}
+
+	if published > 0 {
+		logger.Info("Outbox messages published", zap.Int("count", published))
+	}
+	return published, nil
+}
+
+// Backoff attempts번째 실패 후 대기 시간: base에서 두 배씩, maxDelay로 제한
+// Delay after the attempts-th failure: doubling from base, capped at maxDelay
+func Backoff(base, maxDelay time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
@@ -0,0 +1,229 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupStore(t *testing.T) (*gorm.DB, Store) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Message{}))
+	return db, NewStore(db)
+}
+
+func addMessages(t *testing.T, store Store, aggregateIDs ...string) {
+	t.Helper()
+
+	for _, id := range aggregateIDs {
+		message, err := NewMessage("user", id, "UserUpdated", map[string]string{"id": id})
+		require.NoError(t, err)
+		require.NoError(t, store.Add(message))
+	}
+}
+
+// makeDue 재시도 대기 시간이 지난 것으로 처리 / Treat every retry delay as elapsed
+func makeDue(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	require.NoError(t, db.Model(&Message{}).Where("1 = 1").Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+}
+
+func TestRelay_RunOnce(t *testing.T) {
+	db, store := setupStore(t)
+	addMessages(t, store, "1", "2", "1", "2")
+
+	publisher := NewMemoryPublisher()
+	failing := true
+	publisher.Fail = func(message *Message) error {
+		if failing && message.AggregateID == "1" {
+			return errors.New("broker unavailable")
+		}
+		return nil
+	}
+	relay := NewRelay(store, publisher, RelayConfig{BatchSize: 10, MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour})
+
+	// 실패한 집합체의 뒤 메시지는 보류, 다른 집합체는 진행 / Later messages of the failing aggregate wait, others proceed
+	published, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+
+	var first Message
+	require.NoError(t, db.First(&first, 1).Error)
+	assert.Equal(t, StatusPending, first.Status)
+	assert.Equal(t, 1, first.Attempts)
+	assert.Equal(t, "broker unavailable", first.LastError)
+	require.NotNil(t, first.NextAttemptAt)
+	assert.True(t, first.NextAttemptAt.After(time.Now()))
+
+	// 백오프 중에는 재시도하지 않음 / No retry during the backoff
+	published, err = relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+
+	makeDue(t, db)
+	failing = false
+	published, err = relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+
+	ids := make([]uint, 0, 4)
+	for _, envelope := range publisher.Messages() {
+		ids = append(ids, envelope.ID)
+	}
+	assert.Equal(t, []uint{2, 4, 1, 3}, ids)
+
+	pending, err := store.Pending(time.Now(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestRelay_DeadLetter(t *testing.T) {
+	db, store := setupStore(t)
+	addMessages(t, store, "1", "1")
+
+	publisher := NewMemoryPublisher()
+	publisher.Fail = func(message *Message) error {
+		if message.ID == 1 {
+			return errors.New("rejected")
+		}
+		return nil
+	}
+	relay := NewRelay(store, publisher, RelayConfig{BatchSize: 10, MaxAttempts: 2, BaseBackoff: time.Minute, MaxBackoff: time.Hour})
+
+	_, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	makeDue(t, db)
+
+	// 최대 시도 후 배달 불능 처리되고 뒤 메시지는 진행 / Dead-lettered after the last attempt, unblocking later messages
+	published, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+
+	var dead Message
+	require.NoError(t, db.First(&dead, 1).Error)
+	assert.Equal(t, StatusDead, dead.Status)
+	assert.Equal(t, 2, dead.Attempts)
+	require.Len(t, publisher.Messages(), 1)
+	assert.EqualValues(t, 2, publisher.Messages()[0].ID)
+}
+
+func TestRelay_NotDueDoesNotStarve(t *testing.T) {
+	db, store := setupStore(t)
+	addMessages(t, store, "1", "1", "2")
+	require.NoError(t, db.Model(&Message{}).Where("id = ?", 1).Update("next_attempt_at", time.Now().Add(time.Hour)).Error)
+
+	publisher := NewMemoryPublisher()
+	relay := NewRelay(store, publisher, RelayConfig{BatchSize: 1, MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour})
+
+	// 재시도 대기 메시지가 배치를 차지하지 않고 같은 집합체의 뒤 메시지는 보류
+	// A message waiting for a retry takes no batch slot, and later messages of its aggregate wait
+	published, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	require.Len(t, publisher.Messages(), 1)
+	assert.EqualValues(t, 3, publisher.Messages()[0].ID)
+
+	published, err = relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+}
+
+func TestStore_PurgePublished(t *testing.T) {
+	db, store := setupStore(t)
+	addMessages(t, store, "1", "2", "3")
+
+	now := time.Now()
+	require.NoError(t, store.MarkPublished(1, now.Add(-48*time.Hour)))
+	require.NoError(t, store.MarkPublished(2, now))
+
+	purged, err := store.PurgePublished(now.Add(-24 * time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, purged)
+
+	var ids []uint
+	require.NoError(t, db.Model(&Message{}).Order("id").Pluck("id", &ids).Error)
+	assert.Equal(t, []uint{2, 3}, ids)
+}
+
+func TestBackoff(t *testing.T) {
+	testCases := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 5, want: 10 * time.Second},
+		{attempts: 60, want: 10 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, Backoff(time.Second, 10*time.Second, tc.attempts))
+	}
+}
+
+func TestMultiPublisher_Publish(t *testing.T) {
+	first, second := NewMemoryPublisher(), NewMemoryPublisher()
+	second.Fail = func(*Message) error { return errors.New("unavailable") }
+
+	message, err := NewMessage("user", "1", "UserCreated", map[string]string{})
+	require.NoError(t, err)
+
+	// 하나라도 실패하면 메시지 전체를 재시도 / Any failure retries the whole message
+	err = NewMultiPublisher(first, second).Publish(context.Background(), message)
+	require.Error(t, err)
+	assert.Len(t, first.Messages(), 1)
+	assert.Empty(t, second.Messages())
+}
+
+func TestFilePublisher_Publish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.ndjson")
+	publisher, err := NewFilePublisher(path)
+	require.NoError(t, err)
+
+	message, err := NewMessage("user", "7", "UserCreated", map[string]string{"name": "Test User"})
+	require.NoError(t, err)
+	message.ID = 1
+	require.NoError(t, publisher.Publish(context.Background(), message))
+	require.NoError(t, publisher.Publish(context.Background(), message))
+	require.NoError(t, publisher.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var envelope Envelope
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &envelope))
+		assert.Equal(t, "UserCreated", envelope.EventType)
+		assert.JSONEq(t, `{"name":"Test User"}`, string(envelope.Payload))
+		lines++
+	}
+	assert.Equal(t, 2, lines)
+}
+
+func TestStore_AddLeavesNextAttemptNull(t *testing.T) {
+	db, store := setupStore(t)
+	addMessages(t, store, "1")
+
+	// MySQL의 NO_ZERO_DATE가 거부하는 0 시각 대신 NULL 저장 / Stored as NULL rather than the zero time MySQL's NO_ZERO_DATE rejects
+	var nulls int64
+	require.NoError(t, db.Model(&Message{}).Where("next_attempt_at IS NULL").Count(&nulls).Error)
+	assert.EqualValues(t, 1, nulls)
+}
@@ -0,0 +1,131 @@
+package outbox
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxLastErrorLength 저장하는 오류 메시지 최대 길이 / Maximum stored error message length
+const maxLastErrorLength = 1000
+
+// Store 아웃박스 저장소 인터페이스 / Outbox store interface
+type Store interface {
+	// Add 메시지 추가 (변경과 같은 트랜잭션에서 호출) / Add messages, within the same transaction as the change
+	Add(messages ...*Message) error
+	// Pending now 기준 발행할 수 있는 대기 메시지를 ID 순으로 조회
+	// 재시도 대기 중이거나 같은 집합체의 앞선 메시지가 재시도 대기 중인 메시지는 제외
+	// List pending messages that can be published at now, in ID order; messages waiting for a retry,
+	// or behind an earlier message of the same aggregate that is, are left out
+	Pending(now time.Time, limit int) ([]*Message, error)
+	MarkPublished(id uint, now time.Time) error
+	// PurgePublished before 이전에 발행된 메시지 삭제 / Delete messages published before the given time
+	PurgePublished(before time.Time) (int64, error)
+	// After afterID 이후 메시지를 상태와 무관하게 ID 순으로 조회 / List messages after afterID in ID order, whatever their status
+	After(afterID uint, limit int) ([]*Message, error)
+	// LastID 가장 큰 메시지 ID, 없으면 0 / Highest message ID, or 0 when empty
+	LastID() (uint, error)
+	// MarkFailed 실패 기록, dead이면 배달 불능 처리 / Record a failure, dead-lettering when dead is true
+	MarkFailed(id uint, attempts int, nextAttemptAt time.Time, lastErr string, dead bool) error
+}
+
+// store GORM 기반 저장소 구현체 / GORM-backed store implementation
+type store struct {
+	db *gorm.DB
+}
+
+// NewStore 새 아웃박스 저장소 생성 / Create new outbox store
+// 트랜잭션 핸들을 넘기면 같은 트랜잭션에서 기록 / Pass a transaction handle to write within that transaction
+func NewStore(db *gorm.DB) Store {
+	return &store{db: db}
+}
+
+// Add 메시지 추가 / Add messages
+func (s *store) Add(messages ...*Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	if err := s.db.Create(messages).Error; err != nil {
+		return fmt.Errorf("failed to add outbox messages: %w", err)
+	}
+	return nil
+}
+
+// Pending 대기 메시지 조회 / List pending messages
+// 재시도 대기 메시지가 배치를 채워 발행할 수 있는 메시지를 굶기지 않도록 SQL에서 거름
+// Filtered in SQL so messages waiting for a retry cannot fill the batch and starve messages that are due
+func (s *store) Pending(now time.Time, limit int) ([]*Message, error) {
+	var messages []*Message
+	if err := s.db.Table("outbox AS m").
+		Where("m.status = ? AND (m.next_attempt_at IS NULL OR m.next_attempt_at <= ?)", StatusPending, now).
+		Where(`NOT EXISTS (SELECT 1 FROM outbox AS e
+			WHERE e.aggregate_type = m.aggregate_type AND e.aggregate_id = m.aggregate_id
+			AND e.id < m.id AND e.status = ? AND e.next_attempt_at > ?)`, StatusPending, now).
+		Order("m.id").
+		Limit(limit).
+		Find(&messages).Error; err != nil {
+		return nil, fmt.Errorf("failed to list pending outbox messages: %w", err)
+	}
+	return messages, nil
+}
+
+// After 이후 메시지 조회 / List later messages
+func (s *store) After(afterID uint, limit int) ([]*Message, error) {
+	var messages []*Message
+	if err := s.db.Where("id > ?", afterID).Order("id").Limit(limit).Find(&messages).Error; err != nil {
+		return nil, fmt.Errorf("failed to list outbox messages: %w", err)
+	}
+	return messages, nil
+}
+
+// LastID 마지막 메시지 ID 조회 / Get the last message ID
+func (s *store) LastID() (uint, error) {
+	var id uint
+	if err := s.db.Model(&Message{}).Select("COALESCE(MAX(id), 0)").Scan(&id).Error; err != nil {
+		return 0, fmt.Errorf("failed to get last outbox message id: %w", err)
+	}
+	return id, nil
+}
+
+// MarkPublished 발행 완료 기록 / Record a successful publish
+func (s *store) MarkPublished(id uint, now time.Time) error {
+	if err := s.db.Model(&Message{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       StatusPublished,
+		"published_at": now,
+		"last_error":   "",
+	}).Error; err != nil {
+		return fmt.Errorf("failed to mark outbox message published: %w", err)
+	}
+	return nil
+}
+
+// PurgePublished 발행된 메시지 정리 / Clean up published messages
+func (s *store) PurgePublished(before time.Time) (int64, error) {
+	result := s.db.Where("status = ? AND published_at < ?", StatusPublished, before).Delete(&Message{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge published outbox messages: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// MarkFailed 발행 실패 기록 / Record a failed publish
+func (s *store) MarkFailed(id uint, attempts int, nextAttemptAt time.Time, lastErr string, dead bool) error {
+	status := StatusPending
+	if dead {
+		status = StatusDead
+	}
+	if len(lastErr) > maxLastErrorLength {
+		lastErr = lastErr[:maxLastErrorLength]
+	}
+
+	if err := s.db.Model(&Message{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          status,
+		"attempts":        attempts,
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      lastErr,
+	}).Error; err != nil {
+		return fmt.Errorf("failed to mark outbox message failed: %w", err)
+	}
+	return nil
+}
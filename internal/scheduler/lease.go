@@ -0,0 +1,71 @@
+// Package scheduler runs periodic background jobs, using database leases so only one replica runs each job at a time
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Lease 작업 임대 레코드 / Job lease record
+type Lease struct {
+	Name      string    `gorm:"primaryKey;size:100"`
+	Holder    string    `gorm:"not null;size:100"`
+	ExpiresAt time.Time `gorm:"not null"`
+}
+
+// TableName 테이블 이름 지정 / Specify table name
+func (Lease) TableName() string {
+	return "scheduler_leases"
+}
+
+// LeaseStore 작업 임대 저장소 인터페이스 / Job lease store interface
+type LeaseStore interface {
+	// Acquire 임대 획득 또는 연장, 보유 여부 반환 / Acquire or extend a lease and report whether holder owns it
+	Acquire(name, holder string, ttl time.Duration, now time.Time) (bool, error)
+	// Release 보유 중인 임대 해제 / Release a lease the holder owns
+	Release(name, holder string) error
+}
+
+// leaseStore GORM 기반 임대 저장소 구현체 / GORM-backed lease store implementation
+type leaseStore struct {
+	db *gorm.DB
+}
+
+// NewLeaseStore 새 임대 저장소 생성 / Create new lease store
+func NewLeaseStore(db *gorm.DB) LeaseStore {
+	return &leaseStore{db: db}
+}
+
+// Acquire 임대 획득 / Acquire a lease
+// 행이 없으면 INSERT, 있으면 보유자 본인이거나 만료된 경우에만 UPDATE / Inserts when missing; otherwise updates only if held by holder or expired
+func (s *leaseStore) Acquire(name, holder string, ttl time.Duration, now time.Time) (bool, error) {
+	expiresAt := now.Add(ttl)
+
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&Lease{Name: name, Holder: holder, ExpiresAt: expiresAt})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to create lease: %w", result.Error)
+	}
+	if result.RowsAffected == 1 {
+		return true, nil
+	}
+
+	result = s.db.Model(&Lease{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", name, holder, now).
+		Updates(map[string]interface{}{"holder": holder, "expires_at": expiresAt})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to acquire lease: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Release 임대 해제 / Release a lease
+func (s *leaseStore) Release(name, holder string) error {
+	if err := s.db.Where("name = ? AND holder = ?", name, holder).Delete(&Lease{}).Error; err != nil {
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+	return nil
+}
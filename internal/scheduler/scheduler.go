@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Job 주기 작업 / Periodic job
+type Job struct {
+	// Name 임대 이름으로도 사용 / Also used as the lease name
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// minLeaseTTL 임대 최소 유지 시간 / Shortest lease lifetime
+const minLeaseTTL = 30 * time.Second
+
+// Scheduler 임대 기반 주기 작업 실행기 / Lease-based periodic job runner
+// 임대는 Interval과 30초 중 긴 시간 동안 유지되고 실행 중에는 3분의 1마다 연장됨
+// 보유 레플리카가 멈추면 임대가 만료된 뒤 다른 레플리카가 이어받음
+// Leases last the longer of Interval and 30s and are renewed every third of that while a run is in progress,
+// so a slow run never overlaps another replica; if the holder stops, another replica takes over once the lease expires
+type Scheduler struct {
+	leases LeaseStore
+	holder string
+	jobs   []Job
+	minTTL time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New 새 스케줄러 생성 / Create new scheduler
+// holder는 레플리카마다 고유해야 함 / holder must be unique per replica
+func New(leases LeaseStore, holder string) *Scheduler {
+	return &Scheduler{leases: leases, holder: holder, minTTL: minLeaseTTL}
+}
+
+// Add 작업 등록 (Start 전에 호출) / Register a job (call before Start)
+// Interval이 0 이하인 작업은 비활성화 / Jobs with a non-positive Interval are disabled
+func (s *Scheduler) Add(job Job) {
+	if job.Interval <= 0 {
+		zap.L().Info("Scheduled job disabled", zap.String("job", job.Name))
+		return
+	}
+	s.jobs = append(s.jobs, job)
+}
+
+// Start 작업별 고루틴 시작 / Start one goroutine per job
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+}
+
+// Stop 실행 중인 작업을 기다린 뒤 임대 해제 / Wait for running jobs, then release leases
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+
+	for _, job := range s.jobs {
+		if err := s.leases.Release(job.Name, s.holder); err != nil {
+			zap.L().Warn("Failed to release job lease", zap.String("job", job.Name), zap.Error(err))
+		}
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(ctx, job)
+		}
+	}
+}
+
+// RunOnce 임대를 획득한 경우에만 작업 한 번 실행 / Run a job once if its lease can be acquired
+// 실행했으면 true 반환 / Returns true when the job ran
+func (s *Scheduler) RunOnce(ctx context.Context, job Job) bool {
+	logger := zap.L().With(
+		zap.String("method", "scheduler.RunOnce"),
+		zap.String("job", job.Name))
+
+	ttl := max(job.Interval, s.minTTL)
+	acquired, err := s.leases.Acquire(job.Name, s.holder, ttl, time.Now())
+	if err != nil {
+		logger.Error("Failed to acquire job lease", zap.Error(err))
+		return false
+	}
+	if !acquired {
+		logger.Debug("Job lease held by another replica")
+		return false
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	var renewal sync.WaitGroup
+	renewal.Add(1)
+	go func() {
+		defer renewal.Done()
+		s.renew(runCtx, cancel, job.Name, ttl, logger)
+	}()
+
+	if err := job.Run(runCtx); err != nil {
+		logger.Error("Scheduled job failed", zap.Error(err))
+	}
+	cancel()
+	renewal.Wait()
+	return true
+}
+
+// renew 실행 중 임대 연장, 임대를 잃으면 실행 취소 / Renew the lease during a run, cancelling the run if the lease is lost
+func (s *Scheduler) renew(ctx context.Context, cancel context.CancelFunc, name string, ttl time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			held, err := s.leases.Acquire(name, s.holder, ttl, time.Now())
+			if err != nil || !held {
+				logger.Warn("Lost job lease, cancelling the run", zap.Bool("held", held), zap.Error(err))
+				cancel()
+				return
+			}
+		}
+	}
+}
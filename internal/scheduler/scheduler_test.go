@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupLeaseStore(t *testing.T) LeaseStore {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Lease{}))
+	return NewLeaseStore(db)
+}
+
+func TestLeaseStore_Acquire(t *testing.T) {
+	store := setupLeaseStore(t)
+	now := time.Now()
+
+	acquired, err := store.Acquire("job", "replica-a", time.Minute, now)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// 다른 레플리카는 만료 전까지 획득 불가 / Another replica cannot take it before expiry
+	acquired, err = store.Acquire("job", "replica-b", time.Minute, now.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	// 보유자는 연장 가능 / The holder can extend it
+	acquired, err = store.Acquire("job", "replica-a", time.Minute, now.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// 만료 후에는 다른 레플리카가 이어받음 / Another replica takes over after expiry
+	acquired, err = store.Acquire("job", "replica-b", time.Minute, now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	require.NoError(t, store.Release("job", "replica-b"))
+	acquired, err = store.Acquire("job", "replica-a", time.Minute, now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, acquired)
+}
+
+func TestScheduler_RunOnceRequiresLease(t *testing.T) {
+	store := setupLeaseStore(t)
+	runs := 0
+	job := Job{Name: "sweep", Interval: time.Minute, Run: func(context.Context) error {
+		runs++
+		return nil
+	}}
+
+	first := New(store, "replica-a")
+	second := New(store, "replica-b")
+
+	assert.True(t, first.RunOnce(context.Background(), job))
+	assert.False(t, second.RunOnce(context.Background(), job))
+	assert.True(t, first.RunOnce(context.Background(), job))
+	assert.Equal(t, 2, runs)
+}
+
+// fakeLeases 획득 횟수를 세고 연장 실패를 흉내 내는 임대 저장소 / Lease store counting acquisitions and simulating lost renewals
+type fakeLeases struct {
+	mu       sync.Mutex
+	acquires int
+	ttls     []time.Duration
+	lose     bool
+}
+
+func (f *fakeLeases) Acquire(_, _ string, ttl time.Duration, _ time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.acquires++
+	f.ttls = append(f.ttls, ttl)
+	return f.acquires == 1 || !f.lose, nil
+}
+
+func (f *fakeLeases) Release(_, _ string) error { return nil }
+
+func (f *fakeLeases) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.acquires
+}
+
+func TestScheduler_RenewsLeaseDuringRun(t *testing.T) {
+	leases := &fakeLeases{}
+	s := New(leases, "replica-a")
+	s.minTTL = 30 * time.Millisecond
+
+	job := Job{Name: "relay", Interval: time.Millisecond, Run: func(ctx context.Context) error {
+		for leases.count() < 3 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Millisecond):
+			}
+		}
+		return nil
+	}}
+
+	assert.True(t, s.RunOnce(context.Background(), job))
+	assert.GreaterOrEqual(t, leases.count(), 3)
+	// 임대는 최소 유지 시간 이상 / The lease lasts at least the minimum lifetime
+	assert.Equal(t, 30*time.Millisecond, leases.ttls[0])
+}
+
+func TestScheduler_CancelsRunWhenLeaseIsLost(t *testing.T) {
+	leases := &fakeLeases{lose: true}
+	s := New(leases, "replica-a")
+	s.minTTL = 30 * time.Millisecond
+
+	var runErr error
+	job := Job{Name: "relay", Interval: time.Millisecond, Run: func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			runErr = ctx.Err()
+		case <-time.After(5 * time.Second):
+		}
+		return runErr
+	}}
+
+	assert.True(t, s.RunOnce(context.Background(), job))
+	assert.ErrorIs(t, runErr, context.Canceled)
+}
@@ -0,0 +1,4 @@
+-- Drop composite index for keyset pagination on users
+-- 사용자 키셋 페이지네이션 복합 인덱스 삭제
+
+DROP INDEX IF EXISTS idx_users_created_at_id;
@@ -0,0 +1,4 @@
+-- Add composite index for keyset pagination on users
+-- 사용자 키셋 페이지네이션을 위한 복합 인덱스 추가
+
+CREATE INDEX idx_users_created_at_id ON users(created_at, id);
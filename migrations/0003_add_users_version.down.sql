@@ -0,0 +1,4 @@
+-- Drop version column
+-- 버전 컬럼 삭제
+
+ALTER TABLE users DROP COLUMN version;
@@ -0,0 +1,4 @@
+-- Add version column for optimistic concurrency control
+-- 낙관적 동시성 제어를 위한 버전 컬럼 추가
+
+ALTER TABLE users ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+-- Drop idempotency keys table
+-- 멱등성 키 테이블 삭제
+
+DROP TABLE IF EXISTS idempotency_keys;
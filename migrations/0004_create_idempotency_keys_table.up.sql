@@ -0,0 +1,16 @@
+-- Create idempotency keys table for replaying retried POST requests
+-- 재시도된 POST 요청 재전송을 위한 멱등성 키 테이블 생성
+
+CREATE TABLE idempotency_keys (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,  -- MySQL: AUTO_INCREMENT, PostgreSQL: BIGSERIAL
+    idempotency_key VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    content_type VARCHAR(255),
+    body BLOB,                             -- PostgreSQL: BYTEA
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_idempotency_keys_idempotency_key ON idempotency_keys(idempotency_key);
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,7 @@
+-- Drop status transition columns
+-- 상태 전이 컬럼 삭제
+
+ALTER TABLE users DROP COLUMN suspended_until;
+ALTER TABLE users DROP COLUMN status_changed_at;
+ALTER TABLE users DROP COLUMN status_changed_by;
+ALTER TABLE users DROP COLUMN status_reason;
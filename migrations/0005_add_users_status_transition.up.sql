@@ -0,0 +1,7 @@
+-- Record the reason, actor and time of the last status transition
+-- 마지막 상태 전이의 사유, 주체, 시각 기록
+
+ALTER TABLE users ADD COLUMN status_reason VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN status_changed_by VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN status_changed_at TIMESTAMP NULL;   -- PostgreSQL: TIMESTAMP WITH TIME ZONE
+ALTER TABLE users ADD COLUMN suspended_until TIMESTAMP NULL;     -- PostgreSQL: TIMESTAMP WITH TIME ZONE
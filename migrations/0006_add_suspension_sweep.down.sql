@@ -0,0 +1,6 @@
+-- Drop job leases and the suspension expiry index
+-- 작업 임대 테이블 및 정지 만료 인덱스 삭제
+
+DROP TABLE IF EXISTS scheduler_leases;
+
+DROP INDEX IF EXISTS idx_users_suspended_until;
@@ -0,0 +1,10 @@
+-- Index suspension expiry and add job leases for the reactivation sweep
+-- 재활성화 작업을 위한 정지 만료 인덱스 및 작업 임대 테이블 추가
+
+CREATE INDEX idx_users_suspended_until ON users(suspended_until);
+
+CREATE TABLE scheduler_leases (
+    name VARCHAR(100) PRIMARY KEY,
+    holder VARCHAR(100) NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,4 @@
+-- Drop audit events table
+-- 감사 이벤트 테이블 삭제
+
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,15 @@
+-- Create append-only audit events table for entity change history
+-- 엔터티 변경 이력을 위한 추가 전용 감사 이벤트 테이블 생성
+
+CREATE TABLE audit_events (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,  -- MySQL: AUTO_INCREMENT, PostgreSQL: BIGSERIAL
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(64) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    request_id VARCHAR(100),
+    changes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_events_entity ON audit_events(entity_type, entity_id);
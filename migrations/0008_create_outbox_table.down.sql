@@ -0,0 +1,4 @@
+-- Drop outbox table
+-- 아웃박스 테이블 삭제
+
+DROP TABLE IF EXISTS outbox;
@@ -0,0 +1,19 @@
+-- Create transactional outbox table for domain events awaiting publish
+-- 발행 대기 중인 도메인 이벤트를 위한 트랜잭셔널 아웃박스 테이블 생성
+
+CREATE TABLE outbox (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,  -- MySQL: AUTO_INCREMENT, PostgreSQL: BIGSERIAL
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id VARCHAR(64) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',  -- pending, published, dead
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NULL,
+    last_error VARCHAR(1000),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP NULL
+);
+
+CREATE INDEX idx_outbox_aggregate ON outbox(aggregate_type, aggregate_id);
+CREATE INDEX idx_outbox_status ON outbox(status);
@@ -0,0 +1,6 @@
+-- Drop webhook tables
+-- 웹훅 테이블 삭제
+
+DROP TABLE IF EXISTS webhook_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
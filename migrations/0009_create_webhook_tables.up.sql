@@ -0,0 +1,42 @@
+-- Create webhook subscription, delivery and attempt tables
+-- 웹훅 구독, 전송, 시도 기록 테이블 생성
+
+CREATE TABLE webhook_subscriptions (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,  -- MySQL: AUTO_INCREMENT, PostgreSQL: BIGSERIAL
+    url VARCHAR(2048) NOT NULL,
+    event_types TEXT NOT NULL,             -- JSON array of event types
+    secret VARCHAR(255) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE webhook_deliveries (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    subscription_id BIGINT NOT NULL,
+    message_id BIGINT NOT NULL,            -- outbox.id
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',  -- pending, succeeded, failed
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NULL,
+    last_status_code INT,
+    last_error VARCHAR(1000),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP NULL
+);
+
+CREATE UNIQUE INDEX idx_webhook_deliveries_message ON webhook_deliveries(subscription_id, message_id);
+CREATE INDEX idx_webhook_deliveries_status ON webhook_deliveries(status);
+
+CREATE TABLE webhook_attempts (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    delivery_id BIGINT NOT NULL,
+    status_code INT,
+    error VARCHAR(1000),
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_attempts_delivery_id ON webhook_attempts(delivery_id);
@@ -0,0 +1,4 @@
+-- Drop API keys table
+-- API 키 테이블 삭제
+
+DROP TABLE IF EXISTS api_keys;
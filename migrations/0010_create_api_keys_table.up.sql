@@ -0,0 +1,19 @@
+-- Create API keys table; only the token hash and its lookup prefix are stored
+-- API 키 테이블 생성, 토큰 해시와 조회 접두사만 저장
+
+CREATE TABLE api_keys (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,  -- MySQL: AUTO_INCREMENT, PostgreSQL: BIGSERIAL
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(32) NOT NULL,
+    hash VARCHAR(64) NOT NULL,             -- SHA-256 hex of the whole token
+    scopes TEXT NOT NULL,                  -- JSON array of scopes
+    rotated_from_id BIGINT NULL,           -- api_keys.id of the key this one replaced
+    expires_at TIMESTAMP NULL,
+    last_used_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_api_keys_prefix ON api_keys(prefix);
+CREATE INDEX idx_api_keys_expires_at ON api_keys(expires_at);
@@ -0,0 +1,6 @@
+-- Drop refresh token table and password hash column
+-- 리프레시 토큰 테이블과 비밀번호 해시 컬럼 삭제
+
+DROP TABLE IF EXISTS refresh_tokens;
+
+ALTER TABLE users DROP COLUMN password_hash;
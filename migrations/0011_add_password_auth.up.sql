@@ -0,0 +1,20 @@
+-- Add password hashes to users and create the server-side refresh token table
+-- 사용자 비밀번호 해시 컬럼 추가와 서버 측 리프레시 토큰 테이블 생성
+
+ALTER TABLE users ADD COLUMN password_hash VARCHAR(255) NOT NULL DEFAULT '';  -- argon2id PHC string; empty means no password login
+
+CREATE TABLE refresh_tokens (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,  -- MySQL: AUTO_INCREMENT, PostgreSQL: BIGSERIAL
+    family_id VARCHAR(32) NOT NULL,        -- session ID shared by every token rotated from one login
+    user_id BIGINT NOT NULL,
+    hash VARCHAR(64) NOT NULL,             -- SHA-256 hex of the whole token
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,                -- set when the token is rotated; a second use revokes the family
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_refresh_tokens_hash ON refresh_tokens(hash);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
@@ -0,0 +1,5 @@
+-- Drop login lockout and failure tables
+-- 로그인 잠금과 실패 기록 테이블 삭제
+
+DROP TABLE IF EXISTS login_lockouts;
+DROP TABLE IF EXISTS login_failures;
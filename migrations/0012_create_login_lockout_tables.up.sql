@@ -0,0 +1,24 @@
+-- Create login failure and lockout tables for per-email and per-IP throttling
+-- 이메일별, IP별 로그인 제한을 위한 실패 기록과 잠금 테이블 생성
+
+CREATE TABLE login_failures (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,  -- MySQL: AUTO_INCREMENT, PostgreSQL: BIGSERIAL
+    kind VARCHAR(16) NOT NULL,             -- account or ip
+    identifier VARCHAR(255) NOT NULL,      -- lowercased email or client IP
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_login_failures_identifier ON login_failures(kind, identifier, created_at);
+
+CREATE TABLE login_lockouts (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,  -- MySQL: AUTO_INCREMENT, PostgreSQL: BIGSERIAL
+    kind VARCHAR(16) NOT NULL,
+    identifier VARCHAR(255) NOT NULL,
+    level INT NOT NULL DEFAULT 0,          -- consecutive lockouts; each one doubles the duration
+    locked_until TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_login_lockouts_identifier ON login_lockouts(kind, identifier);
+CREATE INDEX idx_login_lockouts_locked_until ON login_lockouts(locked_until);
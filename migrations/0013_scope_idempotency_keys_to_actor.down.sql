@@ -0,0 +1,9 @@
+-- Return idempotency keys to a single namespace
+-- 멱등성 키를 하나의 네임스페이스로 되돌림
+
+DELETE FROM idempotency_keys;  -- keys of different actors may collide once the actor is dropped
+
+DROP INDEX IF EXISTS idx_idempotency_keys_actor_key;  -- MySQL: DROP INDEX idx_idempotency_keys_actor_key ON idempotency_keys
+CREATE UNIQUE INDEX idx_idempotency_keys_idempotency_key ON idempotency_keys(idempotency_key);
+
+ALTER TABLE idempotency_keys DROP COLUMN actor;
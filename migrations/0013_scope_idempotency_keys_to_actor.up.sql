@@ -0,0 +1,7 @@
+-- Scope idempotency keys to the request actor so callers cannot replay each other's responses
+-- 호출자가 서로의 응답을 재전송받지 못하도록 멱등성 키를 요청 주체별로 구분
+
+ALTER TABLE idempotency_keys ADD COLUMN actor VARCHAR(100) NOT NULL DEFAULT '';  -- middleware.GetActor, such as api-key:<prefix> or jwt:<sub>
+
+DROP INDEX IF EXISTS idx_idempotency_keys_idempotency_key;  -- MySQL: DROP INDEX idx_idempotency_keys_idempotency_key ON idempotency_keys
+CREATE UNIQUE INDEX idx_idempotency_keys_actor_key ON idempotency_keys(actor, idempotency_key);
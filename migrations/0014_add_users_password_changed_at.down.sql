@@ -0,0 +1,4 @@
+-- Drop the password change time
+-- 비밀번호 변경 시각 컬럼 삭제
+
+ALTER TABLE users DROP COLUMN password_changed_at;
@@ -0,0 +1,4 @@
+-- Record when each user's password last changed so older sessions stop refreshing
+-- 이전 세션이 더 이상 갱신되지 않도록 사용자별 마지막 비밀번호 변경 시각 기록
+
+ALTER TABLE users ADD COLUMN password_changed_at TIMESTAMP NULL;  -- refresh tokens issued before this are rejected and their session revoked
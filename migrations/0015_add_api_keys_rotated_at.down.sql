@@ -0,0 +1,4 @@
+-- Drop the API key rotation time
+-- API 키 교체 시각 컬럼 삭제
+
+ALTER TABLE api_keys DROP COLUMN rotated_at;
@@ -0,0 +1,4 @@
+-- Record when each API key was rotated so a key can only be rotated once
+-- 키가 한 번만 교체되도록 API 키별 교체 시각 기록
+
+ALTER TABLE api_keys ADD COLUMN rotated_at TIMESTAMP NULL;  -- set by the rotation that issued the replacement
@@ -0,0 +1,65 @@
+// Package etag provides entity tag helpers for version-based conditional requests
+package etag
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidETag is returned when a precondition header cannot be parsed.
+var ErrInvalidETag = errors.New("invalid entity tag")
+
+// Format 버전을 강한 ETag로 변환 / Format a version as a strong entity tag
+func Format(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// ParseIfMatch If-Match 헤더에서 기대 버전 추출 / Extract the expected version from an If-Match header
+// 헤더가 없거나 "*"이면 0 (무조건) 반환 / Returns 0 (unconditional) when absent or "*"
+// 약한 태그는 강한 비교에 사용할 수 없으므로 거부 / Weak tags are rejected because If-Match uses strong comparison
+func ParseIfMatch(header string) (uint, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	if strings.Contains(header, ",") {
+		return 0, ErrInvalidETag
+	}
+	if strings.HasPrefix(header, "W/") {
+		return 0, ErrInvalidETag
+	}
+	return parseVersion(header)
+}
+
+// MatchIfNoneMatch If-None-Match 헤더가 현재 버전과 일치하는지 확인 / Report whether If-None-Match matches the current version
+// 약한 비교를 사용 / Uses weak comparison
+func MatchIfNoneMatch(header string, version uint) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if parsed, err := parseVersion(tag); err == nil && parsed == version {
+			return true
+		}
+	}
+	return false
+}
+
+func parseVersion(tag string) (uint, error) {
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, ErrInvalidETag
+	}
+
+	version, err := strconv.ParseUint(tag[1:len(tag)-1], 10, 0)
+	if err != nil || version == 0 {
+		return 0, ErrInvalidETag
+	}
+	return uint(version), nil
+}
@@ -0,0 +1,50 @@
+package etag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, `"7"`, Format(7))
+}
+
+func TestParseIfMatch(t *testing.T) {
+	testCases := []struct {
+		name    string
+		header  string
+		want    uint
+		wantErr bool
+	}{
+		{name: "absent", header: "", want: 0},
+		{name: "wildcard", header: "*", want: 0},
+		{name: "strong tag", header: `"3"`, want: 3},
+		{name: "weak tag", header: `W/"3"`, wantErr: true},
+		{name: "list", header: `"3", "4"`, wantErr: true},
+		{name: "unquoted", header: "3", wantErr: true},
+		{name: "zero version", header: `"0"`, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseIfMatch(tc.header)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidETag)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMatchIfNoneMatch(t *testing.T) {
+	assert.False(t, MatchIfNoneMatch("", 1))
+	assert.True(t, MatchIfNoneMatch("*", 1))
+	assert.True(t, MatchIfNoneMatch(`"1"`, 1))
+	assert.True(t, MatchIfNoneMatch(`W/"1"`, 1))
+	assert.True(t, MatchIfNoneMatch(`"2", "1"`, 1))
+	assert.False(t, MatchIfNoneMatch(`"2"`, 1))
+	assert.False(t, MatchIfNoneMatch("garbage", 1))
+}
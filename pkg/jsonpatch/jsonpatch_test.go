@@ -0,0 +1,146 @@
+package jsonpatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePatch(t *testing.T) {
+	// RFC 7396 Appendix A 예시 일부 / Subset of RFC 7396 Appendix A examples
+	testCases := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{name: "replace member", doc: `{"a":"b"}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{name: "add member", doc: `{"a":"b"}`, patch: `{"b":"c"}`, want: `{"a":"b","b":"c"}`},
+		{name: "null removes", doc: `{"a":"b","b":"c"}`, patch: `{"a":null}`, want: `{"b":"c"}`},
+		{name: "array replaced", doc: `{"a":["b"]}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{name: "nested", doc: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, want: `{"a":{"b":"d"}}`},
+		{name: "non-object patch", doc: `{"a":"foo"}`, patch: `["c"]`, want: `["c"]`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tc.doc), []byte(tc.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	// RFC 6902 Appendix A 예시 일부 / Subset of RFC 6902 Appendix A examples
+	testCases := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{
+			name:  "add object member",
+			doc:   `{"foo":"bar"}`,
+			patch: `[{"op":"add","path":"/baz","value":"qux"}]`,
+			want:  `{"baz":"qux","foo":"bar"}`,
+		},
+		{
+			name:  "add array element",
+			doc:   `{"foo":["bar","baz"]}`,
+			patch: `[{"op":"add","path":"/foo/1","value":"qux"}]`,
+			want:  `{"foo":["bar","qux","baz"]}`,
+		},
+		{
+			name:  "append array element",
+			doc:   `{"foo":["bar"]}`,
+			patch: `[{"op":"add","path":"/foo/-","value":"baz"}]`,
+			want:  `{"foo":["bar","baz"]}`,
+		},
+		{
+			name:  "remove array element",
+			doc:   `{"foo":["bar","qux","baz"]}`,
+			patch: `[{"op":"remove","path":"/foo/1"}]`,
+			want:  `{"foo":["bar","baz"]}`,
+		},
+		{
+			name:  "replace value",
+			doc:   `{"baz":"qux","foo":"bar"}`,
+			patch: `[{"op":"replace","path":"/baz","value":"boo"}]`,
+			want:  `{"baz":"boo","foo":"bar"}`,
+		},
+		{
+			name:  "move value",
+			doc:   `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch: `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			want:  `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{
+			name:  "copy value",
+			doc:   `{"foo":{"bar":"baz"}}`,
+			patch: `[{"op":"copy","from":"/foo","path":"/qux"}]`,
+			want:  `{"foo":{"bar":"baz"},"qux":{"bar":"baz"}}`,
+		},
+		{
+			name:  "successful test then replace",
+			doc:   `{"baz":"qux","foo":["a",2,"c"]}`,
+			patch: `[{"op":"test","path":"/foo","value":["a",2,"c"]},{"op":"replace","path":"/baz","value":"x"}]`,
+			want:  `{"baz":"x","foo":["a",2,"c"]}`,
+		},
+		{
+			name:  "add null",
+			doc:   `{"foo":"bar"}`,
+			patch: `[{"op":"add","path":"/baz","value":null}]`,
+			want:  `{"baz":null,"foo":"bar"}`,
+		},
+		{
+			name:  "test and replace null",
+			doc:   `{"baz":null}`,
+			patch: `[{"op":"test","path":"/baz","value":null},{"op":"replace","path":"/baz","value":null}]`,
+			want:  `{"baz":null}`,
+		},
+		{
+			name:  "escaped pointer",
+			doc:   `{"a/b":1,"m~n":2}`,
+			patch: `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`,
+			want:  `{"a/b":3}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Apply([]byte(tc.doc), []byte(tc.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		doc     string
+		patch   string
+		wantErr error
+	}{
+		{name: "failed test", doc: `{"baz":"qux"}`, patch: `[{"op":"test","path":"/baz","value":"bar"}]`, wantErr: ErrTestFailed},
+		{name: "test missing path", doc: `{}`, patch: `[{"op":"test","path":"/baz","value":"bar"}]`, wantErr: ErrTestFailed},
+		{name: "remove missing path", doc: `{}`, patch: `[{"op":"remove","path":"/baz"}]`, wantErr: ErrInvalidPatch},
+		{name: "add to missing parent", doc: `{}`, patch: `[{"op":"add","path":"/a/b","value":1}]`, wantErr: ErrInvalidPatch},
+		{name: "array index out of range", doc: `{"a":[1]}`, patch: `[{"op":"add","path":"/a/5","value":1}]`, wantErr: ErrInvalidPatch},
+		{name: "unknown op", doc: `{}`, patch: `[{"op":"frobnicate","path":"/a"}]`, wantErr: ErrInvalidPatch},
+		{name: "missing value", doc: `{}`, patch: `[{"op":"add","path":"/a"}]`, wantErr: ErrInvalidPatch},
+		{name: "missing test value", doc: `{"a":null}`, patch: `[{"op":"test","path":"/a"}]`, wantErr: ErrInvalidPatch},
+		{name: "null test mismatch", doc: `{"a":1}`, patch: `[{"op":"test","path":"/a","value":null}]`, wantErr: ErrTestFailed},
+		{name: "move into child", doc: `{"a":{"b":1}}`, patch: `[{"op":"move","from":"/a","path":"/a/c"}]`, wantErr: ErrInvalidPatch},
+		{name: "not an array", doc: `{}`, patch: `{"op":"add"}`, wantErr: ErrInvalidPatch},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Apply([]byte(tc.doc), []byte(tc.patch))
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Package listquery provides shared list query primitives: keyset cursors, sorting, and structured filters
+package listquery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor 키셋 페이지네이션 커서 / Keyset pagination cursor
+// (created_at, id) 조합으로 마지막 행의 위치를 표현 / Marks the last row position by (created_at, id)
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+// cursorPayload 커서 직렬화 형식 / Cursor wire format
+type cursorPayload struct {
+	CreatedAt string `json:"c"`
+	ID        uint   `json:"i"`
+}
+
+// Encode 커서를 불투명 문자열로 인코딩 / Encode cursor as an opaque string
+func (c Cursor) Encode() string {
+	payload, _ := json.Marshal(cursorPayload{
+		CreatedAt: c.CreatedAt.Format(time.RFC3339Nano),
+		ID:        c.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeCursor 불투명 문자열을 커서로 디코딩 / Decode an opaque string into a cursor
+func DecodeCursor(raw string) (Cursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var p cursorPayload
+	if err := json.Unmarshal(payload, &p); err != nil || p.ID == 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, p.CreatedAt)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return Cursor{CreatedAt: createdAt, ID: p.ID}, nil
+}
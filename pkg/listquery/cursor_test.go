@@ -0,0 +1,39 @@
+package listquery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_EncodeDecodeRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.FixedZone("KST", 9*60*60))
+	cursor := Cursor{CreatedAt: createdAt, ID: 42}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(42), decoded.ID)
+	assert.True(t, createdAt.Equal(decoded.CreatedAt))
+}
+
+func TestDecodeCursor_RejectsMalformedInput(t *testing.T) {
+	testCases := []struct {
+		name string
+		raw  string
+	}{
+		{name: "not base64", raw: "!!!"},
+		{name: "not json", raw: "bm90LWpzb24"},
+		{name: "missing id", raw: Cursor{CreatedAt: time.Now()}.Encode()},
+		{name: "bad timestamp", raw: "eyJjIjoieCIsImkiOjF9"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := DecodeCursor(tc.raw)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
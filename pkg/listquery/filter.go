@@ -0,0 +1,271 @@
+package listquery
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// MaxFilterConditions 요청당 최대 필터 조건 수 / Maximum filter conditions per request
+	MaxFilterConditions = 20
+	// MaxFilterValues in/nin 연산자의 최대 값 개수 / Maximum values for in/nin operators
+	MaxFilterValues = 100
+)
+
+// ErrInvalidFilter is returned when a filter parameter cannot be parsed or is not allowed.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// FilterError 필터 파라미터 오류 상세 / Filter parameter error details
+type FilterError struct {
+	Param  string `json:"param"`
+	Reason string `json:"reason"`
+}
+
+// Error implements the error interface.
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrInvalidFilter, e.Param, e.Reason)
+}
+
+// Unwrap lets errors.Is match ErrInvalidFilter.
+func (e *FilterError) Unwrap() error {
+	return ErrInvalidFilter
+}
+
+// Operator 필터 비교 연산자 / Filter comparison operator
+type Operator string
+
+const (
+	// OpEq matches equal values.
+	OpEq Operator = "eq"
+	// OpNe matches values that are not equal.
+	OpNe Operator = "ne"
+	// OpGt matches values greater than the operand.
+	OpGt Operator = "gt"
+	// OpGte matches values greater than or equal to the operand.
+	OpGte Operator = "gte"
+	// OpLt matches values less than the operand.
+	OpLt Operator = "lt"
+	// OpLte matches values less than or equal to the operand.
+	OpLte Operator = "lte"
+	// OpIn matches any of a comma-separated set of values.
+	OpIn Operator = "in"
+	// OpNin matches none of a comma-separated set of values.
+	OpNin Operator = "nin"
+	// OpPrefix matches strings starting with the operand.
+	OpPrefix Operator = "prefix"
+	// OpSuffix matches strings ending with the operand.
+	OpSuffix Operator = "suffix"
+	// OpContains matches strings containing the operand.
+	OpContains Operator = "contains"
+)
+
+// FieldType 필터 값 타입 / Filter value type
+type FieldType int
+
+const (
+	// TypeString compares values as strings.
+	TypeString FieldType = iota
+	// TypeInt compares values as unsigned integers.
+	TypeInt
+	// TypeTime compares values as RFC 3339 timestamps or YYYY-MM-DD dates.
+	TypeTime
+)
+
+var (
+	// EqualityOps 동등 비교 연산자 / Equality operators
+	EqualityOps = []Operator{OpEq, OpNe, OpIn, OpNin}
+	// RangeOps 범위 비교 연산자 / Range operators
+	RangeOps = []Operator{OpEq, OpNe, OpGt, OpGte, OpLt, OpLte}
+	// TextOps 문자열 비교 연산자 / Text operators
+	TextOps = []Operator{OpEq, OpNe, OpIn, OpNin, OpPrefix, OpSuffix, OpContains}
+)
+
+// FieldSpec 필터 가능한 필드 정의 / Filterable field definition
+type FieldSpec struct {
+	// Column 데이터베이스 컬럼명 (비어 있으면 필드명 사용) / Database column (defaults to the field name)
+	Column string
+	Type   FieldType
+	Ops    []Operator
+	// Validate 값 추가 검증 (선택) / Optional extra value validation
+	Validate func(value string) error
+}
+
+// Schema 필드명에서 필드 정의로의 매핑 / Mapping from field name to field definition
+type Schema map[string]FieldSpec
+
+// Condition 단일 필터 조건 / Single filter condition
+type Condition struct {
+	Field  string
+	Column string
+	Op     Operator
+	Values []interface{}
+}
+
+// Filter AND로 결합된 필터 조건 목록 / Filter conditions combined with AND
+type Filter []Condition
+
+// ParseFilter "field[op]=value" 형식의 파라미터를 필터로 파싱 / Parse "field[op]=value" parameters into a filter
+// 대괄호가 없는 파라미터는 필터가 아니므로 무시 / Parameters without brackets are not filters and are ignored
+func ParseFilter(params map[string]string, schema Schema) (Filter, error) {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var filter Filter
+	for _, key := range keys {
+		field, op, ok := splitFilterKey(key)
+		if !ok {
+			continue
+		}
+
+		cond, err := parseCondition(key, field, op, params[key], schema)
+		if err != nil {
+			return nil, err
+		}
+
+		filter = append(filter, cond)
+		if len(filter) > MaxFilterConditions {
+			return nil, &FilterError{Param: key, Reason: fmt.Sprintf("at most %d conditions allowed", MaxFilterConditions)}
+		}
+	}
+
+	return filter, nil
+}
+
+// splitFilterKey "field[op]" 키 분해 / Split a "field[op]" key
+func splitFilterKey(key string) (string, Operator, bool) {
+	open := strings.IndexByte(key, '[')
+	if open <= 0 || !strings.HasSuffix(key, "]") {
+		return "", "", false
+	}
+	return key[:open], Operator(key[open+1 : len(key)-1]), true
+}
+
+func parseCondition(param, field string, op Operator, raw string, schema Schema) (Condition, error) {
+	spec, ok := schema[field]
+	if !ok {
+		return Condition{}, &FilterError{Param: param, Reason: "unsupported field"}
+	}
+	if !slices.Contains(spec.Ops, op) {
+		return Condition{}, &FilterError{Param: param, Reason: fmt.Sprintf("unsupported operator %q", op)}
+	}
+
+	rawValues := []string{raw}
+	if op == OpIn || op == OpNin {
+		rawValues = strings.Split(raw, ",")
+		if len(rawValues) > MaxFilterValues {
+			return Condition{}, &FilterError{Param: param, Reason: fmt.Sprintf("at most %d values allowed", MaxFilterValues)}
+		}
+	}
+
+	values := make([]interface{}, 0, len(rawValues))
+	for _, rawValue := range rawValues {
+		rawValue = strings.TrimSpace(rawValue)
+		if rawValue == "" {
+			return Condition{}, &FilterError{Param: param, Reason: "empty value"}
+		}
+		if spec.Validate != nil {
+			if err := spec.Validate(rawValue); err != nil {
+				return Condition{}, &FilterError{Param: param, Reason: err.Error()}
+			}
+		}
+
+		value, err := convertValue(spec.Type, rawValue)
+		if err != nil {
+			return Condition{}, &FilterError{Param: param, Reason: err.Error()}
+		}
+		values = append(values, value)
+	}
+
+	column := spec.Column
+	if column == "" {
+		column = field
+	}
+
+	return Condition{Field: field, Column: column, Op: op, Values: values}, nil
+}
+
+func convertValue(fieldType FieldType, raw string) (interface{}, error) {
+	switch fieldType {
+	case TypeInt:
+		value, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, errors.New("value must be a non-negative integer")
+		}
+		return value, nil
+	case TypeTime:
+		if value, err := time.Parse(time.RFC3339, raw); err == nil {
+			return value, nil
+		}
+		if value, err := time.Parse(time.DateOnly, raw); err == nil {
+			return value, nil
+		}
+		return nil, errors.New("value must be an RFC 3339 timestamp or YYYY-MM-DD date")
+	case TypeString:
+		return raw, nil
+	default:
+		return nil, errors.New("unsupported field type")
+	}
+}
+
+// ApplyFilter 필터를 GORM 쿼리에 적용 / Apply a filter to a GORM query
+// 컬럼명은 식별자로 인용되고 값은 바인딩 파라미터로 전달 / Columns are quoted and values are bound parameters
+func ApplyFilter(db *gorm.DB, filter Filter) *gorm.DB {
+	for _, cond := range filter {
+		db = db.Where(cond.Expression())
+	}
+	return db
+}
+
+// Expression 조건을 GORM 절 표현식으로 변환 / Convert the condition into a GORM clause expression
+func (c Condition) Expression() clause.Expression {
+	column := clause.Column{Name: c.Column}
+
+	switch c.Op {
+	case OpNe:
+		return clause.Neq{Column: column, Value: c.Values[0]}
+	case OpGt:
+		return clause.Gt{Column: column, Value: c.Values[0]}
+	case OpGte:
+		return clause.Gte{Column: column, Value: c.Values[0]}
+	case OpLt:
+		return clause.Lt{Column: column, Value: c.Values[0]}
+	case OpLte:
+		return clause.Lte{Column: column, Value: c.Values[0]}
+	case OpIn:
+		return clause.IN{Column: column, Values: c.Values}
+	case OpNin:
+		return clause.Not(clause.IN{Column: column, Values: c.Values})
+	case OpPrefix:
+		return likeExpression(column, escapeLike(c.Values[0])+"%")
+	case OpSuffix:
+		return likeExpression(column, "%"+escapeLike(c.Values[0]))
+	case OpContains:
+		return likeExpression(column, "%"+escapeLike(c.Values[0])+"%")
+	case OpEq:
+		fallthrough
+	default:
+		return clause.Eq{Column: column, Value: c.Values[0]}
+	}
+}
+
+// likeExpression 이스케이프 문자('!')를 명시한 LIKE 표현식 / LIKE expression with an explicit '!' escape character
+func likeExpression(column clause.Column, pattern string) clause.Expression {
+	return clause.Expr{SQL: "? LIKE ? ESCAPE '!'", Vars: []interface{}{column, pattern}}
+}
+
+// escapeLike LIKE 와일드카드 문자 이스케이프 / Escape LIKE wildcard characters
+func escapeLike(value interface{}) string {
+	s, _ := value.(string)
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
@@ -0,0 +1,137 @@
+package listquery
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type filterRecord struct {
+	ID        uint `gorm:"primarykey"`
+	Email     string
+	Status    string
+	CreatedAt time.Time
+}
+
+var testSchema = Schema{
+	"id":         {Type: TypeInt, Ops: RangeOps},
+	"email":      {Type: TypeString, Ops: TextOps},
+	"created_at": {Type: TypeTime, Ops: RangeOps},
+	"status": {Type: TypeString, Ops: EqualityOps, Validate: func(value string) error {
+		if value != "active" && value != "suspended" && value != "inactive" {
+			return errors.New("unknown status")
+		}
+		return nil
+	}},
+}
+
+func TestParseFilter(t *testing.T) {
+	filter, err := ParseFilter(map[string]string{
+		"created_at[gte]": "2024-01-01",
+		"status[in]":      "active, suspended",
+		"email[suffix]":   "@corp.com",
+		"limit":           "10",
+	}, testSchema)
+
+	require.NoError(t, err)
+	require.Len(t, filter, 3)
+	assert.Equal(t, Condition{
+		Field: "created_at", Column: "created_at", Op: OpGte,
+		Values: []interface{}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, filter[0])
+	assert.Equal(t, Condition{Field: "email", Column: "email", Op: OpSuffix, Values: []interface{}{"@corp.com"}}, filter[1])
+	assert.Equal(t, Condition{
+		Field: "status", Column: "status", Op: OpIn, Values: []interface{}{"active", "suspended"},
+	}, filter[2])
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		params map[string]string
+		reason string
+	}{
+		{name: "unknown field", params: map[string]string{"password[eq]": "x"}, reason: "unsupported field"},
+		{name: "unsupported operator", params: map[string]string{"status[gt]": "active"}, reason: "unsupported operator"},
+		{name: "bad integer", params: map[string]string{"id[gt]": "abc"}, reason: "non-negative integer"},
+		{name: "bad time", params: map[string]string{"created_at[lt]": "yesterday"}, reason: "RFC 3339"},
+		{name: "validator rejects", params: map[string]string{"status[in]": "active,pending"}, reason: "unknown status"},
+		{name: "empty value", params: map[string]string{"email[eq]": ""}, reason: "empty value"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseFilter(tc.params, testSchema)
+
+			require.ErrorIs(t, err, ErrInvalidFilter)
+			var filterErr *FilterError
+			require.ErrorAs(t, err, &filterErr)
+			assert.Contains(t, filterErr.Reason, tc.reason)
+		})
+	}
+}
+
+func TestApplyFilter(t *testing.T) {
+	database, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, database.AutoMigrate(&filterRecord{}))
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := []filterRecord{
+		{Email: "a@corp.com", Status: "active", CreatedAt: base},
+		{Email: "b@corp.com", Status: "suspended", CreatedAt: base.AddDate(0, 1, 0)},
+		{Email: "c@other.com", Status: "active", CreatedAt: base.AddDate(0, 2, 0)},
+		{Email: "100%_real@corp.com", Status: "inactive", CreatedAt: base.AddDate(0, 3, 0)},
+	}
+	require.NoError(t, database.Create(&records).Error)
+
+	testCases := []struct {
+		name   string
+		params map[string]string
+		want   []string
+	}{
+		{
+			name:   "set and suffix",
+			params: map[string]string{"status[in]": "active,suspended", "email[suffix]": "@corp.com"},
+			want:   []string{"a@corp.com", "b@corp.com"},
+		},
+		{
+			name:   "time range",
+			params: map[string]string{"created_at[gte]": "2024-02-01", "created_at[lt]": "2024-04-01"},
+			want:   []string{"b@corp.com", "c@other.com"},
+		},
+		{
+			name:   "not in",
+			params: map[string]string{"status[nin]": "active"},
+			want:   []string{"b@corp.com", "100%_real@corp.com"},
+		},
+		{
+			name:   "wildcards are literal",
+			params: map[string]string{"email[prefix]": "100%_"},
+			want:   []string{"100%_real@corp.com"},
+		},
+		{
+			name:   "wildcard does not match other rows",
+			params: map[string]string{"email[contains]": "_"},
+			want:   []string{"100%_real@corp.com"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := ParseFilter(tc.params, testSchema)
+			require.NoError(t, err)
+
+			var emails []string
+			err = ApplyFilter(database.Model(&filterRecord{}), filter).Order("id").Pluck("email", &emails).Error
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, emails)
+		})
+	}
+}
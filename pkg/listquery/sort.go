@@ -0,0 +1,90 @@
+package listquery
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidSort is returned when a sort expression references an unsupported field.
+var ErrInvalidSort = errors.New("invalid sort")
+
+// SortError 정렬 필드 오류 상세 / Sort field error details
+type SortError struct {
+	Field   string   `json:"field"`
+	Allowed []string `json:"allowed"`
+}
+
+// Error implements the error interface.
+func (e *SortError) Error() string {
+	return fmt.Sprintf("%s: unsupported field %q", ErrInvalidSort, e.Field)
+}
+
+// Unwrap lets errors.Is match ErrInvalidSort.
+func (e *SortError) Unwrap() error {
+	return ErrInvalidSort
+}
+
+// SortField 단일 정렬 조건 / Single sort condition
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort "name,-email" 형식의 정렬 식 파싱 / Parse a "name,-email" style sort expression
+// 허용 목록에 없는 필드는 SortError를 반환 / Fields outside the allowlist yield a SortError
+func ParseSort(raw string, allowed []string) ([]SortField, error) {
+	var fields []SortField
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field := SortField{Field: part}
+		if name, ok := strings.CutPrefix(part, "-"); ok {
+			field = SortField{Field: name, Desc: true}
+		} else if name, ok := strings.CutPrefix(part, "+"); ok {
+			field = SortField{Field: name}
+		}
+
+		if !slices.Contains(allowed, field.Field) {
+			return nil, &SortError{Field: field.Field, Allowed: allowed}
+		}
+		if seen[field.Field] {
+			continue
+		}
+		seen[field.Field] = true
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// WithTieBreaker 결정적 결과를 위해 고유 컬럼을 마지막에 추가 / Append a unique column for deterministic order
+// 마지막 정렬 방향을 따름 / Follows the direction of the last sort field
+func WithTieBreaker(fields []SortField, unique string) []SortField {
+	desc := false
+	for _, field := range fields {
+		if field.Field == unique {
+			return fields
+		}
+		desc = field.Desc
+	}
+	return append(fields, SortField{Field: unique, Desc: desc})
+}
+
+// ApplySort 정렬 조건을 GORM 쿼리에 적용 / Apply sort fields to a GORM query
+// 컬럼명은 식별자로 인용됨 / Column names are quoted as identifiers
+func ApplySort(db *gorm.DB, fields []SortField) *gorm.DB {
+	for _, field := range fields {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: field.Field}, Desc: field.Desc})
+	}
+	return db
+}
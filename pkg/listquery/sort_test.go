@@ -0,0 +1,66 @@
+package listquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSort(t *testing.T) {
+	allowed := []string{"id", "name", "email", "created_at"}
+
+	testCases := []struct {
+		name string
+		raw  string
+		want []SortField
+	}{
+		{
+			name: "mixed directions",
+			raw:  "name,-email,created_at",
+			want: []SortField{{Field: "name"}, {Field: "email", Desc: true}, {Field: "created_at"}},
+		},
+		{
+			name: "whitespace and duplicates",
+			raw:  " name , name,,-id",
+			want: []SortField{{Field: "name"}, {Field: "id", Desc: true}},
+		},
+		{
+			name: "explicit ascending prefix",
+			raw:  "+email",
+			want: []SortField{{Field: "email"}},
+		},
+		{
+			name: "empty",
+			raw:  "",
+			want: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseSort(tc.raw, allowed)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseSort_RejectsUnknownField(t *testing.T) {
+	_, err := ParseSort("name,-password", []string{"name"})
+
+	require.ErrorIs(t, err, ErrInvalidSort)
+	var sortErr *SortError
+	require.ErrorAs(t, err, &sortErr)
+	assert.Equal(t, "password", sortErr.Field)
+	assert.Equal(t, []string{"name"}, sortErr.Allowed)
+}
+
+func TestWithTieBreaker(t *testing.T) {
+	assert.Equal(t,
+		[]SortField{{Field: "created_at", Desc: true}, {Field: "id", Desc: true}},
+		WithTieBreaker([]SortField{{Field: "created_at", Desc: true}}, "id"))
+	assert.Equal(t,
+		[]SortField{{Field: "id"}, {Field: "name"}},
+		WithTieBreaker([]SortField{{Field: "id"}, {Field: "name"}}, "id"))
+}
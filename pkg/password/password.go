@@ -0,0 +1,208 @@
+// Package password hashes passwords with argon2id and verifies argon2id and legacy bcrypt hashes
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	saltLength = 16
+	keyLength  = 32
+
+	// slotWait 해시 슬롯을 기다리는 최대 시간 / Longest wait for a hashing slot
+	slotWait = time.Second
+)
+
+// ErrUnsupportedHash is returned when a stored hash is neither argon2id nor bcrypt.
+var ErrUnsupportedHash = errors.New("unsupported password hash")
+
+// ErrBusy is returned when every hashing slot stays taken for the whole wait.
+var ErrBusy = errors.New("password hasher busy")
+
+// Params argon2id 비용 설정 / argon2id cost settings
+type Params struct {
+	// Memory KiB 단위 메모리 / Memory in KiB
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// DefaultParams RFC 9106 두 번째 권장값 / The second RFC 9106 recommendation
+var DefaultParams = Params{Memory: 64 * 1024, Iterations: 3, Parallelism: 4}
+
+// Hasher 비밀번호 해시 인터페이스 / Password hashing interface
+type Hasher interface {
+	// Hash 현재 설정의 argon2id PHC 문자열 생성 / Produce an argon2id PHC string with the current settings
+	Hash(password string) (string, error)
+	// Verify 비밀번호 확인, rehash가 true이면 현재 설정으로 다시 해시해 저장해야 함
+	// Check the password; when rehash is true the caller should store a fresh hash with the current settings
+	Verify(encoded, password string) (ok bool, rehash bool, err error)
+	// Burn 존재하지 않는 계정에도 같은 시간을 쓰도록 해시 한 번 계산 / Spend one hash worth of time so unknown accounts take as long as known ones
+	Burn(password string) error
+}
+
+// hasher argon2id 구현체 / argon2id implementation
+type hasher struct {
+	params Params
+	// slots 동시 해시 계산 제한, nil이면 무제한 / Limits concurrent hash computations; nil means unlimited
+	slots chan struct{}
+	wait  time.Duration
+
+	dummyMu sync.Mutex
+	dummy   string
+}
+
+// NewHasher 새 해시기 생성 / Create new hasher
+// maxConcurrent개를 넘는 해시 계산은 잠시 기다린 뒤 ErrBusy, 0이면 무제한
+// Hash computations beyond maxConcurrent wait briefly and then fail with ErrBusy; 0 means unlimited
+func NewHasher(params Params, maxConcurrent int) Hasher {
+	h := &hasher{params: params, wait: slotWait}
+	if maxConcurrent > 0 {
+		h.slots = make(chan struct{}, maxConcurrent)
+	}
+	return h
+}
+
+// acquire 해시 슬롯 확보, 반환된 함수로 해제 / Take a hashing slot; the returned func gives it back
+// argon2id는 해시마다 Memory KiB를 쓰므로 동시 계산 수가 곧 메모리 사용량
+// Each argon2id hash allocates Memory KiB, so the number of concurrent hashes bounds memory use
+func (h *hasher) acquire() (func(), error) {
+	if h.slots == nil {
+		return func() {}, nil
+	}
+
+	timer := time.NewTimer(h.wait)
+	defer timer.Stop()
+
+	select {
+	case h.slots <- struct{}{}:
+		return func() { <-h.slots }, nil
+	case <-timer.C:
+		return nil, ErrBusy
+	}
+}
+
+// Hash 비밀번호 해시 / Hash password
+func (h *hasher) Hash(password string) (string, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	release, err := h.acquire()
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, keyLength)
+	return encode(h.params, salt, key), nil
+}
+
+// Verify 비밀번호 확인 / Verify password
+func (h *hasher) Verify(encoded, password string) (bool, bool, error) {
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		params, salt, key, err := decode(encoded)
+		if err != nil {
+			return false, false, err
+		}
+		release, err := h.acquire()
+		if err != nil {
+			return false, false, err
+		}
+		defer release()
+
+		candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(candidate, key) != 1 {
+			return false, false, nil
+		}
+		return true, params != h.params || len(key) != keyLength, nil
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		// bcrypt 해시는 확인 후 argon2id로 교체 / bcrypt hashes are replaced with argon2id once verified
+		release, err := h.acquire()
+		if err != nil {
+			return false, false, err
+		}
+		defer release()
+
+		err = bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, false, nil
+		}
+		if err != nil {
+			return false, false, fmt.Errorf("failed to verify bcrypt hash: %w", err)
+		}
+		return true, true, nil
+	default:
+		return false, false, ErrUnsupportedHash
+	}
+}
+
+// Burn 버리는 해시 계산 / Compute a throwaway hash
+func (h *hasher) Burn(password string) error {
+	dummy, err := h.dummyHash()
+	if err != nil {
+		return err
+	}
+	_, _, err = h.Verify(dummy, password)
+	return err
+}
+
+// dummyHash 버리는 해시용 저장 해시, 실패하면 다음 호출에서 다시 생성 / Stored hash for Burn; regenerated on the next call if creating it failed
+func (h *hasher) dummyHash() (string, error) {
+	h.dummyMu.Lock()
+	defer h.dummyMu.Unlock()
+
+	if h.dummy == "" {
+		dummy, err := h.Hash("not-a-real-password")
+		if err != nil {
+			return "", err
+		}
+		h.dummy = dummy
+	}
+	return h.dummy, nil
+}
+
+// encode PHC 문자열 형식 / PHC string format
+// $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>
+func encode(params Params, salt, key []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+func decode(encoded string) (Params, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return Params{}, nil, nil, fmt.Errorf("%w: malformed argon2id hash", ErrUnsupportedHash)
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Params{}, nil, nil, fmt.Errorf("%w: argon2 version %q", ErrUnsupportedHash, parts[2])
+	}
+	var params Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return Params{}, nil, nil, fmt.Errorf("%w: malformed argon2id parameters", ErrUnsupportedHash)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Params{}, nil, nil, fmt.Errorf("%w: malformed argon2id salt", ErrUnsupportedHash)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Params{}, nil, nil, fmt.Errorf("%w: malformed argon2id key", ErrUnsupportedHash)
+	}
+	return params, salt, key, nil
+}
@@ -0,0 +1,96 @@
+package password
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testParams 테스트를 빠르게 하는 낮은 비용 / Low cost that keeps tests fast
+var testParams = Params{Memory: 1024, Iterations: 1, Parallelism: 1}
+
+func TestHasher_HashAndVerify(t *testing.T) {
+	h := NewHasher(testParams, 0)
+
+	encoded, err := h.Hash("correct horse battery staple")
+	require.NoError(t, err)
+	assert.Regexp(t, `^\$argon2id\$v=19\$m=1024,t=1,p=1\$[A-Za-z0-9+/]+\$[A-Za-z0-9+/]+$`, encoded)
+
+	other, err := h.Hash("correct horse battery staple")
+	require.NoError(t, err)
+	assert.NotEqual(t, encoded, other, "salts must differ")
+
+	ok, rehash, err := h.Verify(encoded, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, rehash)
+
+	ok, _, err = h.Verify(encoded, "Correct horse battery staple")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestHasher_Rehash(t *testing.T) {
+	old, err := NewHasher(testParams, 0).Hash("secret-password")
+	require.NoError(t, err)
+	legacy, err := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		encoded    string
+		params     Params
+		wantOK     bool
+		wantRehash bool
+		wantErr    error
+	}{
+		{name: "same parameters", encoded: old, params: testParams, wantOK: true},
+		{name: "raised memory", encoded: old, params: Params{Memory: 2048, Iterations: 1, Parallelism: 1}, wantOK: true, wantRehash: true},
+		{name: "raised iterations", encoded: old, params: Params{Memory: 1024, Iterations: 2, Parallelism: 1}, wantOK: true, wantRehash: true},
+		{name: "legacy bcrypt", encoded: string(legacy), params: testParams, wantOK: true, wantRehash: true},
+		{name: "unknown scheme", encoded: "plaintext", params: testParams, wantErr: ErrUnsupportedHash},
+		{name: "malformed argon2id", encoded: "$argon2id$v=19$m=1024$salt", params: testParams, wantErr: ErrUnsupportedHash},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, rehash, err := NewHasher(tc.params, 0).Verify(tc.encoded, "secret-password")
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantRehash, rehash)
+		})
+	}
+}
+
+func TestHasher_LimitsConcurrency(t *testing.T) {
+	encoded, err := NewHasher(testParams, 0).Hash("secret-password")
+	require.NoError(t, err)
+
+	h := NewHasher(testParams, 1).(*hasher)
+	h.wait = 10 * time.Millisecond
+
+	// 유일한 슬롯을 점유하면 모든 계산이 ErrBusy / With the only slot taken every computation fails with ErrBusy
+	h.slots <- struct{}{}
+
+	_, err = h.Hash("secret-password")
+	assert.ErrorIs(t, err, ErrBusy)
+	_, _, err = h.Verify(encoded, "secret-password")
+	assert.ErrorIs(t, err, ErrBusy)
+	assert.ErrorIs(t, h.Burn("secret-password"), ErrBusy)
+
+	// 슬롯이 풀리면 다시 계산 / Computations resume once the slot is free
+	<-h.slots
+	ok, _, err := h.Verify(encoded, "secret-password")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, h.Burn("secret-password"))
+	assert.Empty(t, h.slots)
+}
@@ -30,9 +30,12 @@ type PaginatedResponse struct {
 
 // Pagination 페이지네이션 정보 / Pagination information
 type Pagination struct {
-	Offset int   `json:"offset"`
-	Limit  int   `json:"limit"`
-	Total  int64 `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	// Total 전체 개수, 커서 기반 페이지네이션에서는 생략 / Total count; omitted with cursor-based pagination
+	Total      *int64 `json:"total,omitempty"`
+	Cursor     string `json:"cursor,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // Success 성공 응답 반환 / Return success response
@@ -47,11 +50,20 @@ func SuccessWithPagination(c *fiber.Ctx, data interface{}, offset, limit int, to
 		Pagination: Pagination{
 			Offset: offset,
 			Limit:  limit,
-			Total:  total,
+			Total:  &total,
 		},
 	})
 }
 
+// SuccessWithPage 페이지 정보 전체와 함께 성공 응답 반환 / Return success response with full pagination info
+// 커서 기반 페이지네이션에서 사용 / Used by cursor-based pagination
+func SuccessWithPage(c *fiber.Ctx, data interface{}, page Pagination) error {
+	return c.JSON(PaginatedResponse{
+		Data:       data,
+		Pagination: page,
+	})
+}
+
 // Error 에러 응답 반환 / Return error response
 func Error(c *fiber.Ctx, status int, code, message string, details ...interface{}) error {
 	errResp := ErrorResponse{
@@ -98,7 +110,32 @@ func Conflict(c *fiber.Ctx, message string, details ...interface{}) error {
 	return Error(c, fiber.StatusConflict, "CONFLICT", message, details...)
 }
 
+// PreconditionFailed 412 에러 응답 / Return 412 error response
+func PreconditionFailed(c *fiber.Ctx, message string, details ...interface{}) error {
+	return Error(c, fiber.StatusPreconditionFailed, "PRECONDITION_FAILED", message, details...)
+}
+
 // UnprocessableEntity 422 에러 응답 / Return 422 error response
 func UnprocessableEntity(c *fiber.Ctx, message string, details ...interface{}) error {
 	return Error(c, fiber.StatusUnprocessableEntity, "UNPROCESSABLE_ENTITY", message, details...)
 }
+
+// UnsupportedMediaType 415 에러 응답 / Return 415 error response
+func UnsupportedMediaType(c *fiber.Ctx, message string, details ...interface{}) error {
+	return Error(c, fiber.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", message, details...)
+}
+
+// TooManyRequests 429 에러 응답 / Return 429 error response
+func TooManyRequests(c *fiber.Ctx, message string, details ...interface{}) error {
+	return Error(c, fiber.StatusTooManyRequests, "TOO_MANY_REQUESTS", message, details...)
+}
+
+// ServiceUnavailable 503 에러 응답 / Return 503 error response
+func ServiceUnavailable(c *fiber.Ctx, message string, details ...interface{}) error {
+	return Error(c, fiber.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", message, details...)
+}
+
+// GatewayTimeout 504 에러 응답 / Return 504 error response
+func GatewayTimeout(c *fiber.Ctx, message string, details ...interface{}) error {
+	return Error(c, fiber.StatusGatewayTimeout, "GATEWAY_TIMEOUT", message, details...)
+}